@@ -2,53 +2,170 @@ package api
 
 import (
 	"context"
+	"fmt"
 	"net/http"
+	"time"
 
+	"github.com/ONSdigital/dp-legacy-cache-api/config"
+	"github.com/ONSdigital/dp-legacy-cache-api/models"
+	"github.com/ONSdigital/dp-legacy-cache-api/tracing"
 	dphandlers "github.com/ONSdigital/dp-net/v3/handlers"
 	"github.com/gorilla/mux"
+	"go.opentelemetry.io/otel/trace"
 )
 
 // API provides a struct to wrap the api around
 type API struct {
-	Router          *mux.Router
-	dataStore       DataStore
-	identityHandler func(http.Handler) http.Handler
+	Router             *mux.Router
+	dataStore          DataStore
+	publisher          EventPublisher
+	identityHandler    func(http.Handler) http.Handler
+	defaultLimit       int
+	defaultOffset      int
+	maxLimit           int
+	idPathWarnOnly     bool
+	cacheControlPolicy models.CacheControlPolicy
+	cacheControl       string
+	maxUpcomingWindow  time.Duration
+	batchMaxItems      int
+	batchMaxBodySize   int64
 }
 
 // Setup function sets up the api and returns an API
-func Setup(ctx context.Context, isPublishing bool, r *mux.Router, dataStore DataStore, identityHandler func(http.Handler) http.Handler) *API {
+func Setup(_ context.Context, cfg *config.Config, r *mux.Router, dataStore DataStore, publisher EventPublisher, identityHandler func(http.Handler) http.Handler) *API {
 	api := &API{
 		Router:          r,
 		dataStore:       dataStore,
+		publisher:       publisher,
 		identityHandler: identityHandler,
+		defaultLimit:    cfg.DefaultLimit,
+		defaultOffset:   cfg.DefaultOffset,
+		maxLimit:        cfg.DefaultMaxLimit,
+		idPathWarnOnly:  cfg.IDPathValidationWarnOnly,
+		cacheControlPolicy: models.CacheControlPolicy{
+			DefaultMaxAge: cfg.DefaultMaxAge,
+			MinimumMaxAge: cfg.MinimumMaxAge,
+			MaximumMaxAge: cfg.MaximumMaxAge,
+		},
+		cacheControl:      responseCacheControl(cfg),
+		maxUpcomingWindow: cfg.UpcomingReleasesMaxWindow,
+		batchMaxItems:     cfg.BatchMaxItems,
+		batchMaxBodySize:  cfg.BatchMaxBodySize,
+	}
+
+	api.get(
+		"/v1/cache-times",
+		func(w http.ResponseWriter, req *http.Request) { api.GetCacheTimeByPath(req.Context(), w, req) },
+	).Queries("path", "{path}")
+
+	api.get(
+		"/v1/cache-times",
+		func(w http.ResponseWriter, req *http.Request) { api.GetCacheTimes(req.Context(), w, req) },
+	)
+
+	// Registered ahead of /v1/cache-times/{id}, which would otherwise match it
+	if cfg.IsPublishing {
+		api.get(
+			"/v1/cache-times/export",
+			api.isAuthenticated(func(w http.ResponseWriter, req *http.Request) { api.ExportCacheTimes(req.Context(), w, req) }),
+		)
 	}
 
 	api.get(
 		"/v1/cache-times/{id}",
-		func(w http.ResponseWriter, req *http.Request) { api.GetCacheTime(ctx, w, req) },
+		func(w http.ResponseWriter, req *http.Request) { api.GetCacheTime(req.Context(), w, req) },
 	)
 
-	if isPublishing {
+	api.get(
+		"/v1/cache-control/{id}",
+		func(w http.ResponseWriter, req *http.Request) { api.GetCacheControl(req.Context(), w, req) },
+	)
+
+	api.get(
+		"/v1/releases/upcoming",
+		func(w http.ResponseWriter, req *http.Request) { api.GetUpcomingReleases(req.Context(), w, req) },
+	)
+
+	if cfg.IsPublishing {
+		api.put(
+			"/v1/cache-times",
+			api.isAuthenticated(func(w http.ResponseWriter, req *http.Request) {
+				api.CreateOrUpdateCacheTimeByPath(req.Context(), w, req)
+			}),
+		)
+
 		api.put(
 			"/v1/cache-times/{id}",
-			api.isAuthenticated(func(w http.ResponseWriter, req *http.Request) { api.CreateOrUpdateCacheTime(ctx, w, req) }),
+			api.isAuthenticated(func(w http.ResponseWriter, req *http.Request) { api.CreateOrUpdateCacheTime(req.Context(), w, req) }),
+		)
+
+		api.post(
+			"/v1/cache-times/batch",
+			api.isAuthenticated(func(w http.ResponseWriter, req *http.Request) { api.UpsertCacheTimes(req.Context(), w, req) }),
+		)
+
+		api.delete(
+			"/v1/cache-times/{id}",
+			api.isAuthenticated(func(w http.ResponseWriter, req *http.Request) { api.DeleteCacheTime(req.Context(), w, req) }),
+		)
+
+		api.get(
+			"/v1/cache-times/{id}/history",
+			api.isAuthenticated(func(w http.ResponseWriter, req *http.Request) { api.GetCacheTimeHistory(req.Context(), w, req) }),
+		)
+
+		api.put(
+			"/v1/collections/{collection_id}/release-time",
+			api.isAuthenticated(func(w http.ResponseWriter, req *http.Request) { api.UpdateCollectionReleaseTime(req.Context(), w, req) }),
+		)
+
+		api.delete(
+			"/v1/collections/{collection_id}/cache-times",
+			api.isAuthenticated(func(w http.ResponseWriter, req *http.Request) { api.DeleteCollectionCacheTimes(req.Context(), w, req) }),
 		)
 	}
 
 	return api
 }
 
+// responseCacheControl returns the Cache-Control header value of read responses. Web instances let upstream caches
+// serve them for a short while; publishing instances make caches revalidate every time, as editors expect to read
+// their own changes straight away.
+func responseCacheControl(cfg *config.Config) string {
+	if cfg.IsPublishing {
+		return "no-cache"
+	}
+	return fmt.Sprintf("public, max-age=%d", int(cfg.ResponseMaxAge/time.Second))
+}
+
+// isAuthenticated only calls handler for requests with a valid identity. The identity check is traced in a span of its
+// own, which ends before handler is called so that the spans of handler are children of the request span.
 func (api *API) isAuthenticated(handler http.HandlerFunc) http.HandlerFunc {
 	return func(w http.ResponseWriter, req *http.Request) {
-		checkIdentityHandler := api.identityHandler(dphandlers.CheckIdentity(handler))
-		checkIdentityHandler.ServeHTTP(w, req)
+		requestSpan := trace.SpanFromContext(req.Context())
+		ctx, span := tracing.Tracer().Start(req.Context(), "identity check")
+		defer span.End()
+
+		checkIdentityHandler := api.identityHandler(dphandlers.CheckIdentity(func(w http.ResponseWriter, req *http.Request) {
+			span.End()
+			handler(w, req.WithContext(trace.ContextWithSpan(req.Context(), requestSpan)))
+		}))
+		checkIdentityHandler.ServeHTTP(w, req.WithContext(ctx))
 	}
 }
 
-func (api *API) get(path string, handler http.HandlerFunc) {
-	api.Router.HandleFunc(path, handler).Methods(http.MethodGet)
+func (api *API) get(path string, handler http.HandlerFunc) *mux.Route {
+	return api.Router.HandleFunc(path, handler).Methods(http.MethodGet)
 }
 
 func (api *API) put(path string, handler http.HandlerFunc) {
 	api.Router.HandleFunc(path, handler).Methods(http.MethodPut)
 }
+
+func (api *API) post(path string, handler http.HandlerFunc) {
+	api.Router.HandleFunc(path, handler).Methods(http.MethodPost)
+}
+
+func (api *API) delete(path string, handler http.HandlerFunc) {
+	api.Router.HandleFunc(path, handler).Methods(http.MethodDelete)
+}
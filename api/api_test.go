@@ -1,15 +1,23 @@
 package api_test
 
 import (
+	"bytes"
 	"context"
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/ONSdigital/dp-legacy-cache-api/api"
 	"github.com/ONSdigital/dp-legacy-cache-api/api/mock"
+	"github.com/ONSdigital/dp-legacy-cache-api/config"
+	"github.com/ONSdigital/dp-legacy-cache-api/models"
 	"github.com/gorilla/mux"
 	. "github.com/smartystreets/goconvey/convey"
+	"go.opentelemetry.io/otel"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
 )
 
 func TestSetup(t *testing.T) {
@@ -20,17 +28,80 @@ func TestSetup(t *testing.T) {
 			cacheAPI := setupPublishingAPI(mockMongoDB)
 
 			Convey("Then all the routes should be available", func() {
+				So(hasRoute(cacheAPI.Router, "/v1/cache-times", "GET"), ShouldBeTrue)
 				So(hasRoute(cacheAPI.Router, "/v1/cache-times/{id}", "GET"), ShouldBeTrue)
+				So(hasRoute(cacheAPI.Router, "/v1/cache-times?path=/a", "GET"), ShouldBeTrue)
+				So(hasRoute(cacheAPI.Router, "/v1/cache-control/{id}", "GET"), ShouldBeTrue)
+				So(hasRoute(cacheAPI.Router, "/v1/releases/upcoming", "GET"), ShouldBeTrue)
+				So(hasRoute(cacheAPI.Router, "/v1/cache-times", "PUT"), ShouldBeTrue)
 				So(hasRoute(cacheAPI.Router, "/v1/cache-times/{id}", "PUT"), ShouldBeTrue)
+				So(hasRoute(cacheAPI.Router, "/v1/cache-times/{id}", "DELETE"), ShouldBeTrue)
+				So(hasRoute(cacheAPI.Router, "/v1/cache-times/batch", "POST"), ShouldBeTrue)
+				So(hasRoute(cacheAPI.Router, "/v1/cache-times/{id}/history", "GET"), ShouldBeTrue)
+				So(hasRoute(cacheAPI.Router, "/v1/cache-times/export", "GET"), ShouldBeTrue)
+				So(hasRoute(cacheAPI.Router, "/v1/collections/{collection_id}/release-time", "PUT"), ShouldBeTrue)
+				So(hasRoute(cacheAPI.Router, "/v1/collections/{collection_id}/cache-times", "DELETE"), ShouldBeTrue)
 			})
 		})
 
 		Convey("When created in web subnet", func() {
 			cacheAPI := setupWebAPI(mockMongoDB)
 
-			Convey("Then the PUT endpoint should not have been added", func() {
+			Convey("Then the write endpoints should not have been added", func() {
+				So(hasRoute(cacheAPI.Router, "/v1/cache-times", "GET"), ShouldBeTrue)
 				So(hasRoute(cacheAPI.Router, "/v1/cache-times/{id}", "GET"), ShouldBeTrue)
+				So(hasRoute(cacheAPI.Router, "/v1/cache-times?path=/a", "GET"), ShouldBeTrue)
+				So(hasRoute(cacheAPI.Router, "/v1/cache-control/{id}", "GET"), ShouldBeTrue)
+				So(hasRoute(cacheAPI.Router, "/v1/releases/upcoming", "GET"), ShouldBeTrue)
+				So(hasRoute(cacheAPI.Router, "/v1/cache-times", "PUT"), ShouldBeFalse)
 				So(hasRoute(cacheAPI.Router, "/v1/cache-times/{id}", "PUT"), ShouldBeFalse)
+				So(hasRoute(cacheAPI.Router, "/v1/cache-times/{id}", "DELETE"), ShouldBeFalse)
+				So(hasRoute(cacheAPI.Router, "/v1/cache-times/batch", "POST"), ShouldBeFalse)
+				So(hasRoute(cacheAPI.Router, "/v1/cache-times/{id}/history", "GET"), ShouldBeFalse)
+				So(hasRoute(cacheAPI.Router, "/v1/collections/{collection_id}/release-time", "PUT"), ShouldBeFalse)
+				So(hasRoute(cacheAPI.Router, "/v1/collections/{collection_id}/cache-times", "DELETE"), ShouldBeFalse)
+			})
+		})
+	})
+}
+
+func TestIsAuthenticatedTracing(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	previous := otel.GetTracerProvider()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	defer otel.SetTracerProvider(previous)
+
+	Convey("Given a publishing API", t, func() {
+		var dataStoreSpan trace.SpanContext
+		mockMongoDB := &mock.DataStoreMock{
+			UpsertCacheTimeFunc: func(ctx context.Context, cacheTime *models.CacheTime, precondition models.Precondition) (*models.CacheTimeChange, error) {
+				dataStoreSpan = trace.SpanContextFromContext(ctx)
+				return upsertChange(cacheTime, cacheTime), nil
+			},
+		}
+		cacheAPI := setupPublishingAPI(mockMongoDB)
+
+		Convey("When an authenticated request is made within a request span", func() {
+			request := newRequestWithAuth(http.MethodPut, baseURL+testCacheID, bytes.NewBufferString(validBody))
+			ctx, requestSpan := otel.Tracer("test").Start(request.Context(), "request")
+			responseRecorder := httptest.NewRecorder()
+			cacheAPI.Router.ServeHTTP(responseRecorder, request.WithContext(ctx))
+			requestSpan.End()
+			So(responseRecorder.Code, ShouldEqual, http.StatusNoContent)
+
+			Convey("Then the identity check is traced as a child of the request span", func() {
+				var identitySpan sdktrace.ReadOnlySpan
+				for _, span := range recorder.Ended() {
+					if span.Name() == "identity check" {
+						identitySpan = span
+					}
+				}
+				So(identitySpan, ShouldNotBeNil)
+				So(identitySpan.Parent().SpanID(), ShouldEqual, requestSpan.SpanContext().SpanID())
+
+				Convey("And the handler runs in the request span rather than the identity check span", func() {
+					So(dataStoreSpan.SpanID(), ShouldEqual, requestSpan.SpanContext().SpanID())
+				})
 			})
 		})
 	})
@@ -43,11 +114,45 @@ func hasRoute(r *mux.Router, path, method string) bool {
 }
 
 func setupAPI(isPublishing bool, dataStore api.DataStore) *api.API {
+	return setupAPIWithConfig(newTestConfig(isPublishing), dataStore)
+}
+
+func setupAPIWithConfig(cfg *config.Config, dataStore api.DataStore) *api.API {
+	publisher := &mock.EventPublisherMock{
+		PublishFunc: func(ctx context.Context, change *models.CacheTimeChange) error { return nil },
+	}
+
+	return setupAPIWithPublisher(cfg, dataStore, publisher)
+}
+
+func setupAPIWithPublisher(cfg *config.Config, dataStore api.DataStore, publisher api.EventPublisher) *api.API {
 	mockIdentityHandler := func(h http.Handler) http.Handler {
 		return h
 	}
 
-	return api.Setup(context.Background(), isPublishing, mux.NewRouter(), dataStore, mockIdentityHandler)
+	return api.Setup(context.Background(), cfg, mux.NewRouter(), dataStore, publisher, mockIdentityHandler)
+}
+
+// upsertChange returns the change reported by a data store upserting cacheTime over previous, which is nil when the
+// cache time is created
+func upsertChange(previous, cacheTime *models.CacheTime) *models.CacheTimeChange {
+	return models.NewCacheTimeChange(previous, cacheTime, "", time.Now())
+}
+
+func newTestConfig(isPublishing bool) *config.Config {
+	return &config.Config{
+		IsPublishing:              isPublishing,
+		DefaultLimit:              20,
+		DefaultMaxLimit:           1000,
+		DefaultOffset:             0,
+		DefaultMaxAge:             15 * time.Minute,
+		MinimumMaxAge:             5 * time.Second,
+		MaximumMaxAge:             24 * time.Hour,
+		ResponseMaxAge:            10 * time.Second,
+		UpcomingReleasesMaxWindow: 7 * 24 * time.Hour,
+		BatchMaxItems:             5,
+		BatchMaxBodySize:          2048,
+	}
 }
 
 func setupPublishingAPI(dataStore api.DataStore) *api.API {
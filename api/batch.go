@@ -0,0 +1,197 @@
+package api
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+
+	"github.com/ONSdigital/dp-legacy-cache-api/models"
+	"github.com/ONSdigital/log.go/v2/log"
+)
+
+const ndjsonContentType = "application/x-ndjson"
+
+// UpsertCacheTimes creates or updates a batch of cache times, reporting the outcome of each item. Invalid items are
+// reported individually and do not prevent the valid items from being stored. Batches larger than the configured
+// maximum number of items or body size, or giving the same id more than once, are rejected as a whole before anything
+// is stored.
+func (api *API) UpsertCacheTimes(ctx context.Context, w http.ResponseWriter, req *http.Request) {
+	log.Info(ctx, "calling upsert cache times handler")
+
+	// Check request body not empty
+	if req.ContentLength == 0 {
+		log.Info(ctx, "upsertCacheTimes endpoint: empty request body")
+		sendJSONError(ctx, w, http.StatusBadRequest, "bad request: empty request body")
+		return
+	}
+
+	req.Body = http.MaxBytesReader(w, req.Body, api.batchMaxBodySize)
+	items, err := readBatchItems(req, api.batchMaxItems)
+	var maxBytesErr *http.MaxBytesError
+	switch {
+	case errors.As(err, &maxBytesErr):
+		log.Info(ctx, "upsertCacheTimes endpoint: request body too large", log.Data{"limit": maxBytesErr.Limit})
+		sendJSONError(ctx, w, http.StatusRequestEntityTooLarge, fmt.Sprintf("request too large: body exceeds %d bytes", maxBytesErr.Limit))
+		return
+	case errors.Is(err, errTooManyItems):
+		log.Info(ctx, "upsertCacheTimes endpoint: too many items", log.Data{"limit": api.batchMaxItems})
+		sendJSONError(ctx, w, http.StatusRequestEntityTooLarge, fmt.Sprintf("request too large: batch exceeds %d items", api.batchMaxItems))
+		return
+	case err != nil:
+		log.Info(ctx, "upsertCacheTimes endpoint: error reading request body")
+		sendJSONError(ctx, w, http.StatusBadRequest, fmt.Sprintf("bad request: %v", err))
+		return
+	}
+	if len(items) == 0 {
+		log.Info(ctx, "upsertCacheTimes endpoint: empty batch")
+		sendJSONError(ctx, w, http.StatusBadRequest, "bad request: empty batch")
+		return
+	}
+
+	result := models.BatchResult{Items: make([]models.BatchItemResult, len(items))}
+	var valid []*models.CacheTime
+	var validIndexes []int
+	var repeated []error
+	seen := make(map[string]int)
+
+	for i, item := range items {
+		cacheTime := &models.CacheTime{}
+		err = decodeCacheTime(bytes.NewReader(item), cacheTime)
+		if err == nil {
+			err = api.isValidCacheTime(ctx, cacheTime)
+		} else {
+			err = fmt.Errorf("bad request: %w", err)
+		}
+
+		result.Items[i].ID = cacheTime.ID
+		if err != nil {
+			result.Items[i].Status = models.BatchItemInvalid
+			result.Items[i].Error = err.Error()
+			result.Invalid++
+			continue
+		}
+
+		cacheTime.Path = models.NormalisePath(cacheTime.Path)
+
+		if first, ok := seen[cacheTime.ID]; ok {
+			repeated = append(repeated, fmt.Errorf("item %d repeats the id of item %d", i, first))
+			continue
+		}
+		seen[cacheTime.ID] = i
+
+		valid = append(valid, cacheTime)
+		validIndexes = append(validIndexes, i)
+	}
+
+	// The items of a batch are not written in order, so an id given more than once would leave either value stored
+	if len(repeated) > 0 {
+		log.Info(ctx, "upsertCacheTimes endpoint: repeated ids", log.Data{"repeated": len(repeated)})
+		sendJSONError(ctx, w, http.StatusBadRequest, fmt.Sprintf("bad request: repeated ids: %s", formatErrorList(repeated)))
+		return
+	}
+
+	if len(valid) > 0 {
+		upserted, err := api.dataStore.UpsertCacheTimes(ctx, valid)
+		if err != nil {
+			log.Error(ctx, "upsertCacheTimes endpoint: error upserting documents", err)
+			sendJSONError(ctx, w, http.StatusInternalServerError, err.Error())
+			return
+		}
+
+		for i, index := range validIndexes {
+			switch {
+			case upserted[i].Err != nil:
+				result.Items[index].Status = models.BatchItemFailed
+				result.Items[index].Error = upserted[i].Err.Error()
+				result.Failed++
+			case upserted[i].Change.Action == models.CacheTimeCreated:
+				result.Items[index].Status = models.BatchItemCreated
+				result.Created++
+			default:
+				result.Items[index].Status = models.BatchItemUpdated
+				result.Updated++
+			}
+			if upserted[i].Change != nil {
+				api.publish(ctx, upserted[i].Change)
+			}
+		}
+	}
+
+	log.Info(ctx, "upsertCacheTimes endpoint: batch processed", log.Data{"created": result.Created, "updated": result.Updated, "invalid": result.Invalid, "failed": result.Failed})
+
+	if err := json.NewEncoder(w).Encode(result); err != nil {
+		log.Error(ctx, "error encoding results to JSON", err)
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		return
+	}
+}
+
+// errTooManyItems is returned by readBatchItems when the batch has more items than allowed
+var errTooManyItems = errors.New("too many items")
+
+// readBatchItems splits the request body into its raw items, which is either a JSON array, with nothing after it, or,
+// when the content type is NDJSON, one JSON document per line. Reading stops with errTooManyItems as soon as there are more than maxItems.
+func readBatchItems(req *http.Request, maxItems int) ([]json.RawMessage, error) {
+	var items []json.RawMessage
+	add := func(item json.RawMessage) error {
+		if len(items) == maxItems {
+			return errTooManyItems
+		}
+		items = append(items, item)
+		return nil
+	}
+
+	mediaType, _, _ := mime.ParseMediaType(req.Header.Get("Content-Type"))
+	if mediaType != ndjsonContentType {
+		decoder := json.NewDecoder(req.Body)
+		token, err := decoder.Token()
+		if err != nil {
+			return nil, err
+		}
+		if token != json.Delim('[') {
+			return nil, errors.New("body is not a JSON array")
+		}
+		for decoder.More() {
+			var item json.RawMessage
+			if err := decoder.Decode(&item); err != nil {
+				return nil, err
+			}
+			if err := add(item); err != nil {
+				return nil, err
+			}
+		}
+		if _, err := decoder.Token(); err != nil {
+			return nil, err
+		}
+		switch _, err := decoder.Token(); {
+		case errors.Is(err, io.EOF):
+			return items, nil
+		case err != nil:
+			return nil, err
+		default:
+			return nil, errors.New("body has data after the JSON array")
+		}
+	}
+
+	reader := bufio.NewReader(req.Body)
+	for {
+		line, err := reader.ReadBytes('\n')
+		if err != nil && !errors.Is(err, io.EOF) {
+			return nil, err
+		}
+		if line = bytes.TrimSpace(line); len(line) > 0 {
+			if addErr := add(line); addErr != nil {
+				return nil, addErr
+			}
+		}
+		if err != nil {
+			return items, nil
+		}
+	}
+}
@@ -0,0 +1,296 @@
+package api_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/ONSdigital/dp-legacy-cache-api/api/mock"
+	errs "github.com/ONSdigital/dp-legacy-cache-api/apierrors"
+	"github.com/ONSdigital/dp-legacy-cache-api/models"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+var batchURL = "http://localhost:29100/v1/cache-times/batch"
+var otherCacheID = "950327f5e8f6f30b26aee02d2abf5373" // MD5 hash of "/newpath"
+
+func TestUpsertCacheTimes(t *testing.T) {
+	Convey("Given a datastore containing an existing cache time", t, func() {
+		db := map[string]models.CacheTime{
+			testCacheID: {ID: testCacheID, Path: "/testpath"},
+		}
+		dataStoreMock := &mock.DataStoreMock{
+			UpsertCacheTimesFunc: func(ctx context.Context, cacheTimes []*models.CacheTime) ([]models.UpsertResult, error) {
+				results := make([]models.UpsertResult, len(cacheTimes))
+				for i, cacheTime := range cacheTimes {
+					if cacheTime.Path == "/takenpath" {
+						results[i].Err = errs.ErrPathConflict
+						continue
+					}
+					var previous *models.CacheTime
+					if existing, ok := db[cacheTime.ID]; ok {
+						previous = &existing
+					}
+					results[i].Change = upsertChange(previous, cacheTime)
+					db[cacheTime.ID] = *cacheTime
+				}
+				return results, nil
+			},
+		}
+		publisherMock := &mock.EventPublisherMock{
+			PublishFunc: func(ctx context.Context, change *models.CacheTimeChange) error { return nil },
+		}
+		dataStoreAPI := setupAPIWithPublisher(newTestConfig(true), dataStoreMock, publisherMock)
+
+		Convey("When a JSON array with new, existing and invalid cache times is posted", func() {
+			body := `[
+				{"_id": "` + testCacheID + `", "path": "/testpath", "collection_id": "` + testCollectionID + `"},
+				{"_id": "XXX", "path": "invalidpath"},
+				{"_id": "` + otherCacheID + `", "path": "/newpath/", "collection_id": "` + testCollectionID + `"},
+				{"_id": "` + otherCacheID + `", "extra_field": "hello"}
+			]`
+			request := newRequestWithAuth(http.MethodPost, batchURL, bytes.NewBufferString(body))
+			responseRecorder := httptest.NewRecorder()
+			dataStoreAPI.Router.ServeHTTP(responseRecorder, request)
+
+			Convey("Then the valid items are stored and a per-item report is returned with status code 200", func() {
+				So(responseRecorder.Code, ShouldEqual, http.StatusOK)
+
+				result := models.BatchResult{}
+				err := json.Unmarshal(responseRecorder.Body.Bytes(), &result)
+				So(err, ShouldBeNil)
+				So(result.Created, ShouldEqual, 1)
+				So(result.Updated, ShouldEqual, 1)
+				So(result.Invalid, ShouldEqual, 2)
+				So(result.Items, ShouldHaveLength, 4)
+
+				So(result.Items[0], ShouldResemble, models.BatchItemResult{ID: testCacheID, Status: models.BatchItemUpdated})
+				So(result.Items[1].ID, ShouldEqual, "XXX")
+				So(result.Items[1].Status, ShouldEqual, models.BatchItemInvalid)
+				So(result.Items[1].Error, ShouldContainSubstring, "id should be 32 characters in length")
+				So(result.Items[2], ShouldResemble, models.BatchItemResult{ID: otherCacheID, Status: models.BatchItemCreated})
+				So(result.Items[3].Status, ShouldEqual, models.BatchItemInvalid)
+				So(result.Items[3].Error, ShouldContainSubstring, `json: unknown field "extra_field"`)
+
+				So(dataStoreMock.UpsertCacheTimesCalls(), ShouldHaveLength, 1)
+				So(dataStoreMock.UpsertCacheTimesCalls()[0].CacheTimes, ShouldHaveLength, 2)
+				So(db[testCacheID].CollectionID, ShouldEqual, testCollectionID)
+
+				So(publisherMock.PublishCalls(), ShouldHaveLength, 2)
+				So(publisherMock.PublishCalls()[0].Change.Action, ShouldEqual, models.CacheTimeUpdated)
+				So(publisherMock.PublishCalls()[1].Change.Action, ShouldEqual, models.CacheTimeCreated)
+				So(db[otherCacheID].CollectionID, ShouldEqual, testCollectionID)
+			})
+		})
+
+		Convey("When an NDJSON stream of cache times is posted", func() {
+			body := `{"_id": "` + testCacheID + `", "path": "/testpath"}` + "\n\n" +
+				`{"_id": "` + otherCacheID + `"}` + "\n"
+			request := newRequestWithAuth(http.MethodPost, batchURL, bytes.NewBufferString(body))
+			request.Header.Set("Content-Type", "application/x-ndjson")
+			responseRecorder := httptest.NewRecorder()
+			dataStoreAPI.Router.ServeHTTP(responseRecorder, request)
+
+			Convey("Then each line is processed as a separate item", func() {
+				So(responseRecorder.Code, ShouldEqual, http.StatusOK)
+
+				result := models.BatchResult{}
+				err := json.Unmarshal(responseRecorder.Body.Bytes(), &result)
+				So(err, ShouldBeNil)
+				So(result.Updated, ShouldEqual, 1)
+				So(result.Invalid, ShouldEqual, 1)
+				So(result.Items, ShouldHaveLength, 2)
+				So(result.Items[1].Error, ShouldContainSubstring, "[path field missing]")
+			})
+		})
+
+		Convey("When every item in the batch is invalid", func() {
+			body := `[{"path": "nopath"}]`
+			request := newRequestWithAuth(http.MethodPost, batchURL, bytes.NewBufferString(body))
+			responseRecorder := httptest.NewRecorder()
+			dataStoreAPI.Router.ServeHTTP(responseRecorder, request)
+
+			Convey("Then the datastore is not called", func() {
+				So(responseRecorder.Code, ShouldEqual, http.StatusOK)
+				So(responseRecorder.Body.String(), ShouldContainSubstring, `"invalid":1`)
+				So(dataStoreMock.UpsertCacheTimesCalls(), ShouldBeEmpty)
+			})
+		})
+
+		Convey("When no request body is provided", func() {
+			request := newRequestWithAuth(http.MethodPost, batchURL, http.NoBody)
+			responseRecorder := httptest.NewRecorder()
+			dataStoreAPI.Router.ServeHTTP(responseRecorder, request)
+
+			Convey("Then a 400 is returned with 'empty request body' in the response", func() {
+				So(responseRecorder.Code, ShouldEqual, http.StatusBadRequest)
+				So(responseRecorder.Body.String(), ShouldContainSubstring, "empty request body")
+			})
+		})
+
+		Convey("When an empty array is posted", func() {
+			request := newRequestWithAuth(http.MethodPost, batchURL, bytes.NewBufferString(`[]`))
+			responseRecorder := httptest.NewRecorder()
+			dataStoreAPI.Router.ServeHTTP(responseRecorder, request)
+
+			Convey("Then a 400 is returned with 'empty batch' in the response", func() {
+				So(responseRecorder.Code, ShouldEqual, http.StatusBadRequest)
+				So(responseRecorder.Body.String(), ShouldContainSubstring, "empty batch")
+			})
+		})
+
+		Convey("When the body is not a JSON array", func() {
+			request := newRequestWithAuth(http.MethodPost, batchURL, bytes.NewBufferString(`{"path": "/testpath"}`))
+			responseRecorder := httptest.NewRecorder()
+			dataStoreAPI.Router.ServeHTTP(responseRecorder, request)
+
+			Convey("Then a 400 is returned", func() {
+				So(responseRecorder.Code, ShouldEqual, http.StatusBadRequest)
+				So(responseRecorder.Body.String(), ShouldContainSubstring, "bad request: body is not a JSON array")
+			})
+		})
+
+		Convey("When a batch giving the same id more than once is posted", func() {
+			body := `[
+				{"_id": "` + testCacheID + `", "path": "/testpath"},
+				{"_id": "` + otherCacheID + `", "path": "/newpath"},
+				{"_id": "` + testCacheID + `", "path": "/testpath", "collection_id": "` + testCollectionID + `"}
+			]`
+			request := newRequestWithAuth(http.MethodPost, batchURL, bytes.NewBufferString(body))
+			responseRecorder := httptest.NewRecorder()
+			dataStoreAPI.Router.ServeHTTP(responseRecorder, request)
+
+			Convey("Then a 400 naming the repeated item is returned and nothing is stored", func() {
+				So(responseRecorder.Code, ShouldEqual, http.StatusBadRequest)
+				So(responseRecorder.Body.String(), ShouldContainSubstring, "bad request: repeated ids: [item 2 repeats the id of item 0]")
+				So(dataStoreMock.UpsertCacheTimesCalls(), ShouldBeEmpty)
+				So(publisherMock.PublishCalls(), ShouldBeEmpty)
+			})
+		})
+
+		Convey("When the JSON array is followed by other data", func() {
+			body := `[{"_id": "` + testCacheID + `", "path": "/testpath"}]garbage`
+			request := newRequestWithAuth(http.MethodPost, batchURL, bytes.NewBufferString(body))
+			responseRecorder := httptest.NewRecorder()
+			dataStoreAPI.Router.ServeHTTP(responseRecorder, request)
+
+			Convey("Then a 400 is returned and nothing is stored", func() {
+				So(responseRecorder.Code, ShouldEqual, http.StatusBadRequest)
+				So(responseRecorder.Body.String(), ShouldContainSubstring, "bad request: invalid character 'g'")
+				So(dataStoreMock.UpsertCacheTimesCalls(), ShouldBeEmpty)
+			})
+		})
+
+		Convey("When the JSON array is followed by more JSON", func() {
+			body := `[{"_id": "` + testCacheID + `", "path": "/testpath"}] [{"_id": "` + otherCacheID + `", "path": "/newpath"}]`
+			request := newRequestWithAuth(http.MethodPost, batchURL, bytes.NewBufferString(body))
+			responseRecorder := httptest.NewRecorder()
+			dataStoreAPI.Router.ServeHTTP(responseRecorder, request)
+
+			Convey("Then a 400 is returned and nothing is stored", func() {
+				So(responseRecorder.Code, ShouldEqual, http.StatusBadRequest)
+				So(responseRecorder.Body.String(), ShouldContainSubstring, "bad request: body has data after the JSON array")
+				So(dataStoreMock.UpsertCacheTimesCalls(), ShouldBeEmpty)
+			})
+		})
+
+		Convey("When the JSON array is followed by whitespace only", func() {
+			body := `[{"_id": "` + testCacheID + `", "path": "/testpath"}]` + "\n  \n"
+			request := newRequestWithAuth(http.MethodPost, batchURL, bytes.NewBufferString(body))
+			responseRecorder := httptest.NewRecorder()
+			dataStoreAPI.Router.ServeHTTP(responseRecorder, request)
+
+			Convey("Then the batch is processed", func() {
+				So(responseRecorder.Code, ShouldEqual, http.StatusOK)
+			})
+		})
+
+		Convey("When a cache time whose path is already stored under another id is posted", func() {
+			body := `[{"_id": "` + models.HashPath("/takenpath") + `", "path": "/takenpath"}, {"_id": "` + otherCacheID + `", "path": "/newpath"}]`
+			request := newRequestWithAuth(http.MethodPost, batchURL, bytes.NewBufferString(body))
+			responseRecorder := httptest.NewRecorder()
+			dataStoreAPI.Router.ServeHTTP(responseRecorder, request)
+
+			Convey("Then that item is reported as failed without preventing the others from being stored", func() {
+				So(responseRecorder.Code, ShouldEqual, http.StatusOK)
+
+				result := models.BatchResult{}
+				err := json.Unmarshal(responseRecorder.Body.Bytes(), &result)
+				So(err, ShouldBeNil)
+				So(result.Failed, ShouldEqual, 1)
+				So(result.Created, ShouldEqual, 1)
+				So(result.Items[0].Status, ShouldEqual, models.BatchItemFailed)
+				So(result.Items[0].Error, ShouldEqual, errs.ErrPathConflict.Error())
+				So(result.Items[1].Status, ShouldEqual, models.BatchItemCreated)
+			})
+		})
+
+		Convey("When a batch with more items than allowed is posted", func() {
+			body := "[" + strings.TrimSuffix(strings.Repeat(`{"_id": "`+testCacheID+`", "path": "/testpath"},`, 6), ",") + "]"
+			request := newRequestWithAuth(http.MethodPost, batchURL, bytes.NewBufferString(body))
+			responseRecorder := httptest.NewRecorder()
+			dataStoreAPI.Router.ServeHTTP(responseRecorder, request)
+
+			Convey("Then a 413 is returned and nothing is stored", func() {
+				So(responseRecorder.Code, ShouldEqual, http.StatusRequestEntityTooLarge)
+				So(responseRecorder.Body.String(), ShouldContainSubstring, "request too large: batch exceeds 5 items")
+				So(dataStoreMock.UpsertCacheTimesCalls(), ShouldBeEmpty)
+			})
+		})
+
+		Convey("When a body larger than allowed is posted", func() {
+			body := `[{"_id": "` + testCacheID + `", "path": "` + strings.Repeat("a", 4096) + `"}]`
+			request := newRequestWithAuth(http.MethodPost, batchURL, bytes.NewBufferString(body))
+			request.Header.Set("Content-Type", "application/x-ndjson")
+			responseRecorder := httptest.NewRecorder()
+			dataStoreAPI.Router.ServeHTTP(responseRecorder, request)
+
+			Convey("Then a 413 is returned and nothing is stored", func() {
+				So(responseRecorder.Code, ShouldEqual, http.StatusRequestEntityTooLarge)
+				So(responseRecorder.Body.String(), ShouldContainSubstring, "request too large: body exceeds 2048 bytes")
+				So(dataStoreMock.UpsertCacheTimesCalls(), ShouldBeEmpty)
+			})
+		})
+	})
+
+	Convey("Given a datastore that returns an error", t, func() {
+		dataStoreMock := &mock.DataStoreMock{
+			UpsertCacheTimesFunc: func(ctx context.Context, cacheTimes []*models.CacheTime) ([]models.UpsertResult, error) {
+				return nil, errs.ErrDataStore
+			},
+		}
+		dataStoreAPI := setupPublishingAPI(dataStoreMock)
+
+		Convey("When a valid batch is posted", func() {
+			body := `[{"_id": "` + testCacheID + `", "path": "/testpath"}]`
+			request := newRequestWithAuth(http.MethodPost, batchURL, bytes.NewBufferString(body))
+			responseRecorder := httptest.NewRecorder()
+			dataStoreAPI.Router.ServeHTTP(responseRecorder, request)
+
+			Convey("Then a 500 is returned", func() {
+				So(responseRecorder.Code, ShouldEqual, http.StatusInternalServerError)
+			})
+		})
+	})
+}
+
+func TestBatchEndpointRequiresAuthentication(t *testing.T) {
+	Convey("Given an API in the publishing subnet", t, func() {
+		dataStoreMock := &mock.DataStoreMock{}
+		api := setupPublishingAPI(dataStoreMock)
+
+		Convey("When we send an unauthenticated request to upsert a batch of Cache Time resources", func() {
+			request := httptest.NewRequest(http.MethodPost, batchURL, http.NoBody)
+			responseRecorder := httptest.NewRecorder()
+			api.Router.ServeHTTP(responseRecorder, request)
+
+			Convey("The status code should be 401", func() {
+				So(responseRecorder.Code, ShouldEqual, http.StatusUnauthorized)
+			})
+		})
+	})
+}
@@ -0,0 +1,46 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	errs "github.com/ONSdigital/dp-legacy-cache-api/apierrors"
+	"github.com/ONSdigital/log.go/v2/log"
+	"github.com/gorilla/mux"
+)
+
+// GetCacheControl computes the Cache-Control and Expires header values for the page with the given cache time ID
+// and writes them to the HTTP response. A page without a cache time gets the default max-age.
+func (api *API) GetCacheControl(ctx context.Context, w http.ResponseWriter, req *http.Request) {
+	log.Info(ctx, "calling get cache control handler")
+
+	vars := mux.Vars(req)
+	id := vars["id"]
+
+	err := isValidID(id)
+	if err != nil {
+		log.Info(ctx, "getCacheControl endpoint: id failed validation checks")
+		sendJSONError(ctx, w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	cacheTime, err := api.dataStore.GetCacheTime(ctx, id)
+	if err != nil && !errors.Is(err, errs.ErrCacheTimeNotFound) {
+		log.Error(ctx, "getCacheControl endpoint: api.dataStore.GetCacheTime internal server error", err)
+		sendJSONError(ctx, w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	cacheControl := api.cacheControlPolicy.CacheControl(cacheTime, time.Now())
+
+	// The computed max-age and expiry count down from the time of the request, so a cached response would be wrong
+	w.Header().Set("Cache-Control", "no-cache")
+	if err := json.NewEncoder(w).Encode(cacheControl); err != nil {
+		log.Error(ctx, "error encoding results to JSON", err)
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		return
+	}
+}
@@ -0,0 +1,99 @@
+package api_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/ONSdigital/dp-legacy-cache-api/api/mock"
+	errs "github.com/ONSdigital/dp-legacy-cache-api/apierrors"
+	"github.com/ONSdigital/dp-legacy-cache-api/models"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+const cacheControlURL = "/v1/cache-control/"
+
+func TestGetCacheControl(t *testing.T) {
+	Convey("Given an API with a cache time released long in the future", t, func() {
+		releaseTime := time.Now().Add(30 * 24 * time.Hour)
+		dataStoreMock := &mock.DataStoreMock{
+			GetCacheTimeFunc: func(ctx context.Context, id string) (*models.CacheTime, error) {
+				if id == testCacheID {
+					return &models.CacheTime{ID: testCacheID, Path: "/testpath", ReleaseTime: &releaseTime}, nil
+				}
+				return nil, errs.ErrCacheTimeNotFound
+			},
+		}
+		dataStoreAPI := setupWebAPI(dataStoreMock)
+
+		Convey("When the cache control for the cache time is requested", func() {
+			request := httptest.NewRequest(http.MethodGet, cacheControlURL+testCacheID, http.NoBody)
+			responseRecorder := httptest.NewRecorder()
+			dataStoreAPI.Router.ServeHTTP(responseRecorder, request)
+
+			Convey("Then the maximum max-age is returned with status code 200", func() {
+				So(responseRecorder.Code, ShouldEqual, http.StatusOK)
+
+				cacheControl := models.CacheControl{}
+				err := json.Unmarshal(responseRecorder.Body.Bytes(), &cacheControl)
+				So(err, ShouldBeNil)
+				So(cacheControl.MaxAge, ShouldEqual, 86400)
+				So(cacheControl.CacheControl, ShouldEqual, "public, max-age=86400")
+
+				expires, err := http.ParseTime(cacheControl.Expires)
+				So(err, ShouldBeNil)
+				So(expires, ShouldHappenWithin, 2*time.Second, time.Now().Add(24*time.Hour))
+			})
+		})
+
+		Convey("When the cache control for a page without a cache time is requested", func() {
+			request := httptest.NewRequest(http.MethodGet, cacheControlURL+"11111111111111111111111111111111", http.NoBody)
+			responseRecorder := httptest.NewRecorder()
+			dataStoreAPI.Router.ServeHTTP(responseRecorder, request)
+
+			Convey("Then the default max-age is returned with status code 200", func() {
+				So(responseRecorder.Code, ShouldEqual, http.StatusOK)
+
+				cacheControl := models.CacheControl{}
+				err := json.Unmarshal(responseRecorder.Body.Bytes(), &cacheControl)
+				So(err, ShouldBeNil)
+				So(cacheControl.MaxAge, ShouldEqual, 900)
+				So(cacheControl.CacheControl, ShouldEqual, "public, max-age=900")
+			})
+		})
+
+		Convey("When the cache control is requested with an invalid id", func() {
+			request := httptest.NewRequest(http.MethodGet, cacheControlURL+"invalid-id", http.NoBody)
+			responseRecorder := httptest.NewRecorder()
+			dataStoreAPI.Router.ServeHTTP(responseRecorder, request)
+
+			Convey("Then a 400 is returned", func() {
+				So(responseRecorder.Code, ShouldEqual, http.StatusBadRequest)
+				So(dataStoreMock.GetCacheTimeCalls(), ShouldBeEmpty)
+			})
+		})
+	})
+
+	Convey("Given an API whose data store fails", t, func() {
+		dataStoreMock := &mock.DataStoreMock{
+			GetCacheTimeFunc: func(ctx context.Context, id string) (*models.CacheTime, error) {
+				return nil, errors.New("something went wrong")
+			},
+		}
+		dataStoreAPI := setupWebAPI(dataStoreMock)
+
+		Convey("When the cache control is requested", func() {
+			request := httptest.NewRequest(http.MethodGet, cacheControlURL+testCacheID, http.NoBody)
+			responseRecorder := httptest.NewRecorder()
+			dataStoreAPI.Router.ServeHTTP(responseRecorder, request)
+
+			Convey("Then a 500 is returned", func() {
+				So(responseRecorder.Code, ShouldEqual, http.StatusInternalServerError)
+			})
+		})
+	})
+}
@@ -0,0 +1,129 @@
+package api
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/ONSdigital/dp-legacy-cache-api/models"
+)
+
+const (
+	// calendarProductID identifies the service as the producer of its iCalendar feeds
+	calendarProductID = "-//ONS//dp-legacy-cache-api//EN"
+
+	// calendarUIDSuffix makes the UIDs of the events unique across calendars. UIDs are derived from the collection or
+	// cache time of the event, so that subscribers update an event rather than duplicate it when it is rescheduled.
+	calendarUIDSuffix = "@dp-legacy-cache-api"
+
+	calendarTimeFormat = "20060102T150405Z"
+
+	// calendarLineLength is the maximum length of a content line in octets, longer lines being folded
+	calendarLineLength = 75
+)
+
+// writeCalendar renders upcoming releases as an iCalendar feed, with one event per collection or, when perPage is set,
+// per cache time. Cache times without a collection always have an event of their own.
+func writeCalendar(w io.Writer, upcoming *models.UpcomingReleases, perPage bool) error {
+	lines := []string{
+		"BEGIN:VCALENDAR",
+		"VERSION:2.0",
+		"PRODID:" + calendarProductID,
+		"CALSCALE:GREGORIAN",
+		"METHOD:PUBLISH",
+		"X-WR-CALNAME:Scheduled releases",
+	}
+
+	for _, collection := range upcoming.Collections {
+		if perPage || collection.CollectionID == "" {
+			for _, cacheTime := range collection.Items {
+				lines = append(lines, pageEvent(cacheTime, upcoming.From)...)
+			}
+		} else {
+			lines = append(lines, collectionEvent(collection, upcoming.From)...)
+		}
+	}
+	lines = append(lines, "END:VCALENDAR")
+
+	var calendar strings.Builder
+	for _, line := range lines {
+		calendar.WriteString(foldCalendarLine(line))
+		calendar.WriteString("\r\n")
+	}
+	_, err := io.WriteString(w, calendar.String())
+	return err
+}
+
+func collectionEvent(collection *models.CollectionReleases, stamp time.Time) []string {
+	paths := make([]string, len(collection.Items))
+	for i, cacheTime := range collection.Items {
+		paths[i] = cacheTime.Path
+		if !cacheTime.ReleaseTime.Equal(collection.ReleaseTime) {
+			paths[i] += " at " + cacheTime.ReleaseTime.UTC().Format(time.RFC3339)
+		}
+	}
+
+	return calendarEvent(
+		"collection-"+collection.CollectionID,
+		stamp,
+		collection.ReleaseTime,
+		fmt.Sprintf("Release of collection %s (%s)", collection.CollectionID, pluralisePages(collection.Count)),
+		strings.Join(paths, "\n"),
+	)
+}
+
+func pluralisePages(count int) string {
+	if count == 1 {
+		return "1 page"
+	}
+	return fmt.Sprintf("%d pages", count)
+}
+
+func pageEvent(cacheTime *models.CacheTime, stamp time.Time) []string {
+	description := ""
+	if cacheTime.CollectionID != "" {
+		description = "Collection " + cacheTime.CollectionID
+	}
+
+	return calendarEvent(cacheTime.ID, stamp, *cacheTime.ReleaseTime, "Release of "+cacheTime.Path, description)
+}
+
+func calendarEvent(uid string, stamp, start time.Time, summary, description string) []string {
+	lines := []string{
+		"BEGIN:VEVENT",
+		"UID:" + uid + calendarUIDSuffix,
+		"DTSTAMP:" + stamp.UTC().Format(calendarTimeFormat),
+		"DTSTART:" + start.UTC().Format(calendarTimeFormat),
+		"SUMMARY:" + escapeCalendarText(summary),
+	}
+	if description != "" {
+		lines = append(lines, "DESCRIPTION:"+escapeCalendarText(description))
+	}
+	return append(lines, "END:VEVENT")
+}
+
+var calendarTextEscaper = strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\r\n", `\n`, "\n", `\n`)
+
+// escapeCalendarText escapes the characters that have a meaning in iCalendar text values
+func escapeCalendarText(text string) string {
+	return calendarTextEscaper.Replace(text)
+}
+
+// foldCalendarLine splits a content line longer than calendarLineLength octets into several, each continuation line
+// starting with a space. Lines are only split between characters, so that multi-byte characters are kept whole.
+func foldCalendarLine(line string) string {
+	var folded strings.Builder
+	length := 0
+	for _, r := range line {
+		size := utf8.RuneLen(r)
+		if length+size > calendarLineLength {
+			folded.WriteString("\r\n ")
+			length = 1
+		}
+		folded.WriteRune(r)
+		length += size
+	}
+	return folded.String()
+}
@@ -0,0 +1,85 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/ONSdigital/dp-legacy-cache-api/models"
+	"github.com/ONSdigital/log.go/v2/log"
+	"github.com/gorilla/mux"
+)
+
+// UpdateCollectionReleaseTime reschedules every cache time belonging to a collection
+func (api *API) UpdateCollectionReleaseTime(ctx context.Context, w http.ResponseWriter, req *http.Request) {
+	log.Info(ctx, "calling update collection release time handler")
+
+	vars := mux.Vars(req)
+	collectionID := vars["collection_id"]
+
+	// Check request body not empty
+	if req.ContentLength <= 0 {
+		log.Info(ctx, "updateCollectionReleaseTime endpoint: empty request body")
+		sendJSONError(ctx, w, http.StatusBadRequest, "bad request: empty request body")
+		return
+	}
+
+	decoder := json.NewDecoder(req.Body)
+	decoder.DisallowUnknownFields() // disallow unknown fields in the request body
+
+	var body models.CollectionReleaseTime
+	err := decoder.Decode(&body)
+	if err != nil {
+		log.Info(ctx, "updateCollectionReleaseTime endpoint: error decoding request body")
+		sendJSONError(ctx, w, http.StatusBadRequest, fmt.Sprintf("bad request: %v", err))
+		return
+	}
+
+	if body.ReleaseTime == nil {
+		log.Info(ctx, "updateCollectionReleaseTime endpoint: release time failed validation checks")
+		sendJSONError(ctx, w, http.StatusBadRequest, fmt.Sprintf("validation errors: %v", formatErrorList([]error{errors.New("release_time field missing")})))
+		return
+	}
+
+	changes, err := api.dataStore.UpdateCollectionReleaseTime(ctx, collectionID, body.ReleaseTime)
+	if err != nil {
+		log.Error(ctx, "updateCollectionReleaseTime endpoint: api.dataStore.UpdateCollectionReleaseTime internal server error", err)
+		sendJSONError(ctx, w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	api.publish(ctx, changes...)
+
+	log.Info(ctx, "updateCollectionReleaseTime endpoint: collection rescheduled", log.Data{"collection_id": collectionID, "count": len(changes)})
+	sendBulkOperationResult(ctx, w, len(changes))
+}
+
+// DeleteCollectionCacheTimes removes every cache time belonging to a collection
+func (api *API) DeleteCollectionCacheTimes(ctx context.Context, w http.ResponseWriter, req *http.Request) {
+	log.Info(ctx, "calling delete collection cache times handler")
+
+	vars := mux.Vars(req)
+	collectionID := vars["collection_id"]
+
+	changes, err := api.dataStore.DeleteCollectionCacheTimes(ctx, collectionID)
+	if err != nil {
+		log.Error(ctx, "deleteCollectionCacheTimes endpoint: api.dataStore.DeleteCollectionCacheTimes internal server error", err)
+		sendJSONError(ctx, w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	api.publish(ctx, changes...)
+
+	log.Info(ctx, "deleteCollectionCacheTimes endpoint: collection cache times deleted", log.Data{"collection_id": collectionID, "count": len(changes)})
+	sendBulkOperationResult(ctx, w, len(changes))
+}
+
+func sendBulkOperationResult(ctx context.Context, w http.ResponseWriter, count int) {
+	if err := json.NewEncoder(w).Encode(models.BulkOperationResult{Count: count}); err != nil {
+		log.Error(ctx, "error encoding results to JSON", err)
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		return
+	}
+}
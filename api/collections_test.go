@@ -0,0 +1,212 @@
+package api_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/ONSdigital/dp-legacy-cache-api/api/mock"
+	errs "github.com/ONSdigital/dp-legacy-cache-api/apierrors"
+	"github.com/ONSdigital/dp-legacy-cache-api/models"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+var collectionsURL = "http://localhost:29100/v1/collections/"
+
+// collectionChanges returns the changes made to count cache times of a collection: their release time is set to
+// releaseTime, or they are deleted when releaseTime is nil
+func collectionChanges(count int, releaseTime *time.Time) []*models.CacheTimeChange {
+	changes := make([]*models.CacheTimeChange, count)
+	for i := range changes {
+		previous := &models.CacheTime{ID: fmt.Sprintf("%032d", i), CollectionID: testCollectionID}
+		var current *models.CacheTime
+		if releaseTime != nil {
+			current = &models.CacheTime{ID: previous.ID, CollectionID: testCollectionID, ReleaseTime: releaseTime}
+		}
+		changes[i] = models.NewCacheTimeChange(previous, current, "", time.Now())
+	}
+	return changes
+}
+
+func TestUpdateCollectionReleaseTime(t *testing.T) {
+	Convey("Given a collection containing cache times", t, func() {
+		dataStoreMock := &mock.DataStoreMock{
+			UpdateCollectionReleaseTimeFunc: func(ctx context.Context, collectionID string, releaseTime *time.Time) ([]*models.CacheTimeChange, error) {
+				return collectionChanges(3, releaseTime), nil
+			},
+		}
+		publisherMock := &mock.EventPublisherMock{
+			PublishFunc: func(ctx context.Context, change *models.CacheTimeChange) error { return nil },
+		}
+		dataStoreAPI := setupAPIWithPublisher(newTestConfig(true), dataStoreMock, publisherMock)
+
+		Convey("When the collection is rescheduled", func() {
+			body := `{"release_time":"` + staticTime.Format(time.RFC3339) + `"}`
+			request := newRequestWithAuth(http.MethodPut, collectionsURL+testCollectionID+"/release-time", bytes.NewBufferString(body))
+			responseRecorder := httptest.NewRecorder()
+			dataStoreAPI.Router.ServeHTTP(responseRecorder, request)
+
+			Convey("Then the number of rescheduled cache times is returned with status code 200", func() {
+				So(responseRecorder.Code, ShouldEqual, http.StatusOK)
+
+				result := models.BulkOperationResult{}
+				err := json.Unmarshal(responseRecorder.Body.Bytes(), &result)
+				So(err, ShouldBeNil)
+				So(result.Count, ShouldEqual, 3)
+
+				So(dataStoreMock.UpdateCollectionReleaseTimeCalls(), ShouldHaveLength, 1)
+				So(dataStoreMock.UpdateCollectionReleaseTimeCalls()[0].CollectionID, ShouldEqual, testCollectionID)
+				So(*dataStoreMock.UpdateCollectionReleaseTimeCalls()[0].ReleaseTime, ShouldEqual, staticTime)
+			})
+
+			Convey("And the change to every cache time is published", func() {
+				So(publisherMock.PublishCalls(), ShouldHaveLength, 3)
+				So(publisherMock.PublishCalls()[0].Change.Action, ShouldEqual, models.CacheTimeUpdated)
+			})
+		})
+
+		Convey("When no request body is provided", func() {
+			request := newRequestWithAuth(http.MethodPut, collectionsURL+testCollectionID+"/release-time", http.NoBody)
+			responseRecorder := httptest.NewRecorder()
+			dataStoreAPI.Router.ServeHTTP(responseRecorder, request)
+
+			Convey("Then a 400 is returned with 'empty request body' in the response", func() {
+				So(responseRecorder.Code, ShouldEqual, http.StatusBadRequest)
+				So(responseRecorder.Body.String(), ShouldContainSubstring, "empty request body")
+				So(dataStoreMock.UpdateCollectionReleaseTimeCalls(), ShouldBeEmpty)
+			})
+		})
+
+		Convey("When the release time is not provided", func() {
+			request := newRequestWithAuth(http.MethodPut, collectionsURL+testCollectionID+"/release-time", bytes.NewBufferString(`{}`))
+			responseRecorder := httptest.NewRecorder()
+			dataStoreAPI.Router.ServeHTTP(responseRecorder, request)
+
+			Convey("Then a 400 is returned with the missing field in the response", func() {
+				So(responseRecorder.Code, ShouldEqual, http.StatusBadRequest)
+				So(responseRecorder.Body.String(), ShouldContainSubstring, "[release_time field missing]")
+				So(dataStoreMock.UpdateCollectionReleaseTimeCalls(), ShouldBeEmpty)
+			})
+		})
+
+		Convey("When an extra field is provided", func() {
+			body := `{"release_time":"` + staticTime.Format(time.RFC3339) + `", "path": "/testpath"}`
+			request := newRequestWithAuth(http.MethodPut, collectionsURL+testCollectionID+"/release-time", bytes.NewBufferString(body))
+			responseRecorder := httptest.NewRecorder()
+			dataStoreAPI.Router.ServeHTTP(responseRecorder, request)
+
+			Convey("Then a 400 is returned with an error about the unknown field", func() {
+				So(responseRecorder.Code, ShouldEqual, http.StatusBadRequest)
+				So(responseRecorder.Body.String(), ShouldContainSubstring, `json: unknown field \"path\"`)
+			})
+		})
+	})
+
+	Convey("Given a datastore that returns an error", t, func() {
+		dataStoreMock := &mock.DataStoreMock{
+			UpdateCollectionReleaseTimeFunc: func(ctx context.Context, collectionID string, releaseTime *time.Time) ([]*models.CacheTimeChange, error) {
+				return nil, errs.ErrDataStore
+			},
+		}
+		dataStoreAPI := setupPublishingAPI(dataStoreMock)
+
+		Convey("When the collection is rescheduled", func() {
+			body := `{"release_time":"` + staticTime.Format(time.RFC3339) + `"}`
+			request := newRequestWithAuth(http.MethodPut, collectionsURL+testCollectionID+"/release-time", bytes.NewBufferString(body))
+			responseRecorder := httptest.NewRecorder()
+			dataStoreAPI.Router.ServeHTTP(responseRecorder, request)
+
+			Convey("Then a 500 is returned", func() {
+				So(responseRecorder.Code, ShouldEqual, http.StatusInternalServerError)
+			})
+		})
+	})
+}
+
+func TestDeleteCollectionCacheTimes(t *testing.T) {
+	Convey("Given a collection containing cache times", t, func() {
+		dataStoreMock := &mock.DataStoreMock{
+			DeleteCollectionCacheTimesFunc: func(ctx context.Context, collectionID string) ([]*models.CacheTimeChange, error) {
+				return collectionChanges(2, nil), nil
+			},
+		}
+		publisherMock := &mock.EventPublisherMock{
+			PublishFunc: func(ctx context.Context, change *models.CacheTimeChange) error { return nil },
+		}
+		dataStoreAPI := setupAPIWithPublisher(newTestConfig(true), dataStoreMock, publisherMock)
+
+		Convey("When the collection's cache times are deleted", func() {
+			request := newRequestWithAuth(http.MethodDelete, collectionsURL+testCollectionID+"/cache-times", http.NoBody)
+			responseRecorder := httptest.NewRecorder()
+			dataStoreAPI.Router.ServeHTTP(responseRecorder, request)
+
+			Convey("Then the number of deleted cache times is returned with status code 200", func() {
+				So(responseRecorder.Code, ShouldEqual, http.StatusOK)
+
+				result := models.BulkOperationResult{}
+				err := json.Unmarshal(responseRecorder.Body.Bytes(), &result)
+				So(err, ShouldBeNil)
+				So(result.Count, ShouldEqual, 2)
+
+				So(dataStoreMock.DeleteCollectionCacheTimesCalls(), ShouldHaveLength, 1)
+				So(dataStoreMock.DeleteCollectionCacheTimesCalls()[0].CollectionID, ShouldEqual, testCollectionID)
+			})
+
+			Convey("And the deletion of every cache time is published", func() {
+				So(publisherMock.PublishCalls(), ShouldHaveLength, 2)
+				So(publisherMock.PublishCalls()[0].Change.Action, ShouldEqual, models.CacheTimeDeleted)
+			})
+		})
+	})
+
+	Convey("Given a datastore that returns an error", t, func() {
+		dataStoreMock := &mock.DataStoreMock{
+			DeleteCollectionCacheTimesFunc: func(ctx context.Context, collectionID string) ([]*models.CacheTimeChange, error) {
+				return nil, errs.ErrDataStore
+			},
+		}
+		dataStoreAPI := setupPublishingAPI(dataStoreMock)
+
+		Convey("When the collection's cache times are deleted", func() {
+			request := newRequestWithAuth(http.MethodDelete, collectionsURL+testCollectionID+"/cache-times", http.NoBody)
+			responseRecorder := httptest.NewRecorder()
+			dataStoreAPI.Router.ServeHTTP(responseRecorder, request)
+
+			Convey("Then a 500 is returned", func() {
+				So(responseRecorder.Code, ShouldEqual, http.StatusInternalServerError)
+			})
+		})
+	})
+}
+
+func TestCollectionEndpointsRequireAuthentication(t *testing.T) {
+	Convey("Given an API in the publishing subnet", t, func() {
+		dataStoreMock := &mock.DataStoreMock{}
+		api := setupPublishingAPI(dataStoreMock)
+
+		Convey("When we send an unauthenticated request to reschedule a collection", func() {
+			request := httptest.NewRequest(http.MethodPut, collectionsURL+testCollectionID+"/release-time", http.NoBody)
+			responseRecorder := httptest.NewRecorder()
+			api.Router.ServeHTTP(responseRecorder, request)
+
+			Convey("The status code should be 401", func() {
+				So(responseRecorder.Code, ShouldEqual, http.StatusUnauthorized)
+			})
+		})
+
+		Convey("When we send an unauthenticated request to delete a collection's cache times", func() {
+			request := httptest.NewRequest(http.MethodDelete, collectionsURL+testCollectionID+"/cache-times", http.NoBody)
+			responseRecorder := httptest.NewRecorder()
+			api.Router.ServeHTTP(responseRecorder, request)
+
+			Convey("The status code should be 401", func() {
+				So(responseRecorder.Code, ShouldEqual, http.StatusUnauthorized)
+			})
+		})
+	})
+}
@@ -5,9 +5,13 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"net/http"
+	"net/url"
 	"regexp"
+	"strconv"
 	"strings"
+	"time"
 
 	errs "github.com/ONSdigital/dp-legacy-cache-api/apierrors"
 	"github.com/ONSdigital/dp-legacy-cache-api/models"
@@ -33,10 +37,7 @@ func (api *API) CreateOrUpdateCacheTime(ctx context.Context, w http.ResponseWrit
 		return
 	}
 
-	decoder := json.NewDecoder(req.Body)
-	decoder.DisallowUnknownFields() // disallow unknown fields in the request body
-
-	err := decoder.Decode(&docToInsertOrUpdate)
+	err := decodeCacheTime(req.Body, docToInsertOrUpdate)
 	if err != nil {
 		// Handle error for unknown fields, incorrect field type and decode
 		log.Info(ctx, "createOrUpdateCacheTime endpoint: error decoding request body")
@@ -45,22 +46,61 @@ func (api *API) CreateOrUpdateCacheTime(ctx context.Context, w http.ResponseWrit
 	}
 
 	// Validate request body
-	err = isValidCacheTime(docToInsertOrUpdate)
+	err = api.isValidCacheTime(ctx, docToInsertOrUpdate)
 	if err != nil {
 		log.Info(ctx, "createOrUpdateCacheTime endpoint: cache time failed validation checks")
 		sendJSONError(ctx, w, http.StatusBadRequest, err.Error())
 		return
 	}
 
+	// The id is checked against the normalised path, so the path is stored normalised, as by path
+	docToInsertOrUpdate.Path = models.NormalisePath(docToInsertOrUpdate.Path)
+
+	api.upsertCacheTime(ctx, w, req, docToInsertOrUpdate)
+}
+
+// upsertCacheTime stores a cache time, honouring the If-Match and If-None-Match headers of the request. A new cache time
+// is returned with 201 Created and its location, an updated one with 204 No Content.
+func (api *API) upsertCacheTime(ctx context.Context, w http.ResponseWriter, req *http.Request, cacheTime *models.CacheTime) {
+	precondition, err := getPrecondition(req.Header, true)
+	if err != nil {
+		log.Info(ctx, "createOrUpdateCacheTime endpoint: precondition headers cannot be satisfied")
+		sendJSONError(ctx, w, preconditionErrorStatus(err), err.Error())
+		return
+	}
+
 	// Upsert document into mongoDB.
-	err = api.dataStore.UpsertCacheTime(ctx, docToInsertOrUpdate)
+	change, err := api.dataStore.UpsertCacheTime(ctx, cacheTime, precondition)
 	if err != nil {
+		if errors.Is(err, errs.ErrPreconditionFailed) {
+			log.Info(ctx, "createOrUpdateCacheTime endpoint: api.dataStore.UpsertCacheTime precondition failed")
+			sendJSONError(ctx, w, http.StatusPreconditionFailed, err.Error())
+			return
+		}
+		if errors.Is(err, errs.ErrPathConflict) {
+			log.Info(ctx, "createOrUpdateCacheTime endpoint: api.dataStore.UpsertCacheTime path conflict")
+			sendJSONError(ctx, w, http.StatusConflict, err.Error())
+			return
+		}
 		log.Error(ctx, "createOrUpdateCacheTime endpoint: error upserting document", err)
 		sendJSONError(ctx, w, http.StatusInternalServerError, err.Error())
 		return
 	}
 
-	w.WriteHeader(http.StatusNoContent)
+	api.publish(ctx, change)
+
+	stored := change.Current
+	w.Header().Set("ETag", models.ETag(stored.Version))
+	if change.Action != models.CacheTimeCreated {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+
+	w.Header().Set("Location", "/v1/cache-times/"+stored.ID)
+	w.WriteHeader(http.StatusCreated)
+	if err := json.NewEncoder(w).Encode(stored); err != nil {
+		log.Error(ctx, "error encoding results to JSON", err)
+	}
 }
 
 // GetCacheTime retrieves a cache time for a given ID and writes it to the HTTP response.
@@ -77,6 +117,12 @@ func (api *API) GetCacheTime(ctx context.Context, w http.ResponseWriter, req *ht
 		return
 	}
 
+	api.getCacheTime(ctx, w, req, id)
+}
+
+// getCacheTime writes the cache time with the given ID to the HTTP response, or only its headers with a 304 Not
+// Modified status when the request shows the caller already holds it
+func (api *API) getCacheTime(ctx context.Context, w http.ResponseWriter, req *http.Request, id string) {
 	cacheTime, err := api.dataStore.GetCacheTime(ctx, id)
 	if err != nil {
 		if errors.Is(err, errs.ErrCacheTimeNotFound) {
@@ -89,6 +135,17 @@ func (api *API) GetCacheTime(ctx context.Context, w http.ResponseWriter, req *ht
 		return
 	}
 
+	w.Header().Set("ETag", models.ETag(cacheTime.Version))
+	if cacheTime.LastUpdated != nil {
+		w.Header().Set("Last-Modified", cacheTime.LastUpdated.UTC().Format(http.TimeFormat))
+	}
+	w.Header().Set("Cache-Control", api.cacheControl)
+
+	if isNotModified(req.Header, cacheTime) {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+
 	if err := json.NewEncoder(w).Encode(cacheTime); err != nil {
 		log.Error(ctx, "error encoding results to JSON", err)
 		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
@@ -96,18 +153,203 @@ func (api *API) GetCacheTime(ctx context.Context, w http.ResponseWriter, req *ht
 	}
 }
 
-func isValidCacheTime(cacheTime *models.CacheTime) error {
+// DeleteCacheTime removes the cache time with the given ID
+func (api *API) DeleteCacheTime(ctx context.Context, w http.ResponseWriter, req *http.Request) {
+	log.Info(ctx, "calling delete cache time handler")
+
+	vars := mux.Vars(req)
+	id := vars["id"]
+
+	err := isValidID(id)
+	if err != nil {
+		log.Info(ctx, "deleteCacheTime endpoint: id failed validation checks")
+		sendJSONError(ctx, w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	precondition, err := getPrecondition(req.Header, false)
+	if err != nil {
+		log.Info(ctx, "deleteCacheTime endpoint: precondition headers cannot be satisfied")
+		sendJSONError(ctx, w, preconditionErrorStatus(err), err.Error())
+		return
+	}
+
+	change, err := api.dataStore.DeleteCacheTime(ctx, id, precondition)
+	if err != nil {
+		if errors.Is(err, errs.ErrPreconditionFailed) {
+			log.Info(ctx, "deleteCacheTime endpoint: api.dataStore.DeleteCacheTime precondition failed")
+			sendJSONError(ctx, w, http.StatusPreconditionFailed, err.Error())
+		} else if errors.Is(err, errs.ErrCacheTimeNotFound) {
+			log.Info(ctx, "deleteCacheTime endpoint: api.dataStore.DeleteCacheTime document not found")
+			sendJSONError(ctx, w, http.StatusNotFound, err.Error())
+		} else {
+			log.Error(ctx, "deleteCacheTime endpoint: api.dataStore.DeleteCacheTime internal server error", err)
+			sendJSONError(ctx, w, http.StatusInternalServerError, err.Error())
+		}
+		return
+	}
+
+	api.publish(ctx, change)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// publish announces the changes made to cache times. The changes are already stored, so a failure to publish them is
+// logged rather than failing the request.
+func (api *API) publish(ctx context.Context, changes ...*models.CacheTimeChange) {
+	for _, change := range changes {
+		if err := api.publisher.Publish(ctx, change); err != nil {
+			log.Error(ctx, "error publishing cache time changed event", err, log.Data{"cache_time_id": change.CacheTimeID})
+		}
+	}
+}
+
+// GetCacheTimes retrieves a filtered, paginated list of cache times and writes it to the HTTP response.
+func (api *API) GetCacheTimes(ctx context.Context, w http.ResponseWriter, req *http.Request) {
+	log.Info(ctx, "calling get cache times handler")
+
+	filter, offset, limit, err := api.getListParameters(req.URL.Query())
+	if err != nil {
+		log.Info(ctx, "getCacheTimes endpoint: query parameters failed validation checks")
+		sendJSONError(ctx, w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	cacheTimes, totalCount, err := api.dataStore.GetCacheTimes(ctx, filter, offset, limit)
+	if err != nil {
+		log.Error(ctx, "getCacheTimes endpoint: api.dataStore.GetCacheTimes internal server error", err)
+		sendJSONError(ctx, w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	list := models.CacheTimesList{
+		Items:      cacheTimes,
+		Count:      len(cacheTimes),
+		Offset:     offset,
+		Limit:      limit,
+		TotalCount: totalCount,
+	}
+
+	w.Header().Set("Cache-Control", api.cacheControl)
+	if err := json.NewEncoder(w).Encode(list); err != nil {
+		log.Error(ctx, "error encoding results to JSON", err)
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		return
+	}
+}
+
+func (api *API) getListParameters(query url.Values) (filter models.CacheTimesFilter, offset, limit int, err error) {
+	offset, limit, e := api.getPaginationParameters(query)
+	filter, filterErrors := getFilterParameters(query)
+	e = append(e, filterErrors...)
+
+	if len(e) > 0 {
+		return filter, 0, 0, fmt.Errorf("validation errors: %v", formatErrorList(e))
+	}
+	return filter, offset, limit, nil
+}
+
+// getFilterParameters returns the filter given in the query string, along with any validation errors
+func getFilterParameters(query url.Values) (filter models.CacheTimesFilter, e []error) {
+	var err error
+
+	filter.CollectionID = query.Get("collection_id")
+	filter.PathPrefix = query.Get("path_prefix")
+
+	if filter.ReleaseTimeBefore, err = parseTimeParameter(query, "release_time_before"); err != nil {
+		e = append(e, err)
+	}
+	if filter.ReleaseTimeAfter, err = parseTimeParameter(query, "release_time_after"); err != nil {
+		e = append(e, err)
+	}
+	return filter, e
+}
+
+// getPaginationParameters returns the offset and limit given in the query string, or their defaults, along with any
+// validation errors
+func (api *API) getPaginationParameters(query url.Values) (offset, limit int, e []error) {
+	var err error
+
+	offset, limit = api.defaultOffset, api.defaultLimit
+	if value := query.Get("offset"); value != "" {
+		offset, err = strconv.Atoi(value)
+		if err != nil || offset < 0 {
+			e = append(e, errors.New("offset should be a non-negative integer"))
+		}
+	}
+	if value := query.Get("limit"); value != "" {
+		limit, err = strconv.Atoi(value)
+		if err != nil || limit < 1 {
+			e = append(e, errors.New("limit should be a positive integer"))
+		} else if limit > api.maxLimit {
+			e = append(e, fmt.Errorf("limit should not exceed %d", api.maxLimit))
+		}
+	}
+	return offset, limit, e
+}
+
+func parseTimeParameter(query url.Values, name string) (*time.Time, error) {
+	value := query.Get(name)
+	if value == "" {
+		return nil, nil
+	}
+
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return nil, fmt.Errorf("%s is not a valid ISO-8601 date-time", name)
+	}
+	return &t, nil
+}
+
+func decodeCacheTime(r io.Reader, cacheTime *models.CacheTime) error {
+	decoder := json.NewDecoder(r)
+	decoder.DisallowUnknownFields() // disallow unknown fields in the request body
+
+	return decoder.Decode(cacheTime)
+}
+
+func (api *API) isValidCacheTime(ctx context.Context, cacheTime *models.CacheTime) error {
 	e := findIDErrors(cacheTime.ID)
 
 	if cacheTime.Path == "" {
 		e = append(e, errors.New("path field missing"))
 	}
+
+	// Only compare the id with the path once both are known to be well formed
+	if len(e) == 0 && cacheTime.ID != models.CacheTimeID(cacheTime.Path) {
+		if api.idPathWarnOnly {
+			log.Warn(ctx, "cache time id is not the MD5 hash of its normalised path", log.Data{"id": cacheTime.ID, "path": cacheTime.Path})
+		} else {
+			e = append(e, errors.New("id is not the MD5 hash of the normalised path"))
+		}
+	}
+
+	e = append(e, findMetadataErrors(cacheTime)...)
+
 	if len(e) > 0 {
 		return fmt.Errorf("validation errors: %v", formatErrorList(e))
 	}
 	return nil
 }
 
+// findMetadataErrors rejects the fields of a cache time that are managed by the server
+func findMetadataErrors(cacheTime *models.CacheTime) []error {
+	var e []error
+
+	if cacheTime.CreatedAt != nil {
+		e = append(e, errors.New("created_at field is read-only"))
+	}
+	if cacheTime.LastUpdated != nil {
+		e = append(e, errors.New("last_updated field is read-only"))
+	}
+	if cacheTime.LastUpdatedBy != "" {
+		e = append(e, errors.New("last_updated_by field is read-only"))
+	}
+	if cacheTime.Version != 0 {
+		e = append(e, errors.New("version field is read-only"))
+	}
+	return e
+}
+
 func isValidID(id string) error {
 	e := findIDErrors(id)
 	if len(e) > 0 {
@@ -18,9 +18,10 @@ import (
 	. "github.com/smartystreets/goconvey/convey"
 )
 
-var validBody = `{"path": "testpath"}`
-var testCacheID = "a1b2c3d4e5f67890123456789abcdef0"
+var validBody = `{"path": "/testpath"}`
+var testCacheID = "b9bcb297b0e97468d742f86de4edd45d" // MD5 hash of "/testpath"
 var baseURL = "http://localhost:29100/v1/cache-times/"
+var listURL = "http://localhost:29100/v1/cache-times"
 var staticTime = time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)
 var staticTimePtr = &staticTime
 var testCollectionID = "test-1a19e3462937d85804752375daa00ba41d1b6625d396f21000e3c4571ebf2606"
@@ -33,7 +34,7 @@ func TestGetCacheTimeEndpoint(t *testing.T) {
 				case testCacheID:
 					return &models.CacheTime{
 						ID:           testCacheID,
-						Path:         "testpath",
+						Path:         "/testpath",
 						CollectionID: testCollectionID,
 						ReleaseTime:  staticTimePtr,
 					}, nil
@@ -52,7 +53,7 @@ func TestGetCacheTimeEndpoint(t *testing.T) {
 			Convey("The matched cache time is returned with status code 200", func() {
 				expectedCacheTime := models.CacheTime{
 					ID:           testCacheID,
-					Path:         "testpath",
+					Path:         "/testpath",
 					CollectionID: testCollectionID,
 					ReleaseTime:  staticTimePtr,
 				}
@@ -110,31 +111,181 @@ func TestGetCacheTimeReturnsError500(t *testing.T) {
 	})
 }
 
+func TestGetCacheTimesEndpoint(t *testing.T) {
+	Convey("Given a GetCacheTimes handler", t, func() {
+		dataStoreMock := &mock.DataStoreMock{
+			GetCacheTimesFunc: func(ctx context.Context, filter models.CacheTimesFilter, offset, limit int) ([]*models.CacheTime, int, error) {
+				return []*models.CacheTime{
+					{
+						ID:           testCacheID,
+						Path:         "/testpath",
+						CollectionID: testCollectionID,
+						ReleaseTime:  staticTimePtr,
+					},
+				}, 5, nil
+			},
+		}
+		dataStoreAPI := setupWebAPI(dataStoreMock)
+
+		Convey("When cache times are requested without any query parameters", func() {
+			request := httptest.NewRequest(http.MethodGet, listURL, http.NoBody)
+			responseRecorder := httptest.NewRecorder()
+			dataStoreAPI.Router.ServeHTTP(responseRecorder, request)
+
+			Convey("Then the first page is returned with status code 200 using the default pagination values", func() {
+				So(responseRecorder.Code, ShouldEqual, http.StatusOK)
+
+				list := models.CacheTimesList{}
+				err := json.Unmarshal(responseRecorder.Body.Bytes(), &list)
+				So(err, ShouldBeNil)
+				So(list.Count, ShouldEqual, 1)
+				So(list.Offset, ShouldEqual, 0)
+				So(list.Limit, ShouldEqual, 20)
+				So(list.TotalCount, ShouldEqual, 5)
+				So(list.Items, ShouldHaveLength, 1)
+				So(*list.Items[0], ShouldEqual, models.CacheTime{
+					ID:           testCacheID,
+					Path:         "/testpath",
+					CollectionID: testCollectionID,
+					ReleaseTime:  staticTimePtr,
+				})
+
+				So(dataStoreMock.GetCacheTimesCalls(), ShouldHaveLength, 1)
+				So(dataStoreMock.GetCacheTimesCalls()[0].Filter, ShouldResemble, models.CacheTimesFilter{})
+			})
+		})
+
+		Convey("When cache times are requested with filters and pagination", func() {
+			query := "?collection_id=" + testCollectionID + "&path_prefix=/economy&release_time_after=2024-01-01T00:00:00Z&release_time_before=2024-02-01T00:00:00Z&offset=2&limit=3"
+			request := httptest.NewRequest(http.MethodGet, listURL+query, http.NoBody)
+			responseRecorder := httptest.NewRecorder()
+			dataStoreAPI.Router.ServeHTTP(responseRecorder, request)
+
+			Convey("Then the filters and pagination values are passed to the datastore", func() {
+				So(responseRecorder.Code, ShouldEqual, http.StatusOK)
+
+				releaseTimeBefore := time.Date(2024, time.February, 1, 0, 0, 0, 0, time.UTC)
+				So(dataStoreMock.GetCacheTimesCalls(), ShouldHaveLength, 1)
+				call := dataStoreMock.GetCacheTimesCalls()[0]
+				So(call.Filter, ShouldResemble, models.CacheTimesFilter{
+					CollectionID:      testCollectionID,
+					PathPrefix:        "/economy",
+					ReleaseTimeBefore: &releaseTimeBefore,
+					ReleaseTimeAfter:  staticTimePtr,
+				})
+				So(call.Offset, ShouldEqual, 2)
+				So(call.Limit, ShouldEqual, 3)
+			})
+		})
+	})
+}
+
+func TestGetCacheTimesReturnsError400(t *testing.T) {
+	Convey("Given a GetCacheTimes handler", t, func() {
+		dataStoreMock := &mock.DataStoreMock{}
+		dataStoreAPI := setupWebAPI(dataStoreMock)
+
+		Convey("When the pagination parameters are invalid", func() {
+			request := httptest.NewRequest(http.MethodGet, listURL+"?offset=-1&limit=abc", http.NoBody)
+			responseRecorder := httptest.NewRecorder()
+			dataStoreAPI.Router.ServeHTTP(responseRecorder, request)
+
+			Convey("Then a 400 is returned with both pagination errors raised", func() {
+				So(responseRecorder.Code, ShouldEqual, http.StatusBadRequest)
+				So(responseRecorder.Body.String(), ShouldContainSubstring, "offset should be a non-negative integer")
+				So(responseRecorder.Body.String(), ShouldContainSubstring, "limit should be a positive integer")
+				So(dataStoreMock.GetCacheTimesCalls(), ShouldBeEmpty)
+			})
+		})
+
+		Convey("When the limit is 0", func() {
+			request := httptest.NewRequest(http.MethodGet, listURL+"?limit=0", http.NoBody)
+			responseRecorder := httptest.NewRecorder()
+			dataStoreAPI.Router.ServeHTTP(responseRecorder, request)
+
+			Convey("Then a 400 is returned rather than every cache time", func() {
+				So(responseRecorder.Code, ShouldEqual, http.StatusBadRequest)
+				So(responseRecorder.Body.String(), ShouldContainSubstring, "limit should be a positive integer")
+				So(dataStoreMock.GetCacheTimesCalls(), ShouldBeEmpty)
+			})
+		})
+
+		Convey("When the limit exceeds the maximum", func() {
+			request := httptest.NewRequest(http.MethodGet, listURL+"?limit=1001", http.NoBody)
+			responseRecorder := httptest.NewRecorder()
+			dataStoreAPI.Router.ServeHTTP(responseRecorder, request)
+
+			Convey("Then a 400 is returned with a maximum limit error", func() {
+				So(responseRecorder.Code, ShouldEqual, http.StatusBadRequest)
+				So(responseRecorder.Body.String(), ShouldContainSubstring, "limit should not exceed 1000")
+			})
+		})
+
+		Convey("When the release time filters are not valid date-times", func() {
+			request := httptest.NewRequest(http.MethodGet, listURL+"?release_time_before=tomorrow&release_time_after=2024-01-01", http.NoBody)
+			responseRecorder := httptest.NewRecorder()
+			dataStoreAPI.Router.ServeHTTP(responseRecorder, request)
+
+			Convey("Then a 400 is returned with both date-time errors raised", func() {
+				So(responseRecorder.Code, ShouldEqual, http.StatusBadRequest)
+				So(responseRecorder.Body.String(), ShouldContainSubstring, "release_time_before is not a valid ISO-8601 date-time")
+				So(responseRecorder.Body.String(), ShouldContainSubstring, "release_time_after is not a valid ISO-8601 date-time")
+			})
+		})
+	})
+}
+
+func TestGetCacheTimesReturnsError500(t *testing.T) {
+	Convey("Given a GetCacheTimes handler", t, func() {
+		dataStoreMock := &mock.DataStoreMock{
+			GetCacheTimesFunc: func(ctx context.Context, filter models.CacheTimesFilter, offset, limit int) ([]*models.CacheTime, int, error) {
+				return nil, 0, errs.ErrDataStore
+			},
+		}
+		dataStoreAPI := setupWebAPI(dataStoreMock)
+
+		Convey("When there is an error with the datastore", func() {
+			request := httptest.NewRequest(http.MethodGet, listURL, http.NoBody)
+			responseRecorder := httptest.NewRecorder()
+			dataStoreAPI.Router.ServeHTTP(responseRecorder, request)
+
+			Convey("Then return an internal server error with status code 500", func() {
+				So(responseRecorder.Code, ShouldEqual, http.StatusInternalServerError)
+			})
+		})
+	})
+}
+
 func TestUpdateExistingCacheTime(t *testing.T) {
 	Convey("Given an existing cache time", t, func() {
 		db := make(map[string]models.CacheTime)
 		dataStoreMock := &mock.DataStoreMock{
-			UpsertCacheTimeFunc: func(ctx context.Context, cacheTime *models.CacheTime) error {
+			UpsertCacheTimeFunc: func(ctx context.Context, cacheTime *models.CacheTime, precondition models.Precondition) (*models.CacheTimeChange, error) {
+				previous, exists := db[cacheTime.ID]
 				db[cacheTime.ID] = *cacheTime
-				return nil
+				if !exists {
+					return upsertChange(nil, cacheTime), nil
+				}
+				return upsertChange(&previous, cacheTime), nil
 			},
 		}
 		dataStoreAPI := setupPublishingAPI(dataStoreMock)
 
 		existingCacheTime := models.CacheTime{
 			ID:           testCacheID,
-			Path:         "existingpath",
+			Path:         "/testpath",
 			CollectionID: testCollectionID,
 			ReleaseTime:  staticTimePtr,
 		}
 		db[testCacheID] = existingCacheTime
 
 		Convey("When updating the cache time", func() {
+			updatedReleaseTime := staticTime.Add(time.Hour)
 			updatedCacheTime := models.CacheTime{
 				ID:           testCacheID,
-				Path:         "updatedpath",
-				CollectionID: testCollectionID,
-				ReleaseTime:  staticTimePtr,
+				Path:         "/testpath",
+				CollectionID: "updatedcollectionid",
+				ReleaseTime:  &updatedReleaseTime,
 			}
 			payload, err := json.Marshal(updatedCacheTime)
 			So(err, ShouldBeNil)
@@ -158,9 +309,13 @@ func TestCreateNewCacheTime(t *testing.T) {
 	Convey("Given no existing cache time", t, func() {
 		db := make(map[string]models.CacheTime)
 		dataStoreMock := &mock.DataStoreMock{
-			UpsertCacheTimeFunc: func(ctx context.Context, cacheTime *models.CacheTime) error {
+			UpsertCacheTimeFunc: func(ctx context.Context, cacheTime *models.CacheTime, precondition models.Precondition) (*models.CacheTimeChange, error) {
+				previous, exists := db[cacheTime.ID]
 				db[cacheTime.ID] = *cacheTime
-				return nil
+				if !exists {
+					return upsertChange(nil, cacheTime), nil
+				}
+				return upsertChange(&previous, cacheTime), nil
 			},
 		}
 		dataStoreAPI := setupPublishingAPI(dataStoreMock)
@@ -168,7 +323,7 @@ func TestCreateNewCacheTime(t *testing.T) {
 		Convey("When creating a new cache time", func() {
 			newCacheTime := models.CacheTime{
 				ID:           testCacheID,
-				Path:         "newpath",
+				Path:         "/testpath",
 				CollectionID: testCollectionID,
 				ReleaseTime:  staticTimePtr,
 			}
@@ -179,12 +334,16 @@ func TestCreateNewCacheTime(t *testing.T) {
 			responseRecorder := httptest.NewRecorder()
 			dataStoreAPI.Router.ServeHTTP(responseRecorder, request)
 
-			Convey("Then a new cache time should be created with status code 204 with an empty response body", func() {
-				So(responseRecorder.Code, ShouldEqual, http.StatusNoContent)
+			Convey("Then a new cache time should be created with status code 201, its location and the stored resource", func() {
+				So(responseRecorder.Code, ShouldEqual, http.StatusCreated)
+				So(responseRecorder.Header().Get("Location"), ShouldEqual, "/v1/cache-times/"+testCacheID)
 				createdRecord, exists := db[testCacheID]
 				So(exists, ShouldBeTrue)
 				So(createdRecord, ShouldEqual, newCacheTime)
-				So(responseRecorder.Body.Len(), ShouldEqual, 0)
+
+				var returnedCacheTime models.CacheTime
+				So(json.Unmarshal(responseRecorder.Body.Bytes(), &returnedCacheTime), ShouldBeNil)
+				So(returnedCacheTime, ShouldEqual, newCacheTime)
 			})
 		})
 
@@ -193,15 +352,14 @@ func TestCreateNewCacheTime(t *testing.T) {
 			responseRecorder := httptest.NewRecorder()
 			dataStoreAPI.Router.ServeHTTP(responseRecorder, request)
 
-			Convey("Then a new cache time should be created with status code 204 with an empty response body", func() {
+			Convey("Then a new cache time should be created with status code 201", func() {
 				expectedCacheTime := models.CacheTime{
 					ID:           testCacheID,
-					Path:         "testpath",
+					Path:         "/testpath",
 					CollectionID: "",
 					ReleaseTime:  nil,
 				}
-				So(responseRecorder.Code, ShouldEqual, http.StatusNoContent)
-				So(responseRecorder.Body.Len(), ShouldEqual, 0)
+				So(responseRecorder.Code, ShouldEqual, http.StatusCreated)
 				createdRecord, exists := db[testCacheID]
 				So(exists, ShouldBeTrue)
 				So(createdRecord, ShouldEqual, expectedCacheTime)
@@ -261,7 +419,7 @@ func TestCreateOrUpdateCacheTimeReturnsErr(t *testing.T) {
 		})
 
 		Convey("When an extra field is provided and the CreateOrUpdateCacheTime endpoint is called", func() {
-			body := `{"path": "testpath", "extra_field": "hello" }`
+			body := `{"path": "/testpath", "extra_field": "hello" }`
 			request := newRequestWithAuth(http.MethodPut, baseURL+testCacheID, bytes.NewBufferString(body))
 			responseRecorder := httptest.NewRecorder()
 			dataStoreAPI.Router.ServeHTTP(responseRecorder, request)
@@ -284,6 +442,34 @@ func TestCreateOrUpdateCacheTimeReturnsErr(t *testing.T) {
 			})
 		})
 
+		Convey("When server managed fields are provided and the CreateOrUpdateCacheTime endpoint is called", func() {
+			body := `{"path": "/testpath", "created_at": "2024-01-31T01:23:45Z", "last_updated": "2024-01-31T01:23:45Z", "last_updated_by": "someone"}`
+			request := newRequestWithAuth(http.MethodPut, baseURL+testCacheID, bytes.NewBufferString(body))
+			responseRecorder := httptest.NewRecorder()
+			dataStoreAPI.Router.ServeHTTP(responseRecorder, request)
+
+			Convey("Then a 400 is returned listing each read-only field", func() {
+				So(responseRecorder.Code, ShouldEqual, http.StatusBadRequest)
+				So(responseRecorder.Body.String(), ShouldContainSubstring,
+					"[created_at field is read-only, last_updated field is read-only, last_updated_by field is read-only]")
+				So(dataStoreMock.UpsertCacheTimeCalls(), ShouldBeEmpty)
+			})
+		})
+
+		Convey("When another cache time has the same path and the CreateOrUpdateCacheTime endpoint is called", func() {
+			dataStoreMock.UpsertCacheTimeFunc = func(ctx context.Context, cacheTime *models.CacheTime, precondition models.Precondition) (*models.CacheTimeChange, error) {
+				return nil, errs.ErrPathConflict
+			}
+			request := newRequestWithAuth(http.MethodPut, baseURL+testCacheID, bytes.NewBufferString(validBody))
+			responseRecorder := httptest.NewRecorder()
+			dataStoreAPI.Router.ServeHTTP(responseRecorder, request)
+
+			Convey("Then a 409 is returned with the path conflict in the response", func() {
+				So(responseRecorder.Code, ShouldEqual, http.StatusConflict)
+				So(responseRecorder.Body.String(), ShouldContainSubstring, errs.ErrPathConflict.Error())
+			})
+		})
+
 		Convey("When the id provided is not 32 characters in length and the CreateOrUpdateCacheTime endpoint is called", func() {
 			body := validBody
 			idTooShort := "abc"
@@ -324,6 +510,95 @@ func TestCreateOrUpdateCacheTimeReturnsErr(t *testing.T) {
 		})
 	})
 
+	Convey("Given an API in publishing subnet enforcing that ids match paths", t, func() {
+		dataStoreMock := &mock.DataStoreMock{}
+		dataStoreAPI := setupPublishingAPI(dataStoreMock)
+
+		Convey("When the id provided is not the MD5 hash of the path and the CreateOrUpdateCacheTime endpoint is called", func() {
+			body := `{"path": "/otherpath"}`
+			request := newRequestWithAuth(http.MethodPut, baseURL+testCacheID, bytes.NewBufferString(body))
+			responseRecorder := httptest.NewRecorder()
+			dataStoreAPI.Router.ServeHTTP(responseRecorder, request)
+
+			Convey("Then a 400 is returned with an id and path mismatch error in the response", func() {
+				So(responseRecorder.Code, ShouldEqual, http.StatusBadRequest)
+				So(responseRecorder.Body.String(), ShouldContainSubstring, "[id is not the MD5 hash of the normalised path]")
+				So(dataStoreMock.UpsertCacheTimeCalls(), ShouldBeEmpty)
+			})
+		})
+	})
+
+	Convey("Given an API in publishing subnet enforcing that ids match paths", t, func() {
+		dataStoreMock := &mock.DataStoreMock{
+			UpsertCacheTimeFunc: func(ctx context.Context, cacheTime *models.CacheTime, precondition models.Precondition) (*models.CacheTimeChange, error) {
+				return upsertChange(nil, cacheTime), nil
+			},
+		}
+		dataStoreAPI := setupPublishingAPI(dataStoreMock)
+
+		Convey("When the id provided is the MD5 hash of the path once normalised and the CreateOrUpdateCacheTime endpoint is called", func() {
+			body := `{"path": "testpath/"}`
+			request := newRequestWithAuth(http.MethodPut, baseURL+testCacheID, bytes.NewBufferString(body))
+			responseRecorder := httptest.NewRecorder()
+			dataStoreAPI.Router.ServeHTTP(responseRecorder, request)
+
+			Convey("Then the cache time is stored with its path normalised with status code 201", func() {
+				So(responseRecorder.Code, ShouldEqual, http.StatusCreated)
+				So(dataStoreMock.UpsertCacheTimeCalls(), ShouldHaveLength, 1)
+				So(dataStoreMock.UpsertCacheTimeCalls()[0].CacheTime.Path, ShouldEqual, "/testpath")
+			})
+		})
+
+		Convey("When a path with a trailing slash is given under the id of the path without it", func() {
+			body := `{"path": "/testpath/"}`
+			request := newRequestWithAuth(http.MethodPut, baseURL+testCacheID, bytes.NewBufferString(body))
+			responseRecorder := httptest.NewRecorder()
+			dataStoreAPI.Router.ServeHTTP(responseRecorder, request)
+
+			Convey("Then the path is stored without the trailing slash, so it cannot collide with the same path written without it", func() {
+				So(responseRecorder.Code, ShouldEqual, http.StatusCreated)
+				So(dataStoreMock.UpsertCacheTimeCalls(), ShouldHaveLength, 1)
+				So(dataStoreMock.UpsertCacheTimeCalls()[0].CacheTime.Path, ShouldEqual, "/testpath")
+			})
+		})
+
+		Convey("When the id provided is the MD5 hash of a path that is not normalised and the CreateOrUpdateCacheTime endpoint is called", func() {
+			body := `{"path": "testpath/"}`
+			request := newRequestWithAuth(http.MethodPut, baseURL+models.HashPath("testpath/"), bytes.NewBufferString(body))
+			responseRecorder := httptest.NewRecorder()
+			dataStoreAPI.Router.ServeHTTP(responseRecorder, request)
+
+			Convey("Then a 400 is returned with an id and path mismatch error in the response", func() {
+				So(responseRecorder.Code, ShouldEqual, http.StatusBadRequest)
+				So(responseRecorder.Body.String(), ShouldContainSubstring, "[id is not the MD5 hash of the normalised path]")
+				So(dataStoreMock.UpsertCacheTimeCalls(), ShouldBeEmpty)
+			})
+		})
+	})
+
+	Convey("Given an API in publishing subnet only warning when ids do not match paths", t, func() {
+		dataStoreMock := &mock.DataStoreMock{
+			UpsertCacheTimeFunc: func(ctx context.Context, cacheTime *models.CacheTime, precondition models.Precondition) (*models.CacheTimeChange, error) {
+				return upsertChange(cacheTime, cacheTime), nil
+			},
+		}
+		cfg := newTestConfig(true)
+		cfg.IDPathValidationWarnOnly = true
+		dataStoreAPI := setupAPIWithConfig(cfg, dataStoreMock)
+
+		Convey("When the id provided is not the MD5 hash of the path and the CreateOrUpdateCacheTime endpoint is called", func() {
+			body := `{"path": "/otherpath"}`
+			request := newRequestWithAuth(http.MethodPut, baseURL+testCacheID, bytes.NewBufferString(body))
+			responseRecorder := httptest.NewRecorder()
+			dataStoreAPI.Router.ServeHTTP(responseRecorder, request)
+
+			Convey("Then the cache time is still stored with status code 204", func() {
+				So(responseRecorder.Code, ShouldEqual, http.StatusNoContent)
+				So(dataStoreMock.UpsertCacheTimeCalls(), ShouldHaveLength, 1)
+			})
+		})
+	})
+
 	Convey("Given an API in web subnet", t, func() {
 		dataStoreMock := &mock.DataStoreMock{}
 		dataStoreAPI := setupWebAPI(dataStoreMock)
@@ -341,6 +616,141 @@ func TestCreateOrUpdateCacheTimeReturnsErr(t *testing.T) {
 	})
 }
 
+func TestDeleteCacheTime(t *testing.T) {
+	Convey("Given an existing cache time", t, func() {
+		db := map[string]models.CacheTime{
+			testCacheID: {ID: testCacheID, Path: "/testpath"},
+		}
+		dataStoreMock := &mock.DataStoreMock{
+			DeleteCacheTimeFunc: func(ctx context.Context, id string, precondition models.Precondition) (*models.CacheTimeChange, error) {
+				previous, ok := db[id]
+				if !ok {
+					return nil, errs.ErrCacheTimeNotFound
+				}
+				delete(db, id)
+				return models.NewCacheTimeChange(&previous, nil, "", time.Now()), nil
+			},
+		}
+		dataStoreAPI := setupPublishingAPI(dataStoreMock)
+
+		Convey("When deleting the cache time", func() {
+			request := newRequestWithAuth(http.MethodDelete, baseURL+testCacheID, http.NoBody)
+			responseRecorder := httptest.NewRecorder()
+			dataStoreAPI.Router.ServeHTTP(responseRecorder, request)
+
+			Convey("Then the cache time should be removed with status code 204 and an empty response body", func() {
+				So(responseRecorder.Code, ShouldEqual, http.StatusNoContent)
+				So(responseRecorder.Body.Len(), ShouldEqual, 0)
+				_, exists := db[testCacheID]
+				So(exists, ShouldBeFalse)
+			})
+		})
+
+		Convey("When deleting a cache time that does not exist", func() {
+			var nonExistentCacheID = "abcdef0a1b2c3d4e5f67890123456789"
+			request := newRequestWithAuth(http.MethodDelete, baseURL+nonExistentCacheID, http.NoBody)
+			responseRecorder := httptest.NewRecorder()
+			dataStoreAPI.Router.ServeHTTP(responseRecorder, request)
+
+			Convey("Then a 404 is returned", func() {
+				So(responseRecorder.Code, ShouldEqual, http.StatusNotFound)
+				So(responseRecorder.Body.String(), ShouldContainSubstring, errs.ErrCacheTimeNotFound.Error())
+			})
+		})
+
+		Convey("When deleting a cache time with an invalid id", func() {
+			request := newRequestWithAuth(http.MethodDelete, baseURL+"XXX", http.NoBody)
+			responseRecorder := httptest.NewRecorder()
+			dataStoreAPI.Router.ServeHTTP(responseRecorder, request)
+
+			Convey("Then a 400 is returned and the datastore is not called", func() {
+				So(responseRecorder.Code, ShouldEqual, http.StatusBadRequest)
+				So(responseRecorder.Body.String(), ShouldContainSubstring, "id should be 32 characters in length")
+				So(dataStoreMock.DeleteCacheTimeCalls(), ShouldBeEmpty)
+			})
+		})
+	})
+
+	Convey("Given a datastore that returns an error", t, func() {
+		dataStoreMock := &mock.DataStoreMock{
+			DeleteCacheTimeFunc: func(ctx context.Context, id string, precondition models.Precondition) (*models.CacheTimeChange, error) {
+				return nil, errs.ErrDataStore
+			},
+		}
+		dataStoreAPI := setupPublishingAPI(dataStoreMock)
+
+		Convey("When deleting a cache time", func() {
+			request := newRequestWithAuth(http.MethodDelete, baseURL+testCacheID, http.NoBody)
+			responseRecorder := httptest.NewRecorder()
+			dataStoreAPI.Router.ServeHTTP(responseRecorder, request)
+
+			Convey("Then a 500 is returned", func() {
+				So(responseRecorder.Code, ShouldEqual, http.StatusInternalServerError)
+			})
+		})
+	})
+}
+
+func TestCacheTimeChangesArePublished(t *testing.T) {
+	Convey("Given an API publishing the changes made to cache times", t, func() {
+		existing := models.CacheTime{ID: testCacheID, Path: "/testpath", ReleaseTime: staticTimePtr}
+		dataStoreMock := &mock.DataStoreMock{
+			UpsertCacheTimeFunc: func(ctx context.Context, cacheTime *models.CacheTime, precondition models.Precondition) (*models.CacheTimeChange, error) {
+				return upsertChange(&existing, cacheTime), nil
+			},
+			DeleteCacheTimeFunc: func(ctx context.Context, id string, precondition models.Precondition) (*models.CacheTimeChange, error) {
+				return models.NewCacheTimeChange(&existing, nil, "", time.Now()), nil
+			},
+		}
+		publisherMock := &mock.EventPublisherMock{
+			PublishFunc: func(ctx context.Context, change *models.CacheTimeChange) error { return nil },
+		}
+		dataStoreAPI := setupAPIWithPublisher(newTestConfig(true), dataStoreMock, publisherMock)
+
+		Convey("When a cache time is updated", func() {
+			payload, err := json.Marshal(models.CacheTime{ID: testCacheID, Path: "/testpath"})
+			So(err, ShouldBeNil)
+			request := newRequestWithAuth(http.MethodPut, baseURL+testCacheID, bytes.NewReader(payload))
+			responseRecorder := httptest.NewRecorder()
+			dataStoreAPI.Router.ServeHTTP(responseRecorder, request)
+
+			Convey("Then the change reported by the datastore is published", func() {
+				So(responseRecorder.Code, ShouldEqual, http.StatusNoContent)
+				So(publisherMock.PublishCalls(), ShouldHaveLength, 1)
+				change := publisherMock.PublishCalls()[0].Change
+				So(change.Action, ShouldEqual, models.CacheTimeUpdated)
+				So(change.Previous.ReleaseTime, ShouldEqual, staticTimePtr)
+			})
+		})
+
+		Convey("When a cache time is deleted", func() {
+			request := newRequestWithAuth(http.MethodDelete, baseURL+testCacheID, http.NoBody)
+			responseRecorder := httptest.NewRecorder()
+			dataStoreAPI.Router.ServeHTTP(responseRecorder, request)
+
+			Convey("Then the deletion is published", func() {
+				So(responseRecorder.Code, ShouldEqual, http.StatusNoContent)
+				So(publisherMock.PublishCalls(), ShouldHaveLength, 1)
+				So(publisherMock.PublishCalls()[0].Change.Action, ShouldEqual, models.CacheTimeDeleted)
+			})
+		})
+
+		Convey("When the datastore fails to make a change", func() {
+			dataStoreMock.DeleteCacheTimeFunc = func(ctx context.Context, id string, precondition models.Precondition) (*models.CacheTimeChange, error) {
+				return nil, errs.ErrDataStore
+			}
+			request := newRequestWithAuth(http.MethodDelete, baseURL+testCacheID, http.NoBody)
+			responseRecorder := httptest.NewRecorder()
+			dataStoreAPI.Router.ServeHTTP(responseRecorder, request)
+
+			Convey("Then nothing is published", func() {
+				So(responseRecorder.Code, ShouldEqual, http.StatusInternalServerError)
+				So(publisherMock.PublishCalls(), ShouldBeEmpty)
+			})
+		})
+	})
+}
+
 func TestGetEndpointDoesNotRequireAuthentication(t *testing.T) {
 	Convey("Given an API", t, func() {
 		dataStoreMock := &mock.DataStoreMock{
@@ -379,6 +789,24 @@ func TestPutEndpointRequiresAuthentication(t *testing.T) {
 	})
 }
 
+func TestDeleteEndpointRequiresAuthentication(t *testing.T) {
+	Convey("Given an API in the publishing subnet", t, func() {
+		dataStoreMock := &mock.DataStoreMock{}
+		api := setupPublishingAPI(dataStoreMock)
+
+		Convey("When we send an unauthenticated request to delete a Cache Time resource", func() {
+			request := httptest.NewRequest(http.MethodDelete, baseURL+testCacheID, http.NoBody)
+			responseRecorder := httptest.NewRecorder()
+			api.Router.ServeHTTP(responseRecorder, request)
+
+			Convey("The status code should be 401", func() {
+				So(responseRecorder.Code, ShouldEqual, http.StatusUnauthorized)
+				So(dataStoreMock.DeleteCacheTimeCalls(), ShouldBeEmpty)
+			})
+		})
+	})
+}
+
 func newRequestWithAuth(method, target string, body io.Reader) *http.Request {
 	req := httptest.NewRequest(method, target, body)
 	ctx := dprequest.SetCaller(req.Context(), "someone@ons.gov.uk")
@@ -0,0 +1,148 @@
+package api
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/ONSdigital/dp-legacy-cache-api/models"
+	"github.com/ONSdigital/log.go/v2/log"
+)
+
+// csvHeader names the columns of a CSV export, one per field of a cache time
+var csvHeader = []string{"_id", "path", "collection_id", "release_time", "created_at", "last_updated", "last_updated_by", "version"}
+
+// cacheTimesWriter writes the cache times of an export in a given representation
+type cacheTimesWriter interface {
+	writeHeader() error
+	write(cacheTime *models.CacheTime) error
+	flush() error
+}
+
+// ExportCacheTimes streams every cache time matching the filters of the list endpoint, in id order, as NDJSON or CSV.
+// The cache times are written as they are read from the data store, so that the whole collection is never held in
+// memory. The response is committed by the first cache time written: a failure after that can only cut it short.
+func (api *API) ExportCacheTimes(ctx context.Context, w http.ResponseWriter, req *http.Request) {
+	log.Info(ctx, "calling export cache times handler")
+
+	w.Header().Set("Vary", "Accept")
+	rep, err := negotiate(req, ndjsonRepresentation, csvRepresentation)
+	if err != nil {
+		log.Info(ctx, "exportCacheTimes endpoint: no acceptable representation")
+		sendJSONError(ctx, w, negotiationErrorStatus(err), err.Error())
+		return
+	}
+
+	filter, e := getFilterParameters(req.URL.Query())
+	if len(e) > 0 {
+		log.Info(ctx, "exportCacheTimes endpoint: query parameters failed validation checks")
+		sendJSONError(ctx, w, http.StatusBadRequest, fmt.Sprintf("validation errors: %v", formatErrorList(e)))
+		return
+	}
+
+	writer := newCacheTimesWriter(w, rep)
+	count := 0
+	started := false
+	start := func() error {
+		started = true
+		w.Header().Set("Content-Type", rep.mediaType+"; charset=utf-8")
+		w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="cachetimes.%s"`, rep.format))
+		w.Header().Set("Cache-Control", "no-store")
+		w.WriteHeader(http.StatusOK)
+		return writer.writeHeader()
+	}
+
+	err = api.dataStore.ExportCacheTimes(ctx, filter, func(cacheTime *models.CacheTime) error {
+		if !started {
+			if err := start(); err != nil {
+				return err
+			}
+		}
+		count++
+		return writer.write(cacheTime)
+	})
+	if err == nil && !started {
+		err = start()
+	}
+	if err == nil {
+		err = writer.flush()
+	}
+
+	switch {
+	case err != nil && !started:
+		log.Error(ctx, "exportCacheTimes endpoint: api.dataStore.ExportCacheTimes internal server error", err)
+		sendJSONError(ctx, w, http.StatusInternalServerError, err.Error())
+	case err != nil:
+		log.Error(ctx, "exportCacheTimes endpoint: export cut short", err, log.Data{"count": count})
+	default:
+		log.Info(ctx, "exportCacheTimes endpoint: cache times exported", log.Data{"format": rep.format, "count": count})
+	}
+}
+
+func newCacheTimesWriter(w io.Writer, rep representation) cacheTimesWriter {
+	if rep == csvRepresentation {
+		return &csvCacheTimesWriter{writer: csv.NewWriter(w)}
+	}
+	return &ndjsonCacheTimesWriter{encoder: json.NewEncoder(w)}
+}
+
+// ndjsonCacheTimesWriter writes each cache time as a JSON document on a line of its own
+type ndjsonCacheTimesWriter struct {
+	encoder *json.Encoder
+}
+
+func (n *ndjsonCacheTimesWriter) writeHeader() error {
+	return nil
+}
+
+func (n *ndjsonCacheTimesWriter) write(cacheTime *models.CacheTime) error {
+	return n.encoder.Encode(cacheTime)
+}
+
+func (n *ndjsonCacheTimesWriter) flush() error {
+	return nil
+}
+
+// csvCacheTimesWriter writes each cache time as a CSV record, times in ISO-8601 format and missing values left empty
+type csvCacheTimesWriter struct {
+	writer *csv.Writer
+}
+
+func (c *csvCacheTimesWriter) writeHeader() error {
+	return c.writer.Write(csvHeader)
+}
+
+func (c *csvCacheTimesWriter) write(cacheTime *models.CacheTime) error {
+	version := ""
+	if cacheTime.Version != 0 {
+		version = strconv.Itoa(cacheTime.Version)
+	}
+
+	return c.writer.Write([]string{
+		cacheTime.ID,
+		cacheTime.Path,
+		cacheTime.CollectionID,
+		formatCSVTime(cacheTime.ReleaseTime),
+		formatCSVTime(cacheTime.CreatedAt),
+		formatCSVTime(cacheTime.LastUpdated),
+		cacheTime.LastUpdatedBy,
+		version,
+	})
+}
+
+func (c *csvCacheTimesWriter) flush() error {
+	c.writer.Flush()
+	return c.writer.Error()
+}
+
+func formatCSVTime(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339Nano)
+}
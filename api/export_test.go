@@ -0,0 +1,239 @@
+package api_test
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ONSdigital/dp-legacy-cache-api/api/mock"
+	"github.com/ONSdigital/dp-legacy-cache-api/models"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+var exportURL = baseURL + "export"
+
+func TestExportCacheTimes(t *testing.T) {
+	Convey("Given a data store holding two cache times", t, func() {
+		cacheTimes := []*models.CacheTime{
+			{ID: "a", Path: "/economy/a", CollectionID: "collection-1", ReleaseTime: &staticTime, Version: 2},
+			{ID: "b", Path: "/economy/b, with a comma"},
+		}
+		dataStoreMock := &mock.DataStoreMock{
+			ExportCacheTimesFunc: func(ctx context.Context, filter models.CacheTimesFilter, export func(*models.CacheTime) error) error {
+				for _, cacheTime := range cacheTimes {
+					if err := export(cacheTime); err != nil {
+						return err
+					}
+				}
+				return nil
+			},
+		}
+		dataStoreAPI := setupPublishingAPI(dataStoreMock)
+
+		Convey("When the cache times are exported without choosing a format", func() {
+			request := newRequestWithAuth(http.MethodGet, exportURL, http.NoBody)
+			responseRecorder := httptest.NewRecorder()
+			dataStoreAPI.Router.ServeHTTP(responseRecorder, request)
+
+			Convey("Then each cache time is returned as a line of NDJSON with status code 200", func() {
+				So(responseRecorder.Code, ShouldEqual, http.StatusOK)
+				So(responseRecorder.Header().Get("Content-Type"), ShouldEqual, "application/x-ndjson; charset=utf-8")
+				So(responseRecorder.Header().Get("Content-Disposition"), ShouldEqual, `attachment; filename="cachetimes.ndjson"`)
+				So(responseRecorder.Header().Get("Vary"), ShouldEqual, "Accept")
+
+				lines := strings.Split(strings.TrimSuffix(responseRecorder.Body.String(), "\n"), "\n")
+				So(lines, ShouldHaveLength, 2)
+				for i, line := range lines {
+					cacheTime := models.CacheTime{}
+					So(json.Unmarshal([]byte(line), &cacheTime), ShouldBeNil)
+					So(cacheTime, ShouldResemble, *cacheTimes[i])
+				}
+			})
+		})
+
+		Convey("When the cache times are exported accepting CSV", func() {
+			request := newRequestWithAuth(http.MethodGet, exportURL, http.NoBody)
+			request.Header.Set("Accept", "text/csv")
+			responseRecorder := httptest.NewRecorder()
+			dataStoreAPI.Router.ServeHTTP(responseRecorder, request)
+
+			Convey("Then each cache time is returned as a CSV record after a header with status code 200", func() {
+				So(responseRecorder.Code, ShouldEqual, http.StatusOK)
+				So(responseRecorder.Header().Get("Content-Type"), ShouldEqual, "text/csv; charset=utf-8")
+				So(responseRecorder.Header().Get("Content-Disposition"), ShouldEqual, `attachment; filename="cachetimes.csv"`)
+
+				records, err := csv.NewReader(responseRecorder.Body).ReadAll()
+				So(err, ShouldBeNil)
+				So(records, ShouldResemble, [][]string{
+					{"_id", "path", "collection_id", "release_time", "created_at", "last_updated", "last_updated_by", "version"},
+					{"a", "/economy/a", "collection-1", staticTime.UTC().Format(time.RFC3339Nano), "", "", "", "2"},
+					{"b", "/economy/b, with a comma", "", "", "", "", "", ""},
+				})
+			})
+		})
+
+		Convey("When the cache times are exported with the csv format parameter", func() {
+			request := newRequestWithAuth(http.MethodGet, exportURL+"?format=csv", http.NoBody)
+			request.Header.Set("Accept", "application/x-ndjson")
+			responseRecorder := httptest.NewRecorder()
+			dataStoreAPI.Router.ServeHTTP(responseRecorder, request)
+
+			Convey("Then the format parameter takes precedence over the Accept header", func() {
+				So(responseRecorder.Code, ShouldEqual, http.StatusOK)
+				So(responseRecorder.Header().Get("Content-Type"), ShouldEqual, "text/csv; charset=utf-8")
+			})
+		})
+
+		Convey("When the cache times are exported with filters", func() {
+			request := newRequestWithAuth(http.MethodGet, exportURL+"?collection_id=collection-1&path_prefix=/economy&release_time_before=2024-01-01T00:00:00Z", http.NoBody)
+			responseRecorder := httptest.NewRecorder()
+			dataStoreAPI.Router.ServeHTTP(responseRecorder, request)
+
+			Convey("Then the filters are passed to the data store", func() {
+				So(responseRecorder.Code, ShouldEqual, http.StatusOK)
+				So(dataStoreMock.ExportCacheTimesCalls(), ShouldHaveLength, 1)
+				filter := dataStoreMock.ExportCacheTimesCalls()[0].Filter
+				So(filter.CollectionID, ShouldEqual, "collection-1")
+				So(filter.PathPrefix, ShouldEqual, "/economy")
+				So(filter.ReleaseTimeBefore.Equal(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)), ShouldBeTrue)
+				So(filter.ReleaseTimeAfter, ShouldBeNil)
+			})
+		})
+
+		Convey("When the cache times are exported accepting only JSON", func() {
+			request := newRequestWithAuth(http.MethodGet, exportURL, http.NoBody)
+			request.Header.Set("Accept", "application/json")
+			responseRecorder := httptest.NewRecorder()
+			dataStoreAPI.Router.ServeHTTP(responseRecorder, request)
+
+			Convey("Then the status code should be 406 and the data store is not queried", func() {
+				So(responseRecorder.Code, ShouldEqual, http.StatusNotAcceptable)
+				So(dataStoreMock.ExportCacheTimesCalls(), ShouldBeEmpty)
+			})
+		})
+
+		Convey("When the cache times are exported with an unknown format", func() {
+			request := newRequestWithAuth(http.MethodGet, exportURL+"?format=xml", http.NoBody)
+			responseRecorder := httptest.NewRecorder()
+			dataStoreAPI.Router.ServeHTTP(responseRecorder, request)
+
+			Convey("Then the status code should be 400", func() {
+				So(responseRecorder.Code, ShouldEqual, http.StatusBadRequest)
+				So(responseRecorder.Body.String(), ShouldContainSubstring, "format should be one of ndjson, csv")
+			})
+		})
+
+		Convey("When the cache times are exported with an invalid filter", func() {
+			request := newRequestWithAuth(http.MethodGet, exportURL+"?release_time_after=yesterday", http.NoBody)
+			responseRecorder := httptest.NewRecorder()
+			dataStoreAPI.Router.ServeHTTP(responseRecorder, request)
+
+			Convey("Then the status code should be 400 and the data store is not queried", func() {
+				So(responseRecorder.Code, ShouldEqual, http.StatusBadRequest)
+				So(responseRecorder.Body.String(), ShouldContainSubstring, "validation errors")
+				So(dataStoreMock.ExportCacheTimesCalls(), ShouldBeEmpty)
+			})
+		})
+	})
+
+	Convey("Given a data store holding no cache times", t, func() {
+		dataStoreMock := &mock.DataStoreMock{
+			ExportCacheTimesFunc: func(ctx context.Context, filter models.CacheTimesFilter, export func(*models.CacheTime) error) error {
+				return nil
+			},
+		}
+		dataStoreAPI := setupPublishingAPI(dataStoreMock)
+
+		Convey("When the cache times are exported as CSV", func() {
+			request := newRequestWithAuth(http.MethodGet, exportURL+"?format=csv", http.NoBody)
+			responseRecorder := httptest.NewRecorder()
+			dataStoreAPI.Router.ServeHTTP(responseRecorder, request)
+
+			Convey("Then only the header is returned with status code 200", func() {
+				So(responseRecorder.Code, ShouldEqual, http.StatusOK)
+				So(responseRecorder.Body.String(), ShouldEqual, "_id,path,collection_id,release_time,created_at,last_updated,last_updated_by,version\n")
+			})
+		})
+
+		Convey("When the cache times are exported as NDJSON", func() {
+			request := newRequestWithAuth(http.MethodGet, exportURL, http.NoBody)
+			responseRecorder := httptest.NewRecorder()
+			dataStoreAPI.Router.ServeHTTP(responseRecorder, request)
+
+			Convey("Then an empty body is returned with status code 200", func() {
+				So(responseRecorder.Code, ShouldEqual, http.StatusOK)
+				So(responseRecorder.Header().Get("Content-Type"), ShouldEqual, "application/x-ndjson; charset=utf-8")
+				So(responseRecorder.Body.String(), ShouldBeEmpty)
+			})
+		})
+	})
+}
+
+func TestExportCacheTimesReturnsError500(t *testing.T) {
+	Convey("Given a data store that fails before reading any cache time", t, func() {
+		dataStoreMock := &mock.DataStoreMock{
+			ExportCacheTimesFunc: func(ctx context.Context, filter models.CacheTimesFilter, export func(*models.CacheTime) error) error {
+				return errors.New("something went wrong in the data store")
+			},
+		}
+		dataStoreAPI := setupPublishingAPI(dataStoreMock)
+
+		Convey("When the cache times are exported as CSV", func() {
+			request := newRequestWithAuth(http.MethodGet, exportURL+"?format=csv", http.NoBody)
+			responseRecorder := httptest.NewRecorder()
+			dataStoreAPI.Router.ServeHTTP(responseRecorder, request)
+
+			Convey("Then a JSON error is returned with status code 500", func() {
+				So(responseRecorder.Code, ShouldEqual, http.StatusInternalServerError)
+				So(responseRecorder.Body.String(), ShouldContainSubstring, "something went wrong in the data store")
+			})
+		})
+	})
+
+	Convey("Given a data store that fails after reading a cache time", t, func() {
+		dataStoreMock := &mock.DataStoreMock{
+			ExportCacheTimesFunc: func(ctx context.Context, filter models.CacheTimesFilter, export func(*models.CacheTime) error) error {
+				if err := export(&models.CacheTime{ID: "a", Path: "/economy/a"}); err != nil {
+					return err
+				}
+				return errors.New("something went wrong in the data store")
+			},
+		}
+		dataStoreAPI := setupPublishingAPI(dataStoreMock)
+
+		Convey("When the cache times are exported", func() {
+			request := newRequestWithAuth(http.MethodGet, exportURL, http.NoBody)
+			responseRecorder := httptest.NewRecorder()
+			dataStoreAPI.Router.ServeHTTP(responseRecorder, request)
+
+			Convey("Then the export is cut short after the cache time already written", func() {
+				So(responseRecorder.Code, ShouldEqual, http.StatusOK)
+				So(responseRecorder.Body.String(), ShouldEqual, `{"_id":"a","path":"/economy/a"}`+"\n")
+			})
+		})
+	})
+}
+
+func TestExportEndpointRequiresAuthentication(t *testing.T) {
+	Convey("Given an API in the publishing subnet", t, func() {
+		dataStoreMock := &mock.DataStoreMock{}
+		api := setupPublishingAPI(dataStoreMock)
+
+		Convey("When we send an unauthenticated request to export the cache times", func() {
+			request := httptest.NewRequest(http.MethodGet, exportURL, http.NoBody)
+			responseRecorder := httptest.NewRecorder()
+			api.Router.ServeHTTP(responseRecorder, request)
+
+			Convey("The status code should be 401", func() {
+				So(responseRecorder.Code, ShouldEqual, http.StatusUnauthorized)
+				So(dataStoreMock.ExportCacheTimesCalls(), ShouldBeEmpty)
+			})
+		})
+	})
+}
@@ -0,0 +1,57 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/ONSdigital/dp-legacy-cache-api/models"
+	"github.com/ONSdigital/log.go/v2/log"
+	"github.com/gorilla/mux"
+)
+
+// GetCacheTimeHistory retrieves a page of the changes made to the cache time with the given ID, most recent first.
+// The history outlives the cache time, so a deleted cache time still has one.
+func (api *API) GetCacheTimeHistory(ctx context.Context, w http.ResponseWriter, req *http.Request) {
+	log.Info(ctx, "calling get cache time history handler")
+
+	vars := mux.Vars(req)
+	id := vars["id"]
+
+	err := isValidID(id)
+	if err != nil {
+		log.Info(ctx, "getCacheTimeHistory endpoint: id failed validation checks")
+		sendJSONError(ctx, w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	offset, limit, e := api.getPaginationParameters(req.URL.Query())
+	if len(e) > 0 {
+		log.Info(ctx, "getCacheTimeHistory endpoint: query parameters failed validation checks")
+		sendJSONError(ctx, w, http.StatusBadRequest, fmt.Sprintf("validation errors: %v", formatErrorList(e)))
+		return
+	}
+
+	changes, totalCount, err := api.dataStore.GetCacheTimeHistory(ctx, id, offset, limit)
+	if err != nil {
+		log.Error(ctx, "getCacheTimeHistory endpoint: api.dataStore.GetCacheTimeHistory internal server error", err)
+		sendJSONError(ctx, w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	history := models.CacheTimeHistory{
+		Items:      changes,
+		Count:      len(changes),
+		Offset:     offset,
+		Limit:      limit,
+		TotalCount: totalCount,
+	}
+
+	w.Header().Set("Cache-Control", api.cacheControl)
+	if err := json.NewEncoder(w).Encode(history); err != nil {
+		log.Error(ctx, "error encoding results to JSON", err)
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		return
+	}
+}
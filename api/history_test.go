@@ -0,0 +1,122 @@
+package api_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ONSdigital/dp-legacy-cache-api/api/mock"
+	errs "github.com/ONSdigital/dp-legacy-cache-api/apierrors"
+	"github.com/ONSdigital/dp-legacy-cache-api/models"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestGetCacheTimeHistory(t *testing.T) {
+	Convey("Given a cache time that was created and then deleted", t, func() {
+		created := &models.CacheTime{ID: testCacheID, Path: "/testpath", ReleaseTime: &staticTime}
+		changes := []*models.CacheTimeChange{
+			models.NewCacheTimeChange(created, nil, "someone@ons.gov.uk", staticTime.Add(1)),
+			models.NewCacheTimeChange(nil, created, "someone@ons.gov.uk", staticTime),
+		}
+		dataStoreMock := &mock.DataStoreMock{
+			GetCacheTimeHistoryFunc: func(ctx context.Context, id string, offset, limit int) ([]*models.CacheTimeChange, int, error) {
+				return changes, len(changes), nil
+			},
+		}
+		dataStoreAPI := setupPublishingAPI(dataStoreMock)
+
+		Convey("When its history is requested", func() {
+			request := newRequestWithAuth(http.MethodGet, baseURL+testCacheID+"/history?offset=0&limit=10", http.NoBody)
+			responseRecorder := httptest.NewRecorder()
+			dataStoreAPI.Router.ServeHTTP(responseRecorder, request)
+
+			Convey("Then the changes are returned with status code 200", func() {
+				So(responseRecorder.Code, ShouldEqual, http.StatusOK)
+
+				history := models.CacheTimeHistory{}
+				err := json.Unmarshal(responseRecorder.Body.Bytes(), &history)
+				So(err, ShouldBeNil)
+				So(history.Count, ShouldEqual, 2)
+				So(history.TotalCount, ShouldEqual, 2)
+				So(history.Limit, ShouldEqual, 10)
+				So(history.Items[0].Action, ShouldEqual, models.CacheTimeDeleted)
+				So(history.Items[0].Previous.Path, ShouldEqual, "/testpath")
+				So(history.Items[0].Current, ShouldBeNil)
+				So(history.Items[1].Action, ShouldEqual, models.CacheTimeCreated)
+				So(history.Items[1].ChangedBy, ShouldEqual, "someone@ons.gov.uk")
+
+				So(dataStoreMock.GetCacheTimeHistoryCalls(), ShouldHaveLength, 1)
+				So(dataStoreMock.GetCacheTimeHistoryCalls()[0].ID, ShouldEqual, testCacheID)
+				So(dataStoreMock.GetCacheTimeHistoryCalls()[0].Limit, ShouldEqual, 10)
+			})
+		})
+
+		Convey("When the history of an invalid id is requested", func() {
+			request := newRequestWithAuth(http.MethodGet, baseURL+"invalid/history", http.NoBody)
+			responseRecorder := httptest.NewRecorder()
+			dataStoreAPI.Router.ServeHTTP(responseRecorder, request)
+
+			Convey("Then a 400 is returned and the data store is not called", func() {
+				So(responseRecorder.Code, ShouldEqual, http.StatusBadRequest)
+				So(responseRecorder.Body.String(), ShouldContainSubstring, "id should be 32 characters in length")
+				So(dataStoreMock.GetCacheTimeHistoryCalls(), ShouldBeEmpty)
+			})
+		})
+
+		Convey("When the history is requested with a limit that is too high", func() {
+			request := newRequestWithAuth(http.MethodGet, baseURL+testCacheID+"/history?limit=1001", http.NoBody)
+			responseRecorder := httptest.NewRecorder()
+			dataStoreAPI.Router.ServeHTTP(responseRecorder, request)
+
+			Convey("Then a 400 is returned with the limit error in the response", func() {
+				So(responseRecorder.Code, ShouldEqual, http.StatusBadRequest)
+				So(responseRecorder.Body.String(), ShouldContainSubstring, "limit should not exceed 1000")
+				So(dataStoreMock.GetCacheTimeHistoryCalls(), ShouldBeEmpty)
+			})
+		})
+
+		Convey("When the history is requested with a limit of 0", func() {
+			request := newRequestWithAuth(http.MethodGet, baseURL+testCacheID+"/history?limit=0", http.NoBody)
+			responseRecorder := httptest.NewRecorder()
+			dataStoreAPI.Router.ServeHTTP(responseRecorder, request)
+
+			Convey("Then a 400 is returned rather than the whole history", func() {
+				So(responseRecorder.Code, ShouldEqual, http.StatusBadRequest)
+				So(responseRecorder.Body.String(), ShouldContainSubstring, "limit should be a positive integer")
+				So(dataStoreMock.GetCacheTimeHistoryCalls(), ShouldBeEmpty)
+			})
+		})
+
+		Convey("When the history is requested without authentication", func() {
+			request := httptest.NewRequest(http.MethodGet, baseURL+testCacheID+"/history", http.NoBody)
+			responseRecorder := httptest.NewRecorder()
+			dataStoreAPI.Router.ServeHTTP(responseRecorder, request)
+
+			Convey("Then the status code should be 401", func() {
+				So(responseRecorder.Code, ShouldEqual, http.StatusUnauthorized)
+				So(dataStoreMock.GetCacheTimeHistoryCalls(), ShouldBeEmpty)
+			})
+		})
+	})
+
+	Convey("Given a data store that fails", t, func() {
+		dataStoreMock := &mock.DataStoreMock{
+			GetCacheTimeHistoryFunc: func(ctx context.Context, id string, offset, limit int) ([]*models.CacheTimeChange, int, error) {
+				return nil, 0, errs.ErrDataStore
+			},
+		}
+		dataStoreAPI := setupPublishingAPI(dataStoreMock)
+
+		Convey("When a history is requested", func() {
+			request := newRequestWithAuth(http.MethodGet, baseURL+testCacheID+"/history", http.NoBody)
+			responseRecorder := httptest.NewRecorder()
+			dataStoreAPI.Router.ServeHTTP(responseRecorder, request)
+
+			Convey("Then a 500 is returned", func() {
+				So(responseRecorder.Code, ShouldEqual, http.StatusInternalServerError)
+			})
+		})
+	})
+}
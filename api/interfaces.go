@@ -2,13 +2,14 @@ package api
 
 import (
 	"context"
+	"time"
 
 	"github.com/ONSdigital/dp-healthcheck/healthcheck"
 	"github.com/ONSdigital/dp-legacy-cache-api/models"
 )
 
 //go:generate moq -out mock/dataStore.go -pkg mock . DataStore
-//go:generate moq -out ../service/mock/store.go -pkg mock . DataStore
+//go:generate moq -out mock/eventPublisher.go -pkg mock . EventPublisher
 
 // DataStore defines the behaviour of a DataStore
 type DataStore interface {
@@ -16,5 +17,18 @@ type DataStore interface {
 	Close(ctx context.Context) error
 	IsConnected(ctx context.Context) bool
 	GetCacheTime(ctx context.Context, id string) (*models.CacheTime, error)
-	UpsertCacheTime(ctx context.Context, cacheTime *models.CacheTime) error
+	GetCacheTimes(ctx context.Context, filter models.CacheTimesFilter, offset, limit int) ([]*models.CacheTime, int, error)
+	GetCacheTimesReleasedBetween(ctx context.Context, from, to time.Time) ([]*models.CacheTime, error)
+	ExportCacheTimes(ctx context.Context, filter models.CacheTimesFilter, export func(*models.CacheTime) error) error
+	UpsertCacheTime(ctx context.Context, cacheTime *models.CacheTime, precondition models.Precondition) (*models.CacheTimeChange, error)
+	UpsertCacheTimes(ctx context.Context, cacheTimes []*models.CacheTime) ([]models.UpsertResult, error)
+	DeleteCacheTime(ctx context.Context, id string, precondition models.Precondition) (*models.CacheTimeChange, error)
+	UpdateCollectionReleaseTime(ctx context.Context, collectionID string, releaseTime *time.Time) ([]*models.CacheTimeChange, error)
+	DeleteCollectionCacheTimes(ctx context.Context, collectionID string) ([]*models.CacheTimeChange, error)
+	GetCacheTimeHistory(ctx context.Context, id string, offset, limit int) ([]*models.CacheTimeChange, int, error)
+}
+
+// EventPublisher announces the changes made to cache times to downstream caches
+type EventPublisher interface {
+	Publish(ctx context.Context, change *models.CacheTimeChange) error
 }
@@ -9,6 +9,7 @@ import (
 	"github.com/ONSdigital/dp-legacy-cache-api/api"
 	"github.com/ONSdigital/dp-legacy-cache-api/models"
 	"sync"
+	"time"
 )
 
 // Ensure, that DataStoreMock does implement api.DataStore.
@@ -27,15 +28,39 @@ var _ api.DataStore = &DataStoreMock{}
 //			CloseFunc: func(ctx context.Context) error {
 //				panic("mock out the Close method")
 //			},
+//			DeleteCacheTimeFunc: func(ctx context.Context, id string, precondition models.Precondition) (*models.CacheTimeChange, error) {
+//				panic("mock out the DeleteCacheTime method")
+//			},
+//			DeleteCollectionCacheTimesFunc: func(ctx context.Context, collectionID string) ([]*models.CacheTimeChange, error) {
+//				panic("mock out the DeleteCollectionCacheTimes method")
+//			},
+//			ExportCacheTimesFunc: func(ctx context.Context, filter models.CacheTimesFilter, export func(*models.CacheTime) error) error {
+//				panic("mock out the ExportCacheTimes method")
+//			},
 //			GetCacheTimeFunc: func(ctx context.Context, id string) (*models.CacheTime, error) {
 //				panic("mock out the GetCacheTime method")
 //			},
+//			GetCacheTimeHistoryFunc: func(ctx context.Context, id string, offset int, limit int) ([]*models.CacheTimeChange, int, error) {
+//				panic("mock out the GetCacheTimeHistory method")
+//			},
+//			GetCacheTimesFunc: func(ctx context.Context, filter models.CacheTimesFilter, offset int, limit int) ([]*models.CacheTime, int, error) {
+//				panic("mock out the GetCacheTimes method")
+//			},
+//			GetCacheTimesReleasedBetweenFunc: func(ctx context.Context, from time.Time, to time.Time) ([]*models.CacheTime, error) {
+//				panic("mock out the GetCacheTimesReleasedBetween method")
+//			},
 //			IsConnectedFunc: func(ctx context.Context) bool {
 //				panic("mock out the IsConnected method")
 //			},
-//			UpsertCacheTimeFunc: func(ctx context.Context, cacheTime *models.CacheTime) error {
+//			UpdateCollectionReleaseTimeFunc: func(ctx context.Context, collectionID string, releaseTime *time.Time) ([]*models.CacheTimeChange, error) {
+//				panic("mock out the UpdateCollectionReleaseTime method")
+//			},
+//			UpsertCacheTimeFunc: func(ctx context.Context, cacheTime *models.CacheTime, precondition models.Precondition) (*models.CacheTimeChange, error) {
 //				panic("mock out the UpsertCacheTime method")
 //			},
+//			UpsertCacheTimesFunc: func(ctx context.Context, cacheTimes []*models.CacheTime) ([]models.UpsertResult, error) {
+//				panic("mock out the UpsertCacheTimes method")
+//			},
 //		}
 //
 //		// use mockedDataStore in code that requires api.DataStore
@@ -49,14 +74,38 @@ type DataStoreMock struct {
 	// CloseFunc mocks the Close method.
 	CloseFunc func(ctx context.Context) error
 
+	// DeleteCacheTimeFunc mocks the DeleteCacheTime method.
+	DeleteCacheTimeFunc func(ctx context.Context, id string, precondition models.Precondition) (*models.CacheTimeChange, error)
+
+	// DeleteCollectionCacheTimesFunc mocks the DeleteCollectionCacheTimes method.
+	DeleteCollectionCacheTimesFunc func(ctx context.Context, collectionID string) ([]*models.CacheTimeChange, error)
+
+	// ExportCacheTimesFunc mocks the ExportCacheTimes method.
+	ExportCacheTimesFunc func(ctx context.Context, filter models.CacheTimesFilter, export func(*models.CacheTime) error) error
+
 	// GetCacheTimeFunc mocks the GetCacheTime method.
 	GetCacheTimeFunc func(ctx context.Context, id string) (*models.CacheTime, error)
 
+	// GetCacheTimeHistoryFunc mocks the GetCacheTimeHistory method.
+	GetCacheTimeHistoryFunc func(ctx context.Context, id string, offset int, limit int) ([]*models.CacheTimeChange, int, error)
+
+	// GetCacheTimesFunc mocks the GetCacheTimes method.
+	GetCacheTimesFunc func(ctx context.Context, filter models.CacheTimesFilter, offset int, limit int) ([]*models.CacheTime, int, error)
+
+	// GetCacheTimesReleasedBetweenFunc mocks the GetCacheTimesReleasedBetween method.
+	GetCacheTimesReleasedBetweenFunc func(ctx context.Context, from time.Time, to time.Time) ([]*models.CacheTime, error)
+
 	// IsConnectedFunc mocks the IsConnected method.
 	IsConnectedFunc func(ctx context.Context) bool
 
+	// UpdateCollectionReleaseTimeFunc mocks the UpdateCollectionReleaseTime method.
+	UpdateCollectionReleaseTimeFunc func(ctx context.Context, collectionID string, releaseTime *time.Time) ([]*models.CacheTimeChange, error)
+
 	// UpsertCacheTimeFunc mocks the UpsertCacheTime method.
-	UpsertCacheTimeFunc func(ctx context.Context, cacheTime *models.CacheTime) error
+	UpsertCacheTimeFunc func(ctx context.Context, cacheTime *models.CacheTime, precondition models.Precondition) (*models.CacheTimeChange, error)
+
+	// UpsertCacheTimesFunc mocks the UpsertCacheTimes method.
+	UpsertCacheTimesFunc func(ctx context.Context, cacheTimes []*models.CacheTime) ([]models.UpsertResult, error)
 
 	// calls tracks calls to the methods.
 	calls struct {
@@ -72,6 +121,31 @@ type DataStoreMock struct {
 			// Ctx is the ctx argument value.
 			Ctx context.Context
 		}
+		// DeleteCacheTime holds details about calls to the DeleteCacheTime method.
+		DeleteCacheTime []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ID is the id argument value.
+			ID string
+			// Precondition is the precondition argument value.
+			Precondition models.Precondition
+		}
+		// DeleteCollectionCacheTimes holds details about calls to the DeleteCollectionCacheTimes method.
+		DeleteCollectionCacheTimes []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// CollectionID is the collectionID argument value.
+			CollectionID string
+		}
+		// ExportCacheTimes holds details about calls to the ExportCacheTimes method.
+		ExportCacheTimes []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Filter is the filter argument value.
+			Filter models.CacheTimesFilter
+			// Export is the export argument value.
+			Export func(*models.CacheTime) error
+		}
 		// GetCacheTime holds details about calls to the GetCacheTime method.
 		GetCacheTime []struct {
 			// Ctx is the ctx argument value.
@@ -79,24 +153,81 @@ type DataStoreMock struct {
 			// ID is the id argument value.
 			ID string
 		}
+		// GetCacheTimeHistory holds details about calls to the GetCacheTimeHistory method.
+		GetCacheTimeHistory []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ID is the id argument value.
+			ID string
+			// Offset is the offset argument value.
+			Offset int
+			// Limit is the limit argument value.
+			Limit int
+		}
+		// GetCacheTimes holds details about calls to the GetCacheTimes method.
+		GetCacheTimes []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Filter is the filter argument value.
+			Filter models.CacheTimesFilter
+			// Offset is the offset argument value.
+			Offset int
+			// Limit is the limit argument value.
+			Limit int
+		}
+		// GetCacheTimesReleasedBetween holds details about calls to the GetCacheTimesReleasedBetween method.
+		GetCacheTimesReleasedBetween []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// From is the from argument value.
+			From time.Time
+			// To is the to argument value.
+			To time.Time
+		}
 		// IsConnected holds details about calls to the IsConnected method.
 		IsConnected []struct {
 			// Ctx is the ctx argument value.
 			Ctx context.Context
 		}
+		// UpdateCollectionReleaseTime holds details about calls to the UpdateCollectionReleaseTime method.
+		UpdateCollectionReleaseTime []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// CollectionID is the collectionID argument value.
+			CollectionID string
+			// ReleaseTime is the releaseTime argument value.
+			ReleaseTime *time.Time
+		}
 		// UpsertCacheTime holds details about calls to the UpsertCacheTime method.
 		UpsertCacheTime []struct {
 			// Ctx is the ctx argument value.
 			Ctx context.Context
 			// CacheTime is the cacheTime argument value.
 			CacheTime *models.CacheTime
+			// Precondition is the precondition argument value.
+			Precondition models.Precondition
+		}
+		// UpsertCacheTimes holds details about calls to the UpsertCacheTimes method.
+		UpsertCacheTimes []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// CacheTimes is the cacheTimes argument value.
+			CacheTimes []*models.CacheTime
 		}
 	}
-	lockChecker         sync.RWMutex
-	lockClose           sync.RWMutex
-	lockGetCacheTime    sync.RWMutex
-	lockIsConnected     sync.RWMutex
-	lockUpsertCacheTime sync.RWMutex
+	lockChecker                      sync.RWMutex
+	lockClose                        sync.RWMutex
+	lockDeleteCacheTime              sync.RWMutex
+	lockDeleteCollectionCacheTimes   sync.RWMutex
+	lockExportCacheTimes             sync.RWMutex
+	lockGetCacheTime                 sync.RWMutex
+	lockGetCacheTimeHistory          sync.RWMutex
+	lockGetCacheTimes                sync.RWMutex
+	lockGetCacheTimesReleasedBetween sync.RWMutex
+	lockIsConnected                  sync.RWMutex
+	lockUpdateCollectionReleaseTime  sync.RWMutex
+	lockUpsertCacheTime              sync.RWMutex
+	lockUpsertCacheTimes             sync.RWMutex
 }
 
 // Checker calls CheckerFunc.
@@ -167,6 +298,122 @@ func (mock *DataStoreMock) CloseCalls() []struct {
 	return calls
 }
 
+// DeleteCacheTime calls DeleteCacheTimeFunc.
+func (mock *DataStoreMock) DeleteCacheTime(ctx context.Context, id string, precondition models.Precondition) (*models.CacheTimeChange, error) {
+	if mock.DeleteCacheTimeFunc == nil {
+		panic("DataStoreMock.DeleteCacheTimeFunc: method is nil but DataStore.DeleteCacheTime was just called")
+	}
+	callInfo := struct {
+		Ctx          context.Context
+		ID           string
+		Precondition models.Precondition
+	}{
+		Ctx:          ctx,
+		ID:           id,
+		Precondition: precondition,
+	}
+	mock.lockDeleteCacheTime.Lock()
+	mock.calls.DeleteCacheTime = append(mock.calls.DeleteCacheTime, callInfo)
+	mock.lockDeleteCacheTime.Unlock()
+	return mock.DeleteCacheTimeFunc(ctx, id, precondition)
+}
+
+// DeleteCacheTimeCalls gets all the calls that were made to DeleteCacheTime.
+// Check the length with:
+//
+//	len(mockedDataStore.DeleteCacheTimeCalls())
+func (mock *DataStoreMock) DeleteCacheTimeCalls() []struct {
+	Ctx          context.Context
+	ID           string
+	Precondition models.Precondition
+} {
+	var calls []struct {
+		Ctx          context.Context
+		ID           string
+		Precondition models.Precondition
+	}
+	mock.lockDeleteCacheTime.RLock()
+	calls = mock.calls.DeleteCacheTime
+	mock.lockDeleteCacheTime.RUnlock()
+	return calls
+}
+
+// DeleteCollectionCacheTimes calls DeleteCollectionCacheTimesFunc.
+func (mock *DataStoreMock) DeleteCollectionCacheTimes(ctx context.Context, collectionID string) ([]*models.CacheTimeChange, error) {
+	if mock.DeleteCollectionCacheTimesFunc == nil {
+		panic("DataStoreMock.DeleteCollectionCacheTimesFunc: method is nil but DataStore.DeleteCollectionCacheTimes was just called")
+	}
+	callInfo := struct {
+		Ctx          context.Context
+		CollectionID string
+	}{
+		Ctx:          ctx,
+		CollectionID: collectionID,
+	}
+	mock.lockDeleteCollectionCacheTimes.Lock()
+	mock.calls.DeleteCollectionCacheTimes = append(mock.calls.DeleteCollectionCacheTimes, callInfo)
+	mock.lockDeleteCollectionCacheTimes.Unlock()
+	return mock.DeleteCollectionCacheTimesFunc(ctx, collectionID)
+}
+
+// DeleteCollectionCacheTimesCalls gets all the calls that were made to DeleteCollectionCacheTimes.
+// Check the length with:
+//
+//	len(mockedDataStore.DeleteCollectionCacheTimesCalls())
+func (mock *DataStoreMock) DeleteCollectionCacheTimesCalls() []struct {
+	Ctx          context.Context
+	CollectionID string
+} {
+	var calls []struct {
+		Ctx          context.Context
+		CollectionID string
+	}
+	mock.lockDeleteCollectionCacheTimes.RLock()
+	calls = mock.calls.DeleteCollectionCacheTimes
+	mock.lockDeleteCollectionCacheTimes.RUnlock()
+	return calls
+}
+
+// ExportCacheTimes calls ExportCacheTimesFunc.
+func (mock *DataStoreMock) ExportCacheTimes(ctx context.Context, filter models.CacheTimesFilter, export func(*models.CacheTime) error) error {
+	if mock.ExportCacheTimesFunc == nil {
+		panic("DataStoreMock.ExportCacheTimesFunc: method is nil but DataStore.ExportCacheTimes was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		Filter models.CacheTimesFilter
+		Export func(*models.CacheTime) error
+	}{
+		Ctx:    ctx,
+		Filter: filter,
+		Export: export,
+	}
+	mock.lockExportCacheTimes.Lock()
+	mock.calls.ExportCacheTimes = append(mock.calls.ExportCacheTimes, callInfo)
+	mock.lockExportCacheTimes.Unlock()
+	return mock.ExportCacheTimesFunc(ctx, filter, export)
+}
+
+// ExportCacheTimesCalls gets all the calls that were made to ExportCacheTimes.
+// Check the length with:
+//
+//	len(mockedDataStore.ExportCacheTimesCalls())
+func (mock *DataStoreMock) ExportCacheTimesCalls() []struct {
+	Ctx    context.Context
+	Filter models.CacheTimesFilter
+	Export func(*models.CacheTime) error
+} {
+	var calls []struct {
+		Ctx    context.Context
+		Filter models.CacheTimesFilter
+		Export func(*models.CacheTime) error
+	}
+	mock.lockExportCacheTimes.RLock()
+	calls = mock.calls.ExportCacheTimes
+	mock.lockExportCacheTimes.RUnlock()
+	return calls
+}
+
 // GetCacheTime calls GetCacheTimeFunc.
 func (mock *DataStoreMock) GetCacheTime(ctx context.Context, id string) (*models.CacheTime, error) {
 	if mock.GetCacheTimeFunc == nil {
@@ -203,6 +450,134 @@ func (mock *DataStoreMock) GetCacheTimeCalls() []struct {
 	return calls
 }
 
+// GetCacheTimeHistory calls GetCacheTimeHistoryFunc.
+func (mock *DataStoreMock) GetCacheTimeHistory(ctx context.Context, id string, offset int, limit int) ([]*models.CacheTimeChange, int, error) {
+	if mock.GetCacheTimeHistoryFunc == nil {
+		panic("DataStoreMock.GetCacheTimeHistoryFunc: method is nil but DataStore.GetCacheTimeHistory was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		ID     string
+		Offset int
+		Limit  int
+	}{
+		Ctx:    ctx,
+		ID:     id,
+		Offset: offset,
+		Limit:  limit,
+	}
+	mock.lockGetCacheTimeHistory.Lock()
+	mock.calls.GetCacheTimeHistory = append(mock.calls.GetCacheTimeHistory, callInfo)
+	mock.lockGetCacheTimeHistory.Unlock()
+	return mock.GetCacheTimeHistoryFunc(ctx, id, offset, limit)
+}
+
+// GetCacheTimeHistoryCalls gets all the calls that were made to GetCacheTimeHistory.
+// Check the length with:
+//
+//	len(mockedDataStore.GetCacheTimeHistoryCalls())
+func (mock *DataStoreMock) GetCacheTimeHistoryCalls() []struct {
+	Ctx    context.Context
+	ID     string
+	Offset int
+	Limit  int
+} {
+	var calls []struct {
+		Ctx    context.Context
+		ID     string
+		Offset int
+		Limit  int
+	}
+	mock.lockGetCacheTimeHistory.RLock()
+	calls = mock.calls.GetCacheTimeHistory
+	mock.lockGetCacheTimeHistory.RUnlock()
+	return calls
+}
+
+// GetCacheTimes calls GetCacheTimesFunc.
+func (mock *DataStoreMock) GetCacheTimes(ctx context.Context, filter models.CacheTimesFilter, offset int, limit int) ([]*models.CacheTime, int, error) {
+	if mock.GetCacheTimesFunc == nil {
+		panic("DataStoreMock.GetCacheTimesFunc: method is nil but DataStore.GetCacheTimes was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		Filter models.CacheTimesFilter
+		Offset int
+		Limit  int
+	}{
+		Ctx:    ctx,
+		Filter: filter,
+		Offset: offset,
+		Limit:  limit,
+	}
+	mock.lockGetCacheTimes.Lock()
+	mock.calls.GetCacheTimes = append(mock.calls.GetCacheTimes, callInfo)
+	mock.lockGetCacheTimes.Unlock()
+	return mock.GetCacheTimesFunc(ctx, filter, offset, limit)
+}
+
+// GetCacheTimesCalls gets all the calls that were made to GetCacheTimes.
+// Check the length with:
+//
+//	len(mockedDataStore.GetCacheTimesCalls())
+func (mock *DataStoreMock) GetCacheTimesCalls() []struct {
+	Ctx    context.Context
+	Filter models.CacheTimesFilter
+	Offset int
+	Limit  int
+} {
+	var calls []struct {
+		Ctx    context.Context
+		Filter models.CacheTimesFilter
+		Offset int
+		Limit  int
+	}
+	mock.lockGetCacheTimes.RLock()
+	calls = mock.calls.GetCacheTimes
+	mock.lockGetCacheTimes.RUnlock()
+	return calls
+}
+
+// GetCacheTimesReleasedBetween calls GetCacheTimesReleasedBetweenFunc.
+func (mock *DataStoreMock) GetCacheTimesReleasedBetween(ctx context.Context, from time.Time, to time.Time) ([]*models.CacheTime, error) {
+	if mock.GetCacheTimesReleasedBetweenFunc == nil {
+		panic("DataStoreMock.GetCacheTimesReleasedBetweenFunc: method is nil but DataStore.GetCacheTimesReleasedBetween was just called")
+	}
+	callInfo := struct {
+		Ctx  context.Context
+		From time.Time
+		To   time.Time
+	}{
+		Ctx:  ctx,
+		From: from,
+		To:   to,
+	}
+	mock.lockGetCacheTimesReleasedBetween.Lock()
+	mock.calls.GetCacheTimesReleasedBetween = append(mock.calls.GetCacheTimesReleasedBetween, callInfo)
+	mock.lockGetCacheTimesReleasedBetween.Unlock()
+	return mock.GetCacheTimesReleasedBetweenFunc(ctx, from, to)
+}
+
+// GetCacheTimesReleasedBetweenCalls gets all the calls that were made to GetCacheTimesReleasedBetween.
+// Check the length with:
+//
+//	len(mockedDataStore.GetCacheTimesReleasedBetweenCalls())
+func (mock *DataStoreMock) GetCacheTimesReleasedBetweenCalls() []struct {
+	Ctx  context.Context
+	From time.Time
+	To   time.Time
+} {
+	var calls []struct {
+		Ctx  context.Context
+		From time.Time
+		To   time.Time
+	}
+	mock.lockGetCacheTimesReleasedBetween.RLock()
+	calls = mock.calls.GetCacheTimesReleasedBetween
+	mock.lockGetCacheTimesReleasedBetween.RUnlock()
+	return calls
+}
+
 // IsConnected calls IsConnectedFunc.
 func (mock *DataStoreMock) IsConnected(ctx context.Context) bool {
 	if mock.IsConnectedFunc == nil {
@@ -235,22 +610,64 @@ func (mock *DataStoreMock) IsConnectedCalls() []struct {
 	return calls
 }
 
+// UpdateCollectionReleaseTime calls UpdateCollectionReleaseTimeFunc.
+func (mock *DataStoreMock) UpdateCollectionReleaseTime(ctx context.Context, collectionID string, releaseTime *time.Time) ([]*models.CacheTimeChange, error) {
+	if mock.UpdateCollectionReleaseTimeFunc == nil {
+		panic("DataStoreMock.UpdateCollectionReleaseTimeFunc: method is nil but DataStore.UpdateCollectionReleaseTime was just called")
+	}
+	callInfo := struct {
+		Ctx          context.Context
+		CollectionID string
+		ReleaseTime  *time.Time
+	}{
+		Ctx:          ctx,
+		CollectionID: collectionID,
+		ReleaseTime:  releaseTime,
+	}
+	mock.lockUpdateCollectionReleaseTime.Lock()
+	mock.calls.UpdateCollectionReleaseTime = append(mock.calls.UpdateCollectionReleaseTime, callInfo)
+	mock.lockUpdateCollectionReleaseTime.Unlock()
+	return mock.UpdateCollectionReleaseTimeFunc(ctx, collectionID, releaseTime)
+}
+
+// UpdateCollectionReleaseTimeCalls gets all the calls that were made to UpdateCollectionReleaseTime.
+// Check the length with:
+//
+//	len(mockedDataStore.UpdateCollectionReleaseTimeCalls())
+func (mock *DataStoreMock) UpdateCollectionReleaseTimeCalls() []struct {
+	Ctx          context.Context
+	CollectionID string
+	ReleaseTime  *time.Time
+} {
+	var calls []struct {
+		Ctx          context.Context
+		CollectionID string
+		ReleaseTime  *time.Time
+	}
+	mock.lockUpdateCollectionReleaseTime.RLock()
+	calls = mock.calls.UpdateCollectionReleaseTime
+	mock.lockUpdateCollectionReleaseTime.RUnlock()
+	return calls
+}
+
 // UpsertCacheTime calls UpsertCacheTimeFunc.
-func (mock *DataStoreMock) UpsertCacheTime(ctx context.Context, cacheTime *models.CacheTime) error {
+func (mock *DataStoreMock) UpsertCacheTime(ctx context.Context, cacheTime *models.CacheTime, precondition models.Precondition) (*models.CacheTimeChange, error) {
 	if mock.UpsertCacheTimeFunc == nil {
 		panic("DataStoreMock.UpsertCacheTimeFunc: method is nil but DataStore.UpsertCacheTime was just called")
 	}
 	callInfo := struct {
-		Ctx       context.Context
-		CacheTime *models.CacheTime
+		Ctx          context.Context
+		CacheTime    *models.CacheTime
+		Precondition models.Precondition
 	}{
-		Ctx:       ctx,
-		CacheTime: cacheTime,
+		Ctx:          ctx,
+		CacheTime:    cacheTime,
+		Precondition: precondition,
 	}
 	mock.lockUpsertCacheTime.Lock()
 	mock.calls.UpsertCacheTime = append(mock.calls.UpsertCacheTime, callInfo)
 	mock.lockUpsertCacheTime.Unlock()
-	return mock.UpsertCacheTimeFunc(ctx, cacheTime)
+	return mock.UpsertCacheTimeFunc(ctx, cacheTime, precondition)
 }
 
 // UpsertCacheTimeCalls gets all the calls that were made to UpsertCacheTime.
@@ -258,15 +675,53 @@ func (mock *DataStoreMock) UpsertCacheTime(ctx context.Context, cacheTime *model
 //
 //	len(mockedDataStore.UpsertCacheTimeCalls())
 func (mock *DataStoreMock) UpsertCacheTimeCalls() []struct {
-	Ctx       context.Context
-	CacheTime *models.CacheTime
+	Ctx          context.Context
+	CacheTime    *models.CacheTime
+	Precondition models.Precondition
 } {
 	var calls []struct {
-		Ctx       context.Context
-		CacheTime *models.CacheTime
+		Ctx          context.Context
+		CacheTime    *models.CacheTime
+		Precondition models.Precondition
 	}
 	mock.lockUpsertCacheTime.RLock()
 	calls = mock.calls.UpsertCacheTime
 	mock.lockUpsertCacheTime.RUnlock()
 	return calls
 }
+
+// UpsertCacheTimes calls UpsertCacheTimesFunc.
+func (mock *DataStoreMock) UpsertCacheTimes(ctx context.Context, cacheTimes []*models.CacheTime) ([]models.UpsertResult, error) {
+	if mock.UpsertCacheTimesFunc == nil {
+		panic("DataStoreMock.UpsertCacheTimesFunc: method is nil but DataStore.UpsertCacheTimes was just called")
+	}
+	callInfo := struct {
+		Ctx        context.Context
+		CacheTimes []*models.CacheTime
+	}{
+		Ctx:        ctx,
+		CacheTimes: cacheTimes,
+	}
+	mock.lockUpsertCacheTimes.Lock()
+	mock.calls.UpsertCacheTimes = append(mock.calls.UpsertCacheTimes, callInfo)
+	mock.lockUpsertCacheTimes.Unlock()
+	return mock.UpsertCacheTimesFunc(ctx, cacheTimes)
+}
+
+// UpsertCacheTimesCalls gets all the calls that were made to UpsertCacheTimes.
+// Check the length with:
+//
+//	len(mockedDataStore.UpsertCacheTimesCalls())
+func (mock *DataStoreMock) UpsertCacheTimesCalls() []struct {
+	Ctx        context.Context
+	CacheTimes []*models.CacheTime
+} {
+	var calls []struct {
+		Ctx        context.Context
+		CacheTimes []*models.CacheTime
+	}
+	mock.lockUpsertCacheTimes.RLock()
+	calls = mock.calls.UpsertCacheTimes
+	mock.lockUpsertCacheTimes.RUnlock()
+	return calls
+}
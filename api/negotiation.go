@@ -0,0 +1,112 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+
+	errs "github.com/ONSdigital/dp-legacy-cache-api/apierrors"
+)
+
+// Media types of the representations that can be negotiated
+const (
+	jsonContentType     = "application/json"
+	calendarContentType = "text/calendar"
+	csvContentType      = "text/csv"
+)
+
+// representation is a way of rendering a response, which the client selects with the Accept header or, when it cannot
+// set headers, with the format query parameter
+type representation struct {
+	format    string
+	mediaType string
+}
+
+var (
+	jsonRepresentation     = representation{format: "json", mediaType: jsonContentType}
+	calendarRepresentation = representation{format: "ics", mediaType: calendarContentType}
+	ndjsonRepresentation   = representation{format: "ndjson", mediaType: ndjsonContentType}
+	csvRepresentation      = representation{format: "csv", mediaType: csvContentType}
+)
+
+// negotiate returns the representation of the response preferred by the request out of those offered, the first being
+// the default. The format query parameter takes precedence over the Accept header. ErrNotAcceptable is returned when
+// the Accept header excludes every representation offered.
+func negotiate(req *http.Request, offered ...representation) (representation, error) {
+	if format := req.URL.Query().Get("format"); format != "" {
+		formats := make([]string, len(offered))
+		for i, r := range offered {
+			if r.format == format {
+				return r, nil
+			}
+			formats[i] = r.format
+		}
+		err := fmt.Errorf("format should be one of %s", strings.Join(formats, ", "))
+		return representation{}, fmt.Errorf("validation errors: %v", formatErrorList([]error{err}))
+	}
+
+	accept := req.Header.Values("Accept")
+	if len(accept) == 0 {
+		return offered[0], nil
+	}
+
+	best, bestQuality := representation{}, 0.0
+	for _, r := range offered {
+		if quality := acceptQuality(accept, r.mediaType); quality > bestQuality {
+			best, bestQuality = r, quality
+		}
+	}
+	if bestQuality == 0 {
+		return representation{}, errs.ErrNotAcceptable
+	}
+	return best, nil
+}
+
+// acceptQuality returns the quality given to mediaType by the values of an Accept header, from its most specific
+// matching media range. Zero means mediaType is not acceptable, and media ranges that cannot be parsed are skipped.
+func acceptQuality(accept []string, mediaType string) float64 {
+	quality, specificity := 0.0, -1
+	for _, value := range accept {
+		for _, mediaRange := range strings.Split(value, ",") {
+			rangeType, params, err := mime.ParseMediaType(mediaRange)
+			if err != nil {
+				continue
+			}
+
+			var rangeSpecificity int
+			switch {
+			case rangeType == mediaType:
+				rangeSpecificity = 2
+			case rangeType == "*/*":
+				rangeSpecificity = 0
+			case strings.HasSuffix(rangeType, "/*") && strings.HasPrefix(mediaType, strings.TrimSuffix(rangeType, "*")):
+				rangeSpecificity = 1
+			default:
+				continue
+			}
+			if rangeSpecificity <= specificity {
+				continue
+			}
+
+			specificity, quality = rangeSpecificity, 1
+			if q, ok := params["q"]; ok {
+				if quality, err = strconv.ParseFloat(q, 64); err != nil {
+					quality = 0
+				}
+			}
+		}
+	}
+	return quality
+}
+
+// negotiationErrorStatus returns the status code of a response refusing a request because of the representation it
+// asked for
+func negotiationErrorStatus(err error) int {
+	if errors.Is(err, errs.ErrNotAcceptable) {
+		return http.StatusNotAcceptable
+	}
+	return http.StatusBadRequest
+}
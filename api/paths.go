@@ -0,0 +1,65 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/ONSdigital/dp-legacy-cache-api/models"
+	"github.com/ONSdigital/log.go/v2/log"
+)
+
+// GetCacheTimeByPath retrieves the cache time for the page path given in the query string, deriving its ID so that
+// callers do not need to hash the path themselves
+func (api *API) GetCacheTimeByPath(ctx context.Context, w http.ResponseWriter, req *http.Request) {
+	log.Info(ctx, "calling get cache time by path handler")
+
+	path := models.NormalisePath(req.URL.Query().Get("path"))
+	if path == "" {
+		log.Info(ctx, "getCacheTimeByPath endpoint: path failed validation checks")
+		sendJSONError(ctx, w, http.StatusBadRequest, fmt.Sprintf("validation errors: %v", formatErrorList([]error{errors.New("path query parameter is empty")})))
+		return
+	}
+
+	api.getCacheTime(ctx, w, req, models.HashPath(path))
+}
+
+// CreateOrUpdateCacheTimeByPath handles the creation or update of a cache time whose ID is derived from the path in
+// the request body
+func (api *API) CreateOrUpdateCacheTimeByPath(ctx context.Context, w http.ResponseWriter, req *http.Request) {
+	log.Info(ctx, "calling create or update cache time by path handler")
+
+	// Check request body not empty
+	if req.ContentLength <= 0 {
+		log.Info(ctx, "createOrUpdateCacheTimeByPath endpoint: empty request body")
+		sendJSONError(ctx, w, http.StatusBadRequest, "bad request: empty request body")
+		return
+	}
+
+	cacheTime := &models.CacheTime{}
+	err := decodeCacheTime(req.Body, cacheTime)
+	if err != nil {
+		log.Info(ctx, "createOrUpdateCacheTimeByPath endpoint: error decoding request body")
+		sendJSONError(ctx, w, http.StatusBadRequest, fmt.Sprintf("bad request: %v", err))
+		return
+	}
+
+	cacheTime.Path = models.NormalisePath(cacheTime.Path)
+	id := models.HashPath(cacheTime.Path)
+	if cacheTime.ID != "" && cacheTime.ID != id {
+		log.Info(ctx, "createOrUpdateCacheTimeByPath endpoint: id does not match path", log.Data{"id": cacheTime.ID, "path": cacheTime.Path})
+		sendJSONError(ctx, w, http.StatusBadRequest, fmt.Sprintf("validation errors: %v", formatErrorList([]error{errors.New("id does not match the normalised path")})))
+		return
+	}
+	cacheTime.ID = id
+
+	err = api.isValidCacheTime(ctx, cacheTime)
+	if err != nil {
+		log.Info(ctx, "createOrUpdateCacheTimeByPath endpoint: cache time failed validation checks")
+		sendJSONError(ctx, w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	api.upsertCacheTime(ctx, w, req, cacheTime)
+}
@@ -0,0 +1,165 @@
+package api_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ONSdigital/dp-legacy-cache-api/api/mock"
+	errs "github.com/ONSdigital/dp-legacy-cache-api/apierrors"
+	"github.com/ONSdigital/dp-legacy-cache-api/models"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+// testPathCacheID is the MD5 hash of "/economy/inflation"
+var testPathCacheID = "4836470a4e61477475682454751b9af0"
+
+func TestGetCacheTimeByPath(t *testing.T) {
+	Convey("Given a cache time stored under the id derived from its path", t, func() {
+		dataStoreMock := &mock.DataStoreMock{
+			GetCacheTimeFunc: func(ctx context.Context, id string) (*models.CacheTime, error) {
+				if id == testPathCacheID {
+					return &models.CacheTime{ID: testPathCacheID, Path: "/economy/inflation"}, nil
+				}
+				return nil, errs.ErrCacheTimeNotFound
+			},
+		}
+		dataStoreAPI := setupWebAPI(dataStoreMock)
+
+		Convey("When the cache time is requested with a path that needs normalising", func() {
+			request := httptest.NewRequest(http.MethodGet, listURL+"?path=economy//inflation/", http.NoBody)
+			responseRecorder := httptest.NewRecorder()
+			dataStoreAPI.Router.ServeHTTP(responseRecorder, request)
+
+			Convey("Then the matched cache time is returned with status code 200", func() {
+				So(responseRecorder.Code, ShouldEqual, http.StatusOK)
+
+				cacheTime := models.CacheTime{}
+				err := json.Unmarshal(responseRecorder.Body.Bytes(), &cacheTime)
+				So(err, ShouldBeNil)
+				So(cacheTime, ShouldResemble, models.CacheTime{ID: testPathCacheID, Path: "/economy/inflation"})
+				So(dataStoreMock.GetCacheTimeCalls()[0].ID, ShouldEqual, testPathCacheID)
+			})
+		})
+
+		Convey("When a cache time is requested for an unknown path", func() {
+			request := httptest.NewRequest(http.MethodGet, listURL+"?path=/unknown", http.NoBody)
+			responseRecorder := httptest.NewRecorder()
+			dataStoreAPI.Router.ServeHTTP(responseRecorder, request)
+
+			Convey("Then a 404 is returned", func() {
+				So(responseRecorder.Code, ShouldEqual, http.StatusNotFound)
+			})
+		})
+
+		Convey("When a cache time is requested with an empty path", func() {
+			request := httptest.NewRequest(http.MethodGet, listURL+"?path=", http.NoBody)
+			responseRecorder := httptest.NewRecorder()
+			dataStoreAPI.Router.ServeHTTP(responseRecorder, request)
+
+			Convey("Then a 400 is returned and the datastore is not called", func() {
+				So(responseRecorder.Code, ShouldEqual, http.StatusBadRequest)
+				So(responseRecorder.Body.String(), ShouldContainSubstring, "path query parameter is empty")
+				So(dataStoreMock.GetCacheTimeCalls(), ShouldBeEmpty)
+			})
+		})
+	})
+}
+
+func TestCreateOrUpdateCacheTimeByPath(t *testing.T) {
+	Convey("Given a PUT by path handler", t, func() {
+		db := make(map[string]models.CacheTime)
+		dataStoreMock := &mock.DataStoreMock{
+			UpsertCacheTimeFunc: func(ctx context.Context, cacheTime *models.CacheTime, precondition models.Precondition) (*models.CacheTimeChange, error) {
+				previous, exists := db[cacheTime.ID]
+				db[cacheTime.ID] = *cacheTime
+				if !exists {
+					return upsertChange(nil, cacheTime), nil
+				}
+				return upsertChange(&previous, cacheTime), nil
+			},
+		}
+		dataStoreAPI := setupPublishingAPI(dataStoreMock)
+
+		Convey("When a cache time is put with a path that needs normalising", func() {
+			body := `{"path": "/economy/inflation/", "collection_id": "` + testCollectionID + `"}`
+			request := newRequestWithAuth(http.MethodPut, listURL, bytes.NewBufferString(body))
+			responseRecorder := httptest.NewRecorder()
+			dataStoreAPI.Router.ServeHTTP(responseRecorder, request)
+
+			Convey("Then it is created under the id derived from the normalised path with status code 201", func() {
+				So(responseRecorder.Code, ShouldEqual, http.StatusCreated)
+				So(responseRecorder.Header().Get("Location"), ShouldEqual, "/v1/cache-times/"+testPathCacheID)
+				So(db[testPathCacheID], ShouldResemble, models.CacheTime{
+					ID:           testPathCacheID,
+					Path:         "/economy/inflation",
+					CollectionID: testCollectionID,
+				})
+			})
+		})
+
+		Convey("When the body contains an id matching the path", func() {
+			body := `{"_id": "` + testPathCacheID + `", "path": "/economy/inflation"}`
+			request := newRequestWithAuth(http.MethodPut, listURL, bytes.NewBufferString(body))
+			responseRecorder := httptest.NewRecorder()
+			dataStoreAPI.Router.ServeHTTP(responseRecorder, request)
+
+			Convey("Then the cache time is created with status code 201", func() {
+				So(responseRecorder.Code, ShouldEqual, http.StatusCreated)
+				So(db, ShouldContainKey, testPathCacheID)
+			})
+		})
+
+		Convey("When the body contains an id that does not match the path", func() {
+			body := `{"_id": "` + testCacheID + `", "path": "/economy/inflation"}`
+			request := newRequestWithAuth(http.MethodPut, listURL, bytes.NewBufferString(body))
+			responseRecorder := httptest.NewRecorder()
+			dataStoreAPI.Router.ServeHTTP(responseRecorder, request)
+
+			Convey("Then a 400 is returned and nothing is stored", func() {
+				So(responseRecorder.Code, ShouldEqual, http.StatusBadRequest)
+				So(responseRecorder.Body.String(), ShouldContainSubstring, "id does not match the normalised path")
+				So(db, ShouldBeEmpty)
+			})
+		})
+
+		Convey("When the path is missing", func() {
+			request := newRequestWithAuth(http.MethodPut, listURL, bytes.NewBufferString(`{"collection_id": "`+testCollectionID+`"}`))
+			responseRecorder := httptest.NewRecorder()
+			dataStoreAPI.Router.ServeHTTP(responseRecorder, request)
+
+			Convey("Then a 400 is returned with the missing field in the response", func() {
+				So(responseRecorder.Code, ShouldEqual, http.StatusBadRequest)
+				So(responseRecorder.Body.String(), ShouldContainSubstring, "[path field missing]")
+			})
+		})
+
+		Convey("When no request body is provided", func() {
+			request := newRequestWithAuth(http.MethodPut, listURL, http.NoBody)
+			responseRecorder := httptest.NewRecorder()
+			dataStoreAPI.Router.ServeHTTP(responseRecorder, request)
+
+			Convey("Then a 400 is returned with 'empty request body' in the response", func() {
+				So(responseRecorder.Code, ShouldEqual, http.StatusBadRequest)
+				So(responseRecorder.Body.String(), ShouldContainSubstring, "empty request body")
+			})
+		})
+	})
+
+	Convey("Given an API in the publishing subnet", t, func() {
+		dataStoreAPI := setupPublishingAPI(&mock.DataStoreMock{})
+
+		Convey("When we send an unauthenticated request to put a cache time by path", func() {
+			request := httptest.NewRequest(http.MethodPut, listURL, bytes.NewBufferString(validBody))
+			responseRecorder := httptest.NewRecorder()
+			dataStoreAPI.Router.ServeHTTP(responseRecorder, request)
+
+			Convey("The status code should be 401", func() {
+				So(responseRecorder.Code, ShouldEqual, http.StatusUnauthorized)
+			})
+		})
+	})
+}
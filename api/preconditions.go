@@ -0,0 +1,110 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	errs "github.com/ONSdigital/dp-legacy-cache-api/apierrors"
+	"github.com/ONSdigital/dp-legacy-cache-api/models"
+)
+
+// getPrecondition reads the If-Match header, and the If-None-Match header when allowCreateOnly is set, of a write to a
+// cache time. ErrPreconditionFailed is returned when the headers can never be satisfied, so that the write is refused
+// without consulting the data store.
+func getPrecondition(header http.Header, allowCreateOnly bool) (models.Precondition, error) {
+	var precondition models.Precondition
+	var e []error
+
+	if values := header.Values("If-Match"); len(values) > 0 {
+		if isWildcard(values) {
+			precondition.MustExist = true
+		} else {
+			versions, err := parseETagVersions("If-Match", values, false)
+			if err != nil {
+				e = append(e, err)
+			} else if len(versions) == 0 {
+				return precondition, errs.ErrPreconditionFailed
+			}
+			precondition.Versions = versions
+		}
+	}
+
+	if values := header.Values("If-None-Match"); allowCreateOnly && len(values) > 0 {
+		if isWildcard(values) {
+			precondition.MustNotExist = true
+		} else {
+			e = append(e, errors.New("If-None-Match should be *"))
+		}
+	}
+
+	if len(e) > 0 {
+		return precondition, fmt.Errorf("validation errors: %v", formatErrorList(e))
+	}
+	if precondition.MustNotExist && precondition.RequiresExisting() {
+		return precondition, errs.ErrPreconditionFailed
+	}
+	return precondition, nil
+}
+
+// isNotModified reports whether a read of cacheTime can be answered with 304 Not Modified, because the caller already
+// holds its current version. If-Modified-Since is only considered without If-None-Match, and a header that cannot be
+// parsed is ignored so that the full response is sent.
+func isNotModified(header http.Header, cacheTime *models.CacheTime) bool {
+	if values := header.Values("If-None-Match"); len(values) > 0 {
+		if isWildcard(values) {
+			return true
+		}
+		versions, err := parseETagVersions("If-None-Match", values, true)
+		return err == nil && slices.Contains(versions, cacheTime.Version)
+	}
+
+	modifiedSince, err := http.ParseTime(header.Get("If-Modified-Since"))
+	if err != nil || cacheTime.LastUpdated == nil {
+		return false
+	}
+	return !cacheTime.LastUpdated.Truncate(time.Second).After(modifiedSince)
+}
+
+// preconditionErrorStatus returns the status code of a response refusing a write because of its precondition headers
+func preconditionErrorStatus(err error) int {
+	if errors.Is(err, errs.ErrPreconditionFailed) {
+		return http.StatusPreconditionFailed
+	}
+	return http.StatusBadRequest
+}
+
+func isWildcard(values []string) bool {
+	return len(values) == 1 && strings.TrimSpace(values[0]) == "*"
+}
+
+// parseETagVersions returns the versions given by the entity tags listed in the values of the named header. Entity tags
+// this API could not have generated are skipped as they can never match, and so are weak entity tags unless weak
+// comparison is allowed.
+func parseETagVersions(name string, values []string, weak bool) ([]int, error) {
+	var versions []int
+	for _, value := range values {
+		for _, tag := range strings.Split(value, ",") {
+			tag = strings.TrimSpace(tag)
+			if tag == "" {
+				continue
+			}
+
+			opaque := strings.TrimPrefix(tag, "W/")
+			if len(opaque) < 2 || opaque[0] != '"' || opaque[len(opaque)-1] != '"' {
+				return nil, fmt.Errorf("%s should be * or a list of entity tags", name)
+			}
+
+			version, err := strconv.Atoi(opaque[1 : len(opaque)-1])
+			if (opaque != tag && !weak) || err != nil || version < 0 {
+				continue
+			}
+			versions = append(versions, version)
+		}
+	}
+	return versions, nil
+}
@@ -0,0 +1,299 @@
+package api_test
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/ONSdigital/dp-legacy-cache-api/api/mock"
+	errs "github.com/ONSdigital/dp-legacy-cache-api/apierrors"
+	"github.com/ONSdigital/dp-legacy-cache-api/models"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestGetCacheTimeETag(t *testing.T) {
+	Convey("Given a stored cache time at version 3", t, func() {
+		dataStoreMock := &mock.DataStoreMock{
+			GetCacheTimeFunc: func(ctx context.Context, id string) (*models.CacheTime, error) {
+				return &models.CacheTime{ID: testCacheID, Path: "/testpath", Version: 3}, nil
+			},
+		}
+		dataStoreAPI := setupWebAPI(dataStoreMock)
+
+		Convey("When the cache time is requested", func() {
+			request := httptest.NewRequest(http.MethodGet, baseURL+testCacheID, http.NoBody)
+			responseRecorder := httptest.NewRecorder()
+			dataStoreAPI.Router.ServeHTTP(responseRecorder, request)
+
+			Convey("Then the response carries an ETag derived from its version", func() {
+				So(responseRecorder.Code, ShouldEqual, http.StatusOK)
+				So(responseRecorder.Header().Get("ETag"), ShouldEqual, `"3"`)
+			})
+		})
+	})
+}
+
+func TestGetCacheTimeConditional(t *testing.T) {
+	Convey("Given a stored cache time at version 3, last updated at noon", t, func() {
+		lastUpdated := time.Date(2024, time.March, 1, 12, 0, 0, 500, time.UTC)
+		dataStoreMock := &mock.DataStoreMock{
+			GetCacheTimeFunc: func(ctx context.Context, id string) (*models.CacheTime, error) {
+				return &models.CacheTime{ID: testCacheID, Path: "/testpath", LastUpdated: &lastUpdated, Version: 3}, nil
+			},
+		}
+		dataStoreAPI := setupWebAPI(dataStoreMock)
+
+		get := func(header, value string) *httptest.ResponseRecorder {
+			request := httptest.NewRequest(http.MethodGet, baseURL+testCacheID, http.NoBody)
+			if header != "" {
+				request.Header.Set(header, value)
+			}
+			responseRecorder := httptest.NewRecorder()
+			dataStoreAPI.Router.ServeHTTP(responseRecorder, request)
+			return responseRecorder
+		}
+
+		Convey("When the cache time is requested without conditional headers", func() {
+			responseRecorder := get("", "")
+
+			Convey("Then it is returned with its validators and cache control", func() {
+				So(responseRecorder.Code, ShouldEqual, http.StatusOK)
+				So(responseRecorder.Header().Get("Last-Modified"), ShouldEqual, "Fri, 01 Mar 2024 12:00:00 GMT")
+				So(responseRecorder.Header().Get("Cache-Control"), ShouldEqual, "public, max-age=10")
+				So(responseRecorder.Body.Len(), ShouldBeGreaterThan, 0)
+			})
+		})
+
+		Convey("When the cache time is requested with its current entity tag in If-None-Match", func() {
+			responseRecorder := get("If-None-Match", `"2", W/"3"`)
+
+			Convey("Then 304 Not Modified is returned without a body", func() {
+				So(responseRecorder.Code, ShouldEqual, http.StatusNotModified)
+				So(responseRecorder.Header().Get("ETag"), ShouldEqual, `"3"`)
+				So(responseRecorder.Header().Get("Cache-Control"), ShouldEqual, "public, max-age=10")
+				So(responseRecorder.Body.Len(), ShouldEqual, 0)
+			})
+		})
+
+		Convey("When the cache time is requested with If-None-Match: *", func() {
+			responseRecorder := get("If-None-Match", "*")
+
+			Convey("Then 304 Not Modified is returned", func() {
+				So(responseRecorder.Code, ShouldEqual, http.StatusNotModified)
+			})
+		})
+
+		Convey("When the cache time is requested with an older entity tag in If-None-Match", func() {
+			responseRecorder := get("If-None-Match", `"2"`)
+
+			Convey("Then it is returned", func() {
+				So(responseRecorder.Code, ShouldEqual, http.StatusOK)
+			})
+		})
+
+		Convey("When the cache time is requested with a malformed If-None-Match", func() {
+			responseRecorder := get("If-None-Match", "3")
+
+			Convey("Then the header is ignored and it is returned", func() {
+				So(responseRecorder.Code, ShouldEqual, http.StatusOK)
+			})
+		})
+
+		Convey("When the cache time is requested with If-Modified-Since its last update", func() {
+			responseRecorder := get("If-Modified-Since", "Fri, 01 Mar 2024 12:00:00 GMT")
+
+			Convey("Then 304 Not Modified is returned", func() {
+				So(responseRecorder.Code, ShouldEqual, http.StatusNotModified)
+			})
+		})
+
+		Convey("When the cache time is requested with If-Modified-Since before its last update", func() {
+			responseRecorder := get("If-Modified-Since", "Fri, 01 Mar 2024 11:59:59 GMT")
+
+			Convey("Then it is returned", func() {
+				So(responseRecorder.Code, ShouldEqual, http.StatusOK)
+			})
+		})
+
+		Convey("When the cache time is requested with a stale entity tag and a recent If-Modified-Since", func() {
+			request := httptest.NewRequest(http.MethodGet, baseURL+testCacheID, http.NoBody)
+			request.Header.Set("If-None-Match", `"2"`)
+			request.Header.Set("If-Modified-Since", "Fri, 01 Mar 2024 13:00:00 GMT")
+			responseRecorder := httptest.NewRecorder()
+			dataStoreAPI.Router.ServeHTTP(responseRecorder, request)
+
+			Convey("Then If-Modified-Since is ignored and it is returned", func() {
+				So(responseRecorder.Code, ShouldEqual, http.StatusOK)
+			})
+		})
+	})
+
+	Convey("Given a publishing API", t, func() {
+		dataStoreMock := &mock.DataStoreMock{
+			GetCacheTimeFunc: func(ctx context.Context, id string) (*models.CacheTime, error) {
+				return &models.CacheTime{ID: testCacheID, Path: "/testpath", Version: 3}, nil
+			},
+		}
+		dataStoreAPI := setupPublishingAPI(dataStoreMock)
+
+		Convey("When a cache time is requested", func() {
+			request := httptest.NewRequest(http.MethodGet, baseURL+testCacheID, http.NoBody)
+			responseRecorder := httptest.NewRecorder()
+			dataStoreAPI.Router.ServeHTTP(responseRecorder, request)
+
+			Convey("Then caches are made to revalidate it", func() {
+				So(responseRecorder.Code, ShouldEqual, http.StatusOK)
+				So(responseRecorder.Header().Get("Cache-Control"), ShouldEqual, "no-cache")
+			})
+		})
+	})
+}
+
+func TestCreateOrUpdateCacheTimePreconditions(t *testing.T) {
+	Convey("Given a publishing API", t, func() {
+		dataStoreMock := &mock.DataStoreMock{
+			UpsertCacheTimeFunc: func(ctx context.Context, cacheTime *models.CacheTime, precondition models.Precondition) (*models.CacheTimeChange, error) {
+				return upsertChange(cacheTime, cacheTime), nil
+			},
+		}
+		dataStoreAPI := setupPublishingAPI(dataStoreMock)
+
+		put := func(header, value string) *httptest.ResponseRecorder {
+			request := newRequestWithAuth(http.MethodPut, baseURL+testCacheID, bytes.NewBufferString(validBody))
+			if header != "" {
+				request.Header.Set(header, value)
+			}
+			responseRecorder := httptest.NewRecorder()
+			dataStoreAPI.Router.ServeHTTP(responseRecorder, request)
+			return responseRecorder
+		}
+
+		Convey("When a cache time is put without precondition headers", func() {
+			responseRecorder := put("", "")
+
+			Convey("Then it is stored unconditionally", func() {
+				So(responseRecorder.Code, ShouldEqual, http.StatusNoContent)
+				So(dataStoreMock.UpsertCacheTimeCalls()[0].Precondition, ShouldResemble, models.Precondition{})
+			})
+		})
+
+		Convey("When a cache time is put with a list of entity tags in If-Match", func() {
+			responseRecorder := put("If-Match", `"3", W/"4", "5"`)
+
+			Convey("Then it is only stored at the versions of the strong entity tags", func() {
+				So(responseRecorder.Code, ShouldEqual, http.StatusNoContent)
+				So(dataStoreMock.UpsertCacheTimeCalls()[0].Precondition, ShouldResemble, models.Precondition{Versions: []int{3, 5}})
+			})
+		})
+
+		Convey("When a cache time is put with If-Match: *", func() {
+			responseRecorder := put("If-Match", "*")
+
+			Convey("Then it is only stored when it already exists", func() {
+				So(responseRecorder.Code, ShouldEqual, http.StatusNoContent)
+				So(dataStoreMock.UpsertCacheTimeCalls()[0].Precondition, ShouldResemble, models.Precondition{MustExist: true})
+			})
+		})
+
+		Convey("When a cache time is put with If-None-Match: *", func() {
+			responseRecorder := put("If-None-Match", "*")
+
+			Convey("Then it is only stored when it does not exist yet", func() {
+				So(responseRecorder.Code, ShouldEqual, http.StatusNoContent)
+				So(dataStoreMock.UpsertCacheTimeCalls()[0].Precondition, ShouldResemble, models.Precondition{MustNotExist: true})
+			})
+		})
+
+		Convey("When a cache time is put with only weak entity tags in If-Match", func() {
+			responseRecorder := put("If-Match", `W/"3"`)
+
+			Convey("Then a 412 is returned without consulting the data store", func() {
+				So(responseRecorder.Code, ShouldEqual, http.StatusPreconditionFailed)
+				So(dataStoreMock.UpsertCacheTimeCalls(), ShouldBeEmpty)
+			})
+		})
+
+		Convey("When a cache time is put with a malformed If-Match header", func() {
+			responseRecorder := put("If-Match", "3")
+
+			Convey("Then a 400 is returned", func() {
+				So(responseRecorder.Code, ShouldEqual, http.StatusBadRequest)
+				So(responseRecorder.Body.String(), ShouldContainSubstring, "[If-Match should be * or a list of entity tags]")
+				So(dataStoreMock.UpsertCacheTimeCalls(), ShouldBeEmpty)
+			})
+		})
+
+		Convey("When a cache time is put with entity tags in If-None-Match", func() {
+			responseRecorder := put("If-None-Match", `"3"`)
+
+			Convey("Then a 400 is returned", func() {
+				So(responseRecorder.Code, ShouldEqual, http.StatusBadRequest)
+				So(responseRecorder.Body.String(), ShouldContainSubstring, "[If-None-Match should be *]")
+			})
+		})
+	})
+
+	Convey("Given a publishing API whose data store reports that the precondition failed", t, func() {
+		dataStoreMock := &mock.DataStoreMock{
+			UpsertCacheTimeFunc: func(ctx context.Context, cacheTime *models.CacheTime, precondition models.Precondition) (*models.CacheTimeChange, error) {
+				return nil, errs.ErrPreconditionFailed
+			},
+		}
+		dataStoreAPI := setupPublishingAPI(dataStoreMock)
+
+		Convey("When a cache time is put with an outdated entity tag", func() {
+			request := newRequestWithAuth(http.MethodPut, baseURL+testCacheID, bytes.NewBufferString(validBody))
+			request.Header.Set("If-Match", `"2"`)
+			responseRecorder := httptest.NewRecorder()
+			dataStoreAPI.Router.ServeHTTP(responseRecorder, request)
+
+			Convey("Then a 412 is returned", func() {
+				So(responseRecorder.Code, ShouldEqual, http.StatusPreconditionFailed)
+				So(responseRecorder.Body.String(), ShouldContainSubstring, errs.ErrPreconditionFailed.Error())
+			})
+		})
+	})
+}
+
+func TestDeleteCacheTimePreconditions(t *testing.T) {
+	Convey("Given a publishing API whose data store only deletes version 3", t, func() {
+		dataStoreMock := &mock.DataStoreMock{
+			DeleteCacheTimeFunc: func(ctx context.Context, id string, precondition models.Precondition) (*models.CacheTimeChange, error) {
+				if !precondition.Matches(&models.CacheTime{ID: id, Version: 3}) {
+					return nil, errs.ErrPreconditionFailed
+				}
+				return models.NewCacheTimeChange(&models.CacheTime{ID: id, Version: 3}, nil, "", time.Now()), nil
+			},
+		}
+		dataStoreAPI := setupPublishingAPI(dataStoreMock)
+
+		del := func(ifMatch string) *httptest.ResponseRecorder {
+			request := newRequestWithAuth(http.MethodDelete, baseURL+testCacheID, http.NoBody)
+			request.Header.Set("If-Match", ifMatch)
+			request.Header.Set("If-None-Match", "*")
+			responseRecorder := httptest.NewRecorder()
+			dataStoreAPI.Router.ServeHTTP(responseRecorder, request)
+			return responseRecorder
+		}
+
+		Convey("When the cache time is deleted with its current entity tag", func() {
+			responseRecorder := del(`"3"`)
+
+			Convey("Then it is deleted, ignoring If-None-Match", func() {
+				So(responseRecorder.Code, ShouldEqual, http.StatusNoContent)
+				So(dataStoreMock.DeleteCacheTimeCalls()[0].Precondition, ShouldResemble, models.Precondition{Versions: []int{3}})
+			})
+		})
+
+		Convey("When the cache time is deleted with an outdated entity tag", func() {
+			responseRecorder := del(`"2"`)
+
+			Convey("Then a 412 is returned", func() {
+				So(responseRecorder.Code, ShouldEqual, http.StatusPreconditionFailed)
+			})
+		})
+	})
+}
@@ -0,0 +1,99 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/ONSdigital/dp-legacy-cache-api/models"
+	"github.com/ONSdigital/log.go/v2/log"
+)
+
+// defaultUpcomingWindow is how far ahead releases are looked for when the within query parameter is not given
+const defaultUpcomingWindow = 24 * time.Hour
+
+// Ways of grouping upcoming releases into calendar events, selected with the group query parameter
+const (
+	groupByCollection = "collection"
+	groupByPage       = "page"
+)
+
+// GetUpcomingReleases lists the cache times released between now and the end of the window given by the within query
+// parameter, grouped by collection and in release time order. They are rendered as JSON or, for calendar clients, as an
+// iCalendar feed with an event per collection or per page.
+func (api *API) GetUpcomingReleases(ctx context.Context, w http.ResponseWriter, req *http.Request) {
+	log.Info(ctx, "calling get upcoming releases handler")
+
+	w.Header().Set("Vary", "Accept")
+	rep, err := negotiate(req, jsonRepresentation, calendarRepresentation)
+	if err != nil {
+		log.Info(ctx, "getUpcomingReleases endpoint: no acceptable representation")
+		sendJSONError(ctx, w, negotiationErrorStatus(err), err.Error())
+		return
+	}
+
+	within, perPage, err := api.getUpcomingParameters(req.URL.Query())
+	if err != nil {
+		log.Info(ctx, "getUpcomingReleases endpoint: query parameters failed validation checks")
+		sendJSONError(ctx, w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	from := time.Now().UTC()
+	to := from.Add(within)
+	cacheTimes, err := api.dataStore.GetCacheTimesReleasedBetween(ctx, from, to)
+	if err != nil {
+		log.Error(ctx, "getUpcomingReleases endpoint: api.dataStore.GetCacheTimesReleasedBetween internal server error", err)
+		sendJSONError(ctx, w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	upcoming := models.NewUpcomingReleases(cacheTimes, from, to)
+
+	w.Header().Set("Cache-Control", api.cacheControl)
+	if rep == calendarRepresentation {
+		w.Header().Set("Content-Type", calendarContentType+"; charset=utf-8")
+		if err := writeCalendar(w, upcoming, perPage); err != nil {
+			log.Error(ctx, "error writing calendar", err)
+		}
+		return
+	}
+
+	if err := json.NewEncoder(w).Encode(upcoming); err != nil {
+		log.Error(ctx, "error encoding results to JSON", err)
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		return
+	}
+}
+
+// getUpcomingParameters returns the window given by the within query parameter, or its default, and whether calendar
+// events are grouped by page rather than by collection
+func (api *API) getUpcomingParameters(query url.Values) (within time.Duration, perPage bool, err error) {
+	var e []error
+
+	within = min(defaultUpcomingWindow, api.maxUpcomingWindow)
+	if value := query.Get("within"); value != "" {
+		within, err = time.ParseDuration(value)
+		if err != nil || within <= 0 {
+			e = append(e, errors.New("within should be a positive duration, such as 24h"))
+		} else if within > api.maxUpcomingWindow {
+			e = append(e, fmt.Errorf("within should not exceed %s", api.maxUpcomingWindow))
+		}
+	}
+
+	switch query.Get("group") {
+	case "", groupByCollection:
+	case groupByPage:
+		perPage = true
+	default:
+		e = append(e, fmt.Errorf("group should be %s or %s", groupByCollection, groupByPage))
+	}
+
+	if len(e) > 0 {
+		return 0, false, fmt.Errorf("validation errors: %v", formatErrorList(e))
+	}
+	return within, perPage, nil
+}
@@ -0,0 +1,259 @@
+package api_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+
+	"github.com/ONSdigital/dp-legacy-cache-api/api/mock"
+	"github.com/ONSdigital/dp-legacy-cache-api/models"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+var upcomingURL = "http://localhost:29100/v1/releases/upcoming"
+
+func TestGetUpcomingReleases(t *testing.T) {
+	Convey("Given cache times of two collections released in the next hours", t, func() {
+		first, second := time.Now().Add(time.Hour), time.Now().Add(2*time.Hour)
+		cacheTimes := []*models.CacheTime{
+			{ID: "a", Path: "/economy/a", CollectionID: "collection-1", ReleaseTime: &first},
+			{ID: "b", Path: "/economy/b", CollectionID: "collection-2", ReleaseTime: &second},
+			{ID: "c", Path: "/economy/c", CollectionID: "collection-1", ReleaseTime: &second},
+		}
+		dataStoreMock := &mock.DataStoreMock{
+			GetCacheTimesReleasedBetweenFunc: func(ctx context.Context, from, to time.Time) ([]*models.CacheTime, error) {
+				return cacheTimes, nil
+			},
+		}
+		dataStoreAPI := setupWebAPI(dataStoreMock)
+
+		Convey("When the releases within the next 6 hours are requested", func() {
+			request := httptest.NewRequest(http.MethodGet, upcomingURL+"?within=6h", http.NoBody)
+			responseRecorder := httptest.NewRecorder()
+			dataStoreAPI.Router.ServeHTTP(responseRecorder, request)
+
+			Convey("Then the cache times are returned grouped by collection with status code 200", func() {
+				So(responseRecorder.Code, ShouldEqual, http.StatusOK)
+				So(responseRecorder.Header().Get("Cache-Control"), ShouldEqual, "public, max-age=10")
+
+				upcoming := models.UpcomingReleases{}
+				err := json.Unmarshal(responseRecorder.Body.Bytes(), &upcoming)
+				So(err, ShouldBeNil)
+				So(upcoming.Count, ShouldEqual, 3)
+				So(upcoming.Collections, ShouldHaveLength, 2)
+				So(upcoming.Collections[0].CollectionID, ShouldEqual, "collection-1")
+				So(upcoming.Collections[0].Count, ShouldEqual, 2)
+				So(upcoming.Collections[1].CollectionID, ShouldEqual, "collection-2")
+
+				Convey("And the data store is queried from now to the end of the window", func() {
+					So(dataStoreMock.GetCacheTimesReleasedBetweenCalls(), ShouldHaveLength, 1)
+					call := dataStoreMock.GetCacheTimesReleasedBetweenCalls()[0]
+					So(call.To.Sub(call.From), ShouldEqual, 6*time.Hour)
+					So(call.From, ShouldHappenWithin, time.Minute, time.Now())
+					So(upcoming.From.Equal(call.From), ShouldBeTrue)
+					So(upcoming.To.Equal(call.To), ShouldBeTrue)
+				})
+			})
+		})
+
+		Convey("When the releases are requested without a window", func() {
+			request := httptest.NewRequest(http.MethodGet, upcomingURL, http.NoBody)
+			responseRecorder := httptest.NewRecorder()
+			dataStoreAPI.Router.ServeHTTP(responseRecorder, request)
+
+			Convey("Then the releases within the next 24 hours are returned", func() {
+				So(responseRecorder.Code, ShouldEqual, http.StatusOK)
+				call := dataStoreMock.GetCacheTimesReleasedBetweenCalls()[0]
+				So(call.To.Sub(call.From), ShouldEqual, 24*time.Hour)
+			})
+		})
+
+		Convey("When the releases are requested with a window that is not a positive duration", func() {
+			for _, within := range []string{"tomorrow", "-1h", "0s"} {
+				request := httptest.NewRequest(http.MethodGet, upcomingURL+"?within="+within, http.NoBody)
+				responseRecorder := httptest.NewRecorder()
+				dataStoreAPI.Router.ServeHTTP(responseRecorder, request)
+
+				So(responseRecorder.Code, ShouldEqual, http.StatusBadRequest)
+				So(responseRecorder.Body.String(), ShouldContainSubstring, "within should be a positive duration")
+			}
+
+			Convey("Then the data store is not called", func() {
+				So(dataStoreMock.GetCacheTimesReleasedBetweenCalls(), ShouldBeEmpty)
+			})
+		})
+
+		Convey("When the releases are requested with a window longer than the maximum", func() {
+			request := httptest.NewRequest(http.MethodGet, upcomingURL+"?within=169h", http.NoBody)
+			responseRecorder := httptest.NewRecorder()
+			dataStoreAPI.Router.ServeHTTP(responseRecorder, request)
+
+			Convey("Then a 400 is returned and the data store is not called", func() {
+				So(responseRecorder.Code, ShouldEqual, http.StatusBadRequest)
+				So(responseRecorder.Body.String(), ShouldContainSubstring, "within should not exceed 168h0m0s")
+				So(dataStoreMock.GetCacheTimesReleasedBetweenCalls(), ShouldBeEmpty)
+			})
+		})
+	})
+
+	Convey("Given a data store that fails", t, func() {
+		dataStoreMock := &mock.DataStoreMock{
+			GetCacheTimesReleasedBetweenFunc: func(ctx context.Context, from, to time.Time) ([]*models.CacheTime, error) {
+				return nil, errors.New("datastore error")
+			},
+		}
+		dataStoreAPI := setupWebAPI(dataStoreMock)
+
+		Convey("When the upcoming releases are requested", func() {
+			request := httptest.NewRequest(http.MethodGet, upcomingURL+"?within=24h", http.NoBody)
+			responseRecorder := httptest.NewRecorder()
+			dataStoreAPI.Router.ServeHTTP(responseRecorder, request)
+
+			Convey("Then a 500 is returned", func() {
+				So(responseRecorder.Code, ShouldEqual, http.StatusInternalServerError)
+			})
+		})
+	})
+}
+
+func TestGetUpcomingReleasesCalendar(t *testing.T) {
+	Convey("Given cache times of a collection and a page without a collection released in the next hours", t, func() {
+		first, second := time.Now().Add(time.Hour), time.Now().Add(2*time.Hour)
+		cacheTimes := []*models.CacheTime{
+			{ID: "a", Path: "/economy/a", CollectionID: "collection-1", ReleaseTime: &first},
+			{ID: "b", Path: "/economy/b", ReleaseTime: &first},
+			{ID: "c", Path: "/economy/c,d", CollectionID: "collection-1", ReleaseTime: &second},
+		}
+		dataStoreMock := &mock.DataStoreMock{
+			GetCacheTimesReleasedBetweenFunc: func(ctx context.Context, from, to time.Time) ([]*models.CacheTime, error) {
+				return cacheTimes, nil
+			},
+		}
+		dataStoreAPI := setupWebAPI(dataStoreMock)
+
+		Convey("When the upcoming releases are requested as a calendar", func() {
+			request := httptest.NewRequest(http.MethodGet, upcomingURL, http.NoBody)
+			request.Header.Set("Accept", "text/calendar, application/json;q=0.5")
+			responseRecorder := httptest.NewRecorder()
+			dataStoreAPI.Router.ServeHTTP(responseRecorder, request)
+
+			Convey("Then an iCalendar feed is returned with an event per collection", func() {
+				So(responseRecorder.Code, ShouldEqual, http.StatusOK)
+				So(responseRecorder.Header().Get("Content-Type"), ShouldEqual, "text/calendar; charset=utf-8")
+				So(responseRecorder.Header().Get("Vary"), ShouldEqual, "Accept")
+
+				calendar := responseRecorder.Body.String()
+				So(calendar, ShouldStartWith, "BEGIN:VCALENDAR\r\nVERSION:2.0\r\n")
+				So(calendar, ShouldEndWith, "END:VCALENDAR\r\n")
+				So(strings.Count(calendar, "BEGIN:VEVENT"), ShouldEqual, 2)
+				So(calendar, ShouldContainSubstring, "UID:collection-collection-1@dp-legacy-cache-api\r\n")
+				So(calendar, ShouldContainSubstring, "DTSTART:"+first.UTC().Format("20060102T150405Z")+"\r\n")
+				So(calendar, ShouldContainSubstring, "SUMMARY:Release of collection collection-1 (2 pages)\r\n")
+				So(calendar, ShouldContainSubstring, `DESCRIPTION:/economy/a\n/economy/c\,d at `+second.UTC().Format(time.RFC3339))
+
+				Convey("And the page without a collection has an event of its own", func() {
+					So(calendar, ShouldContainSubstring, "UID:b@dp-legacy-cache-api\r\n")
+					So(calendar, ShouldContainSubstring, "SUMMARY:Release of /economy/b\r\n")
+				})
+			})
+		})
+
+		Convey("When the upcoming releases are requested as a calendar of pages with the format parameter", func() {
+			request := httptest.NewRequest(http.MethodGet, upcomingURL+"?format=ics&group=page", http.NoBody)
+			responseRecorder := httptest.NewRecorder()
+			dataStoreAPI.Router.ServeHTTP(responseRecorder, request)
+
+			Convey("Then an iCalendar feed is returned with an event per page", func() {
+				So(responseRecorder.Code, ShouldEqual, http.StatusOK)
+				So(responseRecorder.Header().Get("Content-Type"), ShouldEqual, "text/calendar; charset=utf-8")
+
+				calendar := responseRecorder.Body.String()
+				So(strings.Count(calendar, "BEGIN:VEVENT"), ShouldEqual, 3)
+				So(calendar, ShouldContainSubstring, "UID:a@dp-legacy-cache-api\r\n")
+				So(calendar, ShouldContainSubstring, "SUMMARY:Release of /economy/c\\,d\r\n")
+				So(calendar, ShouldContainSubstring, "DESCRIPTION:Collection collection-1\r\n")
+			})
+		})
+
+		Convey("When the upcoming releases are requested as a calendar with an invalid grouping", func() {
+			request := httptest.NewRequest(http.MethodGet, upcomingURL+"?format=ics&group=week", http.NoBody)
+			responseRecorder := httptest.NewRecorder()
+			dataStoreAPI.Router.ServeHTTP(responseRecorder, request)
+
+			Convey("Then a 400 is returned and the data store is not called", func() {
+				So(responseRecorder.Code, ShouldEqual, http.StatusBadRequest)
+				So(responseRecorder.Body.String(), ShouldContainSubstring, "group should be collection or page")
+				So(dataStoreMock.GetCacheTimesReleasedBetweenCalls(), ShouldBeEmpty)
+			})
+		})
+
+		Convey("When the upcoming releases are requested in an unknown format", func() {
+			request := httptest.NewRequest(http.MethodGet, upcomingURL+"?format=xml", http.NoBody)
+			responseRecorder := httptest.NewRecorder()
+			dataStoreAPI.Router.ServeHTTP(responseRecorder, request)
+
+			Convey("Then a 400 is returned and the data store is not called", func() {
+				So(responseRecorder.Code, ShouldEqual, http.StatusBadRequest)
+				So(responseRecorder.Body.String(), ShouldContainSubstring, "format should be one of json, ics")
+				So(dataStoreMock.GetCacheTimesReleasedBetweenCalls(), ShouldBeEmpty)
+			})
+		})
+
+		Convey("When the upcoming releases are requested with an Accept header excluding every representation", func() {
+			request := httptest.NewRequest(http.MethodGet, upcomingURL, http.NoBody)
+			request.Header.Set("Accept", "application/xml, text/*;q=0")
+			responseRecorder := httptest.NewRecorder()
+			dataStoreAPI.Router.ServeHTTP(responseRecorder, request)
+
+			Convey("Then a 406 is returned and the data store is not called", func() {
+				So(responseRecorder.Code, ShouldEqual, http.StatusNotAcceptable)
+				So(dataStoreMock.GetCacheTimesReleasedBetweenCalls(), ShouldBeEmpty)
+			})
+		})
+
+		Convey("When the upcoming releases are requested with an Accept header preferring JSON", func() {
+			request := httptest.NewRequest(http.MethodGet, upcomingURL, http.NoBody)
+			request.Header.Set("Accept", "text/calendar;q=0.2, */*;q=0.8")
+			responseRecorder := httptest.NewRecorder()
+			dataStoreAPI.Router.ServeHTTP(responseRecorder, request)
+
+			Convey("Then JSON is returned", func() {
+				So(responseRecorder.Code, ShouldEqual, http.StatusOK)
+				So(responseRecorder.Header().Get("Content-Type"), ShouldNotStartWith, "text/calendar")
+				So(json.Valid(responseRecorder.Body.Bytes()), ShouldBeTrue)
+			})
+		})
+	})
+
+	Convey("Given a cache time with a long path", t, func() {
+		releaseTime := time.Now().Add(time.Hour)
+		path := "/economy/" + strings.Repeat("é", 60)
+		dataStoreMock := &mock.DataStoreMock{
+			GetCacheTimesReleasedBetweenFunc: func(ctx context.Context, from, to time.Time) ([]*models.CacheTime, error) {
+				return []*models.CacheTime{{ID: "a", Path: path, ReleaseTime: &releaseTime}}, nil
+			},
+		}
+		dataStoreAPI := setupWebAPI(dataStoreMock)
+
+		Convey("When the upcoming releases are requested as a calendar", func() {
+			request := httptest.NewRequest(http.MethodGet, upcomingURL+"?format=ics", http.NoBody)
+			responseRecorder := httptest.NewRecorder()
+			dataStoreAPI.Router.ServeHTTP(responseRecorder, request)
+
+			Convey("Then its lines are folded to 75 octets without splitting characters", func() {
+				calendar := responseRecorder.Body.String()
+				for _, line := range strings.Split(calendar, "\r\n") {
+					So(len(line), ShouldBeLessThanOrEqualTo, 75)
+					So(utf8.ValidString(line), ShouldBeTrue)
+				}
+				So(strings.ReplaceAll(calendar, "\r\n ", ""), ShouldContainSubstring, "SUMMARY:Release of "+path+"\r\n")
+			})
+		})
+	})
+}
@@ -6,6 +6,10 @@ import (
 
 // A list of error messages for Cache API
 var (
-	ErrCacheTimeNotFound = errors.New("cachetime not found")
-	ErrDataStore         = errors.New("DataStore error")
+	ErrCacheTimeNotFound  = errors.New("cachetime not found")
+	ErrDataStore          = errors.New("DataStore error")
+	ErrPreconditionFailed = errors.New("cachetime does not match the precondition")
+	ErrReleaseRecorded    = errors.New("release already recorded")
+	ErrPathConflict       = errors.New("another cachetime has the same path")
+	ErrNotAcceptable      = errors.New("none of the representations of the resource is acceptable")
 )
@@ -0,0 +1,201 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	"github.com/ONSdigital/dp-healthcheck/healthcheck"
+	"github.com/ONSdigital/dp-legacy-cache-api/api"
+	errs "github.com/ONSdigital/dp-legacy-cache-api/apierrors"
+	"github.com/ONSdigital/dp-legacy-cache-api/models"
+	lru "github.com/hashicorp/golang-lru"
+	"golang.org/x/sync/singleflight"
+)
+
+// Store wraps an api.DataStore with a read-through LRU cache of GetCacheTime results. Not found results are cached
+// too, and concurrent lookups of the same id share a single call to the underlying store. Writes made through the
+// store invalidate the affected entries; any other write is only seen once the entry expires.
+type Store struct {
+	api.DataStore
+
+	entries     *lru.Cache
+	ttl         time.Duration
+	notFoundTTL time.Duration
+	group       singleflight.Group
+	generation  atomic.Uint64
+	hits        atomic.Uint64
+	misses      atomic.Uint64
+	now         func() time.Time
+}
+
+// Stats holds the counters of a Store
+type Stats struct {
+	Hits    uint64
+	Misses  uint64
+	Entries int
+}
+
+type entry struct {
+	cacheTime *models.CacheTime
+	expires   time.Time
+}
+
+// NewStore creates a Store caching up to size cache times for ttl, and not found results for notFoundTTL. A zero
+// notFoundTTL disables the caching of not found results.
+func NewStore(dataStore api.DataStore, size int, ttl, notFoundTTL time.Duration) (*Store, error) {
+	entries, err := lru.New(size)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Store{
+		DataStore:   dataStore,
+		entries:     entries,
+		ttl:         ttl,
+		notFoundTTL: notFoundTTL,
+		now:         time.Now,
+	}, nil
+}
+
+// GetCacheTime returns a cache time with its given id, from the cache when possible
+func (s *Store) GetCacheTime(ctx context.Context, id string) (*models.CacheTime, error) {
+	if e, ok := s.lookup(id); ok {
+		s.hits.Add(1)
+		return e.result()
+	}
+	s.misses.Add(1)
+
+	// The lookup is shared by every concurrent caller, so it must not be cancelled along with the caller that started it
+	sharedCtx := context.WithoutCancel(ctx)
+	v, err, _ := s.group.Do(id, func() (interface{}, error) {
+		generation := s.generation.Load()
+
+		cacheTime, err := s.DataStore.GetCacheTime(sharedCtx, id)
+		switch {
+		case err == nil:
+			s.add(id, generation, &entry{cacheTime: cacheTime, expires: s.now().Add(s.ttl)})
+		case errors.Is(err, errs.ErrCacheTimeNotFound) && s.notFoundTTL > 0:
+			s.add(id, generation, &entry{expires: s.now().Add(s.notFoundTTL)})
+		}
+		return cacheTime, err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return copyCacheTime(v.(*models.CacheTime)), nil
+}
+
+func (s *Store) lookup(id string) (*entry, bool) {
+	v, ok := s.entries.Get(id)
+	if !ok {
+		return nil, false
+	}
+
+	e := v.(*entry)
+	if !s.now().Before(e.expires) {
+		s.entries.Remove(id)
+		return nil, false
+	}
+	return e, true
+}
+
+// add caches an entry unless the cache has been invalidated since the entry was read from the underlying store
+func (s *Store) add(id string, generation uint64, e *entry) {
+	if s.generation.Load() == generation {
+		s.entries.Add(id, e)
+	}
+}
+
+func (e *entry) result() (*models.CacheTime, error) {
+	if e.cacheTime == nil {
+		return nil, errs.ErrCacheTimeNotFound
+	}
+	return copyCacheTime(e.cacheTime), nil
+}
+
+// UpsertCacheTime adds or overrides an existing cache time, invalidating its cache entry
+func (s *Store) UpsertCacheTime(ctx context.Context, cacheTime *models.CacheTime, precondition models.Precondition) (*models.CacheTimeChange, error) {
+	defer s.invalidate(cacheTime.ID)
+	return s.DataStore.UpsertCacheTime(ctx, cacheTime, precondition)
+}
+
+// UpsertCacheTimes adds or overrides the given cache times, invalidating their cache entries
+func (s *Store) UpsertCacheTimes(ctx context.Context, cacheTimes []*models.CacheTime) ([]models.UpsertResult, error) {
+	ids := make([]string, len(cacheTimes))
+	for i, cacheTime := range cacheTimes {
+		ids[i] = cacheTime.ID
+	}
+	defer s.invalidate(ids...)
+	return s.DataStore.UpsertCacheTimes(ctx, cacheTimes)
+}
+
+// DeleteCacheTime removes the cache time with the given id, invalidating its cache entry
+func (s *Store) DeleteCacheTime(ctx context.Context, id string, precondition models.Precondition) (*models.CacheTimeChange, error) {
+	defer s.invalidate(id)
+	return s.DataStore.DeleteCacheTime(ctx, id, precondition)
+}
+
+// UpdateCollectionReleaseTime sets the release time of every cache time in the given collection, purging the cache as
+// the ids affected are not known
+func (s *Store) UpdateCollectionReleaseTime(ctx context.Context, collectionID string, releaseTime *time.Time) ([]*models.CacheTimeChange, error) {
+	defer s.purge()
+	return s.DataStore.UpdateCollectionReleaseTime(ctx, collectionID, releaseTime)
+}
+
+// DeleteCollectionCacheTimes removes every cache time in the given collection, purging the cache as the ids affected
+// are not known
+func (s *Store) DeleteCollectionCacheTimes(ctx context.Context, collectionID string) ([]*models.CacheTimeChange, error) {
+	defer s.purge()
+	return s.DataStore.DeleteCollectionCacheTimes(ctx, collectionID)
+}
+
+func (s *Store) invalidate(ids ...string) {
+	s.generation.Add(1)
+	for _, id := range ids {
+		s.entries.Remove(id)
+	}
+}
+
+func (s *Store) purge() {
+	s.generation.Add(1)
+	s.entries.Purge()
+}
+
+// Stats returns the number of cache hits and misses since the store was created and the number of cached entries
+func (s *Store) Stats() Stats {
+	return Stats{
+		Hits:    s.hits.Load(),
+		Misses:  s.misses.Load(),
+		Entries: s.entries.Len(),
+	}
+}
+
+// StatsChecker reports the cache counters in the message of an always healthy check, making them observable through
+// the health endpoint
+func (s *Store) StatsChecker(_ context.Context, state *healthcheck.CheckState) error {
+	stats := s.Stats()
+	message := fmt.Sprintf("cache hits: %d, misses: %d, entries: %d", stats.Hits, stats.Misses, stats.Entries)
+	return state.Update(healthcheck.StatusOK, message, 0)
+}
+
+func copyCacheTime(cacheTime *models.CacheTime) *models.CacheTime {
+	if cacheTime == nil {
+		return nil
+	}
+	copied := *cacheTime
+	copied.ReleaseTime = copyTime(cacheTime.ReleaseTime)
+	copied.CreatedAt = copyTime(cacheTime.CreatedAt)
+	copied.LastUpdated = copyTime(cacheTime.LastUpdated)
+	return &copied
+}
+
+func copyTime(t *time.Time) *time.Time {
+	if t == nil {
+		return nil
+	}
+	copied := *t
+	return &copied
+}
@@ -0,0 +1,230 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ONSdigital/dp-healthcheck/healthcheck"
+	"github.com/ONSdigital/dp-legacy-cache-api/api/mock"
+	errs "github.com/ONSdigital/dp-legacy-cache-api/apierrors"
+	"github.com/ONSdigital/dp-legacy-cache-api/models"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+const (
+	testID      = "374690207ce40c08d0c3b1a1869ef66e"
+	unknownID   = "11111111111111111111111111111111"
+	testTTL     = 10 * time.Second
+	notFoundTTL = 5 * time.Second
+)
+
+var ctx = context.Background()
+
+// newTestStore creates a store in front of a mock data store holding a single cache time, with a controllable clock
+func newTestStore() (*Store, *mock.DataStoreMock, *time.Time) {
+	dataStoreMock := &mock.DataStoreMock{
+		GetCacheTimeFunc: func(ctx context.Context, id string) (*models.CacheTime, error) {
+			if id == testID {
+				createdAt := time.Date(2024, time.January, 1, 9, 30, 0, 0, time.UTC)
+				return &models.CacheTime{ID: testID, Path: "testpath", CreatedAt: &createdAt, LastUpdated: &createdAt}, nil
+			}
+			return nil, errs.ErrCacheTimeNotFound
+		},
+		UpsertCacheTimeFunc: func(ctx context.Context, cacheTime *models.CacheTime, precondition models.Precondition) (*models.CacheTimeChange, error) {
+			return models.NewCacheTimeChange(nil, cacheTime, "", time.Now()), nil
+		},
+		DeleteCollectionCacheTimesFunc: func(ctx context.Context, collectionID string) ([]*models.CacheTimeChange, error) {
+			return []*models.CacheTimeChange{models.NewCacheTimeChange(&models.CacheTime{ID: testID}, nil, "", time.Now())}, nil
+		},
+	}
+
+	store, err := NewStore(dataStoreMock, 10, testTTL, notFoundTTL)
+	So(err, ShouldBeNil)
+
+	now := time.Date(2024, time.January, 31, 9, 30, 0, 0, time.UTC)
+	store.now = func() time.Time { return now }
+	return store, dataStoreMock, &now
+}
+
+func TestGetCacheTime(t *testing.T) {
+	Convey("Given a cache in front of a data store", t, func() {
+		store, dataStoreMock, now := newTestStore()
+
+		Convey("When the same cache time is requested twice", func() {
+			first, err1 := store.GetCacheTime(ctx, testID)
+			second, err2 := store.GetCacheTime(ctx, testID)
+
+			Convey("Then the data store is only called once and the hit and miss are counted", func() {
+				So(err1, ShouldBeNil)
+				So(err2, ShouldBeNil)
+				So(first, ShouldResemble, second)
+				So(dataStoreMock.GetCacheTimeCalls(), ShouldHaveLength, 1)
+				So(store.Stats(), ShouldResemble, Stats{Hits: 1, Misses: 1, Entries: 1})
+			})
+		})
+
+		Convey("When a returned cache time is modified", func() {
+			first, _ := store.GetCacheTime(ctx, testID)
+			first.Path = "modified"
+			*first.CreatedAt = first.CreatedAt.Add(time.Hour)
+			*first.LastUpdated = first.LastUpdated.Add(time.Hour)
+
+			Convey("Then the cached cache time is unchanged", func() {
+				second, _ := store.GetCacheTime(ctx, testID)
+				So(second.Path, ShouldEqual, "testpath")
+				So(second.CreatedAt.Hour(), ShouldEqual, 9)
+				So(second.LastUpdated.Hour(), ShouldEqual, 9)
+			})
+		})
+
+		Convey("When a cache time is requested with a context that is already cancelled", func() {
+			cancelledCtx, cancel := context.WithCancel(ctx)
+			cancel()
+			_, err := store.GetCacheTime(cancelledCtx, testID)
+
+			Convey("Then the data store is called with a context that is not cancelled, as the lookup may be shared", func() {
+				So(err, ShouldBeNil)
+				So(dataStoreMock.GetCacheTimeCalls(), ShouldHaveLength, 1)
+				So(dataStoreMock.GetCacheTimeCalls()[0].Ctx.Err(), ShouldBeNil)
+			})
+		})
+
+		Convey("When a cache time is requested again after the ttl", func() {
+			_, _ = store.GetCacheTime(ctx, testID)
+			*now = now.Add(testTTL)
+			_, err := store.GetCacheTime(ctx, testID)
+
+			Convey("Then the data store is called again", func() {
+				So(err, ShouldBeNil)
+				So(dataStoreMock.GetCacheTimeCalls(), ShouldHaveLength, 2)
+			})
+		})
+
+		Convey("When an unknown cache time is requested twice", func() {
+			_, err1 := store.GetCacheTime(ctx, unknownID)
+			_, err2 := store.GetCacheTime(ctx, unknownID)
+
+			Convey("Then the not found result is cached", func() {
+				So(err1, ShouldEqual, errs.ErrCacheTimeNotFound)
+				So(err2, ShouldEqual, errs.ErrCacheTimeNotFound)
+				So(dataStoreMock.GetCacheTimeCalls(), ShouldHaveLength, 1)
+			})
+
+			Convey("And it expires after the not found ttl", func() {
+				*now = now.Add(notFoundTTL)
+				_, err := store.GetCacheTime(ctx, unknownID)
+				So(err, ShouldEqual, errs.ErrCacheTimeNotFound)
+				So(dataStoreMock.GetCacheTimeCalls(), ShouldHaveLength, 2)
+			})
+		})
+
+		Convey("When the data store fails", func() {
+			dataStoreMock.GetCacheTimeFunc = func(ctx context.Context, id string) (*models.CacheTime, error) {
+				return nil, errs.ErrDataStore
+			}
+			_, err1 := store.GetCacheTime(ctx, testID)
+			_, err2 := store.GetCacheTime(ctx, testID)
+
+			Convey("Then the error is returned and not cached", func() {
+				So(err1, ShouldEqual, errs.ErrDataStore)
+				So(err2, ShouldEqual, errs.ErrDataStore)
+				So(dataStoreMock.GetCacheTimeCalls(), ShouldHaveLength, 2)
+			})
+		})
+	})
+
+	Convey("Given a cache whose data store answers slowly", t, func() {
+		store, dataStoreMock, _ := newTestStore()
+		release := make(chan struct{})
+		dataStoreMock.GetCacheTimeFunc = func(ctx context.Context, id string) (*models.CacheTime, error) {
+			<-release
+			return &models.CacheTime{ID: id, Path: "testpath"}, nil
+		}
+
+		Convey("When the same cache time is requested concurrently", func() {
+			var wg sync.WaitGroup
+			results := make([]error, 5)
+			for i := range results {
+				wg.Add(1)
+				go func(i int) {
+					defer wg.Done()
+					_, results[i] = store.GetCacheTime(ctx, testID)
+				}(i)
+			}
+
+			// give every lookup the chance to join the first one before it completes
+			time.Sleep(50 * time.Millisecond)
+			close(release)
+			wg.Wait()
+
+			Convey("Then the lookups share a single call to the data store", func() {
+				for _, err := range results {
+					So(err, ShouldBeNil)
+				}
+				So(dataStoreMock.GetCacheTimeCalls(), ShouldHaveLength, 1)
+			})
+		})
+	})
+}
+
+func TestInvalidation(t *testing.T) {
+	Convey("Given a cache holding a cache time", t, func() {
+		store, dataStoreMock, _ := newTestStore()
+		_, _ = store.GetCacheTime(ctx, testID)
+
+		Convey("When the cache time is upserted through the cache", func() {
+			_, err := store.UpsertCacheTime(ctx, &models.CacheTime{ID: testID, Path: "testpath"}, models.Precondition{})
+
+			Convey("Then its entry is invalidated", func() {
+				So(err, ShouldBeNil)
+				_, _ = store.GetCacheTime(ctx, testID)
+				So(dataStoreMock.GetCacheTimeCalls(), ShouldHaveLength, 2)
+			})
+		})
+
+		Convey("When the cache times of a collection are deleted through the cache", func() {
+			changes, err := store.DeleteCollectionCacheTimes(ctx, "collection")
+
+			Convey("Then the cache is purged", func() {
+				So(err, ShouldBeNil)
+				So(changes, ShouldHaveLength, 1)
+				So(store.Stats().Entries, ShouldEqual, 0)
+			})
+		})
+	})
+}
+
+func TestStatsChecker(t *testing.T) {
+	Convey("Given a cache that has served a hit and a miss", t, func() {
+		store, _, _ := newTestStore()
+		_, _ = store.GetCacheTime(ctx, testID)
+		_, _ = store.GetCacheTime(ctx, testID)
+
+		Convey("When its stats are checked", func() {
+			state := healthcheck.NewCheckState("Cache")
+			err := store.StatsChecker(ctx, state)
+
+			Convey("Then the counters are reported in a healthy check", func() {
+				So(err, ShouldBeNil)
+				So(state.Status(), ShouldEqual, healthcheck.StatusOK)
+				So(state.Message(), ShouldEqual, "cache hits: 1, misses: 1, entries: 1")
+			})
+		})
+	})
+}
+
+func TestNewStore(t *testing.T) {
+	Convey("Given an invalid cache size", t, func() {
+		Convey("When a store is created", func() {
+			store, err := NewStore(&mock.DataStoreMock{}, 0, testTTL, notFoundTTL)
+
+			Convey("Then an error is returned", func() {
+				So(store, ShouldBeNil)
+				So(err, ShouldResemble, errors.New("must provide a positive size"))
+			})
+		})
+	})
+}
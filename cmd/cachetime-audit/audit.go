@@ -0,0 +1,164 @@
+package main
+
+import (
+	"context"
+	"time"
+
+	"github.com/ONSdigital/dp-legacy-cache-api/models"
+)
+
+// Repair actions recorded against failed repairs
+const (
+	actionDelete           = "delete"
+	actionMove             = "move"
+	actionClearReleaseTime = "clear_release_time"
+)
+
+// cacheTimeStore is the subset of the mongo store needed to audit and repair the cachetimes collection
+type cacheTimeStore interface {
+	ScanCacheTimes(ctx context.Context, fn func(*models.CacheTime) error) error
+	DeleteCacheTime(ctx context.Context, id string, precondition models.Precondition) (*models.CacheTimeChange, error)
+	MoveCacheTime(ctx context.Context, oldID string, cacheTime *models.CacheTime) error
+	ClearReleaseTime(ctx context.Context, id string) error
+}
+
+// report lists every inconsistency found in the cachetimes collection and, when fixing, the outcome of each repair
+type report struct {
+	Scanned           int                `json:"scanned"`
+	DryRun            bool               `json:"dry_run"`
+	StaleBefore       time.Time          `json:"stale_before"`
+	DuplicatePaths    []duplicatePath    `json:"duplicate_paths"`
+	IDMismatches      []idMismatch       `json:"id_mismatches"`
+	StaleReleaseTimes []staleReleaseTime `json:"stale_release_times"`
+	Repaired          int                `json:"repaired"`
+	Errors            []repairError      `json:"errors,omitempty"`
+}
+
+// duplicatePath is a set of cache times sharing the same normalised path. Repairing keeps one and deletes the others.
+type duplicatePath struct {
+	Path       string   `json:"path"`
+	KeptID     string   `json:"kept_id"`
+	DeletedIDs []string `json:"deleted_ids"`
+}
+
+// idMismatch is a cache time whose id is not the MD5 hash of its normalised path. Repairing moves it to the expected id
+// and stores its path normalised.
+type idMismatch struct {
+	ID         string `json:"_id"`
+	Path       string `json:"path"`
+	ExpectedID string `json:"expected_id"`
+
+	cacheTime *models.CacheTime
+}
+
+// staleReleaseTime is a cache time whose release time is older than the stale threshold. Repairing clears it.
+type staleReleaseTime struct {
+	ID          string    `json:"_id"`
+	Path        string    `json:"path"`
+	ReleaseTime time.Time `json:"release_time"`
+}
+
+type repairError struct {
+	ID     string `json:"_id"`
+	Action string `json:"action"`
+	Error  string `json:"error"`
+}
+
+// audit scans the store for inconsistent cache times and repairs them unless dryRun is set
+func audit(ctx context.Context, store cacheTimeStore, staleBefore time.Time, dryRun bool) (*report, error) {
+	var cacheTimes []*models.CacheTime
+	err := store.ScanCacheTimes(ctx, func(cacheTime *models.CacheTime) error {
+		cacheTimes = append(cacheTimes, cacheTime)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	r := findInconsistencies(cacheTimes, staleBefore)
+	r.DryRun = dryRun
+	if !dryRun {
+		r.repair(ctx, store)
+	}
+	return r, nil
+}
+
+// findInconsistencies reports duplicate paths, id mismatches and stale release times. Cache times without a path are
+// ignored as no id can be derived for them, and cache times that would be deleted as duplicates are not reported for
+// any other inconsistency.
+func findInconsistencies(cacheTimes []*models.CacheTime, staleBefore time.Time) *report {
+	r := &report{
+		Scanned:           len(cacheTimes),
+		StaleBefore:       staleBefore,
+		DuplicatePaths:    []duplicatePath{},
+		IDMismatches:      []idMismatch{},
+		StaleReleaseTimes: []staleReleaseTime{},
+	}
+
+	paths, groups := models.GroupByPath(cacheTimes)
+	for _, path := range paths {
+		if path == "" {
+			continue
+		}
+		group := groups[path]
+		kept := group[0]
+		if len(group) > 1 {
+			kept = models.PreferredCacheTime(group)
+			duplicate := duplicatePath{Path: path, KeptID: kept.ID}
+			for _, cacheTime := range group {
+				if cacheTime != kept {
+					duplicate.DeletedIDs = append(duplicate.DeletedIDs, cacheTime.ID)
+				}
+			}
+			r.DuplicatePaths = append(r.DuplicatePaths, duplicate)
+		}
+
+		if expectedID := models.HashPath(path); kept.ID != expectedID {
+			r.IDMismatches = append(r.IDMismatches, idMismatch{ID: kept.ID, Path: kept.Path, ExpectedID: expectedID, cacheTime: kept})
+		}
+
+		if kept.ReleaseTime != nil && kept.ReleaseTime.Before(staleBefore) {
+			r.StaleReleaseTimes = append(r.StaleReleaseTimes, staleReleaseTime{ID: kept.ID, Path: kept.Path, ReleaseTime: *kept.ReleaseTime})
+		}
+	}
+	return r
+}
+
+// repair deletes duplicates, then moves mismatched cache times to their expected id and finally clears stale release
+// times, recording any failure without stopping
+func (r *report) repair(ctx context.Context, store cacheTimeStore) {
+	for _, duplicate := range r.DuplicatePaths {
+		for _, id := range duplicate.DeletedIDs {
+			_, err := store.DeleteCacheTime(ctx, id, models.Precondition{})
+			r.record(id, actionDelete, err)
+		}
+	}
+
+	moved := make(map[string]string)
+	for _, mismatch := range r.IDMismatches {
+		cacheTime := *mismatch.cacheTime
+		cacheTime.ID = mismatch.ExpectedID
+		cacheTime.Path = models.NormalisePath(cacheTime.Path)
+		err := store.MoveCacheTime(ctx, mismatch.ID, &cacheTime)
+		if err == nil {
+			moved[mismatch.ID] = mismatch.ExpectedID
+		}
+		r.record(mismatch.ID, actionMove, err)
+	}
+
+	for _, stale := range r.StaleReleaseTimes {
+		id := stale.ID
+		if newID, ok := moved[id]; ok {
+			id = newID
+		}
+		r.record(id, actionClearReleaseTime, store.ClearReleaseTime(ctx, id))
+	}
+}
+
+func (r *report) record(id, action string, err error) {
+	if err != nil {
+		r.Errors = append(r.Errors, repairError{ID: id, Action: action, Error: err.Error()})
+		return
+	}
+	r.Repaired++
+}
@@ -0,0 +1,248 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ONSdigital/dp-legacy-cache-api/models"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+var (
+	staleBefore = time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)
+	staleTime   = time.Date(2019, time.March, 5, 9, 30, 0, 0, time.UTC)
+	recentTime  = time.Date(2024, time.June, 1, 9, 30, 0, 0, time.UTC)
+)
+
+// fakeStore is an in-memory cacheTimeStore keyed by id
+type fakeStore struct {
+	cacheTimes map[string]*models.CacheTime
+	ids        []string
+	moveErr    error
+}
+
+func newFakeStore(cacheTimes ...*models.CacheTime) *fakeStore {
+	store := &fakeStore{cacheTimes: make(map[string]*models.CacheTime)}
+	for _, cacheTime := range cacheTimes {
+		store.cacheTimes[cacheTime.ID] = cacheTime
+		store.ids = append(store.ids, cacheTime.ID)
+	}
+	return store
+}
+
+func (s *fakeStore) ScanCacheTimes(_ context.Context, fn func(*models.CacheTime) error) error {
+	for _, id := range s.ids {
+		if cacheTime, ok := s.cacheTimes[id]; ok {
+			copied := *cacheTime
+			if err := fn(&copied); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (s *fakeStore) DeleteCacheTime(_ context.Context, id string, _ models.Precondition) (*models.CacheTimeChange, error) {
+	delete(s.cacheTimes, id)
+	return nil, nil
+}
+
+func (s *fakeStore) MoveCacheTime(_ context.Context, oldID string, cacheTime *models.CacheTime) error {
+	if s.moveErr != nil {
+		return s.moveErr
+	}
+	delete(s.cacheTimes, oldID)
+	s.cacheTimes[cacheTime.ID] = cacheTime
+	s.ids = append(s.ids, cacheTime.ID)
+	return nil
+}
+
+func (s *fakeStore) ClearReleaseTime(_ context.Context, id string) error {
+	s.cacheTimes[id].ReleaseTime = nil
+	return nil
+}
+
+func TestFindInconsistencies(t *testing.T) {
+	Convey("Given a set of cache times that are all consistent", t, func() {
+		cacheTimes := []*models.CacheTime{
+			{ID: models.HashPath("/economy"), Path: "/economy", ReleaseTime: &recentTime},
+			{ID: models.HashPath("/people"), Path: "/people"},
+		}
+
+		Convey("When the inconsistencies are found", func() {
+			r := findInconsistencies(cacheTimes, staleBefore)
+
+			Convey("Then nothing is reported", func() {
+				So(r.Scanned, ShouldEqual, 2)
+				So(r.DuplicatePaths, ShouldBeEmpty)
+				So(r.IDMismatches, ShouldBeEmpty)
+				So(r.StaleReleaseTimes, ShouldBeEmpty)
+			})
+		})
+	})
+
+	Convey("Given a cache time whose id is not the MD5 hash of its path", t, func() {
+		cacheTimes := []*models.CacheTime{{ID: "wrong-id", Path: "/economy"}}
+
+		Convey("When the inconsistencies are found", func() {
+			r := findInconsistencies(cacheTimes, staleBefore)
+
+			Convey("Then the id mismatch is reported with the expected id", func() {
+				So(r.IDMismatches, ShouldHaveLength, 1)
+				So(r.IDMismatches[0].ID, ShouldEqual, "wrong-id")
+				So(r.IDMismatches[0].ExpectedID, ShouldEqual, models.HashPath("/economy"))
+			})
+		})
+	})
+
+	Convey("Given a cache time stored under the id of its path as written, which is not normalised", t, func() {
+		cacheTimes := []*models.CacheTime{{ID: models.HashPath("/economy/"), Path: "/economy/"}}
+
+		Convey("When the inconsistencies are found", func() {
+			r := findInconsistencies(cacheTimes, staleBefore)
+
+			Convey("Then the id mismatch is reported with the id of the normalised path", func() {
+				So(r.IDMismatches, ShouldHaveLength, 1)
+				So(r.IDMismatches[0].ExpectedID, ShouldEqual, models.HashPath("/economy"))
+			})
+
+			Convey("And repairing it moves it to that id with its path normalised", func() {
+				store := newFakeStore(cacheTimes...)
+				r.repair(context.Background(), store)
+				So(r.Errors, ShouldBeEmpty)
+				So(store.cacheTimes, ShouldNotContainKey, models.HashPath("/economy/"))
+				So(store.cacheTimes[models.HashPath("/economy")].Path, ShouldEqual, "/economy")
+			})
+		})
+	})
+
+	Convey("Given cache times sharing the same normalised path", t, func() {
+		cacheTimes := []*models.CacheTime{
+			{ID: "wrong-id", Path: "/economy", ReleaseTime: &recentTime},
+			{ID: models.HashPath("/economy/"), Path: "/economy/"},
+			{ID: models.HashPath("/economy"), Path: "/economy", ReleaseTime: &staleTime},
+		}
+
+		Convey("When the inconsistencies are found", func() {
+			r := findInconsistencies(cacheTimes, staleBefore)
+
+			Convey("Then the duplicates are reported, keeping the cache time stored under the id of the normalised path", func() {
+				So(r.DuplicatePaths, ShouldHaveLength, 1)
+				So(r.DuplicatePaths[0].Path, ShouldEqual, "/economy")
+				So(r.DuplicatePaths[0].KeptID, ShouldEqual, models.HashPath("/economy"))
+				So(r.DuplicatePaths[0].DeletedIDs, ShouldResemble, []string{"wrong-id", models.HashPath("/economy/")})
+			})
+
+			Convey("And only the kept cache time is checked for other inconsistencies", func() {
+				So(r.IDMismatches, ShouldBeEmpty)
+				So(r.StaleReleaseTimes, ShouldHaveLength, 1)
+				So(r.StaleReleaseTimes[0].ID, ShouldEqual, models.HashPath("/economy"))
+			})
+		})
+	})
+
+	Convey("Given duplicate cache times none of which match their path", t, func() {
+		cacheTimes := []*models.CacheTime{
+			{ID: "first-id", Path: "/economy", ReleaseTime: &staleTime},
+			{ID: "second-id", Path: "/economy", ReleaseTime: &recentTime},
+		}
+
+		Convey("When the inconsistencies are found", func() {
+			r := findInconsistencies(cacheTimes, staleBefore)
+
+			Convey("Then the cache time released last is kept and reported as an id mismatch", func() {
+				So(r.DuplicatePaths, ShouldHaveLength, 1)
+				So(r.DuplicatePaths[0].KeptID, ShouldEqual, "second-id")
+				So(r.IDMismatches, ShouldHaveLength, 1)
+				So(r.IDMismatches[0].ID, ShouldEqual, "second-id")
+			})
+		})
+	})
+
+	Convey("Given a cache time without a path", t, func() {
+		cacheTimes := []*models.CacheTime{{ID: "no-path", ReleaseTime: &staleTime}}
+
+		Convey("When the inconsistencies are found", func() {
+			r := findInconsistencies(cacheTimes, staleBefore)
+
+			Convey("Then it is scanned but not reported", func() {
+				So(r.Scanned, ShouldEqual, 1)
+				So(r.IDMismatches, ShouldBeEmpty)
+				So(r.StaleReleaseTimes, ShouldBeEmpty)
+			})
+		})
+	})
+}
+
+func TestAudit(t *testing.T) {
+	ctx := context.Background()
+
+	newInconsistentStore := func() *fakeStore {
+		return newFakeStore(
+			&models.CacheTime{ID: "duplicate-id", Path: "/economy/"},
+			&models.CacheTime{ID: models.HashPath("/economy"), Path: "/economy"},
+			&models.CacheTime{ID: "wrong-id", Path: "/people", ReleaseTime: &staleTime},
+		)
+	}
+
+	Convey("Given a store containing inconsistent cache times", t, func() {
+		store := newInconsistentStore()
+
+		Convey("When the audit is run as a dry run", func() {
+			r, err := audit(ctx, store, staleBefore, true)
+
+			Convey("Then every inconsistency is reported and the store is left untouched", func() {
+				So(err, ShouldBeNil)
+				So(r.DryRun, ShouldBeTrue)
+				So(r.DuplicatePaths, ShouldHaveLength, 1)
+				So(r.IDMismatches, ShouldHaveLength, 1)
+				So(r.StaleReleaseTimes, ShouldHaveLength, 1)
+				So(r.Repaired, ShouldEqual, 0)
+				So(store.cacheTimes, ShouldHaveLength, 3)
+				So(store.cacheTimes, ShouldContainKey, "wrong-id")
+			})
+		})
+
+		Convey("When the audit is run with fixing enabled", func() {
+			r, err := audit(ctx, store, staleBefore, false)
+
+			Convey("Then every inconsistency is repaired", func() {
+				So(err, ShouldBeNil)
+				So(r.DryRun, ShouldBeFalse)
+				So(r.Repaired, ShouldEqual, 3)
+				So(r.Errors, ShouldBeEmpty)
+				So(store.cacheTimes, ShouldHaveLength, 2)
+				So(store.cacheTimes, ShouldNotContainKey, "duplicate-id")
+				So(store.cacheTimes, ShouldNotContainKey, "wrong-id")
+				So(store.cacheTimes, ShouldContainKey, models.HashPath("/people"))
+				So(store.cacheTimes[models.HashPath("/people")].ReleaseTime, ShouldBeNil)
+			})
+
+			Convey("And a second audit finds nothing to report", func() {
+				r, err = audit(ctx, store, staleBefore, true)
+				So(err, ShouldBeNil)
+				So(r.DuplicatePaths, ShouldBeEmpty)
+				So(r.IDMismatches, ShouldBeEmpty)
+				So(r.StaleReleaseTimes, ShouldBeEmpty)
+			})
+		})
+	})
+
+	Convey("Given a store that fails to move cache times", t, func() {
+		store := newInconsistentStore()
+		store.moveErr = errors.New("duplicate key")
+
+		Convey("When the audit is run with fixing enabled", func() {
+			r, err := audit(ctx, store, staleBefore, false)
+
+			Convey("Then the failed move is recorded and the stale release time is cleared under the original id", func() {
+				So(err, ShouldBeNil)
+				So(r.Repaired, ShouldEqual, 2)
+				So(r.Errors, ShouldResemble, []repairError{{ID: "wrong-id", Action: actionMove, Error: "duplicate key"}})
+				So(store.cacheTimes["wrong-id"].ReleaseTime, ShouldBeNil)
+			})
+		})
+	})
+}
@@ -0,0 +1,73 @@
+// Command cachetime-audit scans the cachetimes collection for documents whose id is not the MD5 hash of their normalised
+// path, documents sharing the same normalised path and release times older than a threshold. It writes its findings to
+// stdout as JSON and, when run with --fix, repairs them.
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"io"
+	"os"
+	"time"
+
+	"github.com/ONSdigital/dp-legacy-cache-api/config"
+	"github.com/ONSdigital/dp-legacy-cache-api/mongo"
+	dprequest "github.com/ONSdigital/dp-net/v3/request"
+	"github.com/ONSdigital/log.go/v2/log"
+	"github.com/pkg/errors"
+)
+
+const (
+	commandName       = "cachetime-audit"
+	defaultStaleAfter = 365 * 24 * time.Hour
+)
+
+func main() {
+	log.Namespace = commandName
+	ctx := context.Background()
+
+	if err := run(ctx, os.Args[1:], os.Stdout); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return
+		}
+		log.Fatal(ctx, "fatal runtime error", err)
+		os.Exit(1)
+	}
+}
+
+func run(ctx context.Context, args []string, out io.Writer) error {
+	flags := flag.NewFlagSet(commandName, flag.ContinueOnError)
+	fix := flags.Bool("fix", false, "repair the inconsistencies found; without it the audit only reports them")
+	staleAfter := flags.Duration("stale-after", defaultStaleAfter, "age after which a release time is considered stale")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	cfg, err := config.Get()
+	if err != nil {
+		return errors.Wrap(err, "error getting configuration")
+	}
+
+	store, err := mongo.NewMongoStore(ctx, cfg.MongoConfig)
+	if err != nil {
+		return errors.Wrap(err, "error connecting to mongo")
+	}
+	defer func() {
+		if closeErr := store.Close(ctx); closeErr != nil {
+			log.Error(ctx, "error closing mongo connection", closeErr)
+		}
+	}()
+
+	// Repairs are recorded in the cache time history as made by the audit command
+	ctx = dprequest.SetCaller(ctx, commandName)
+
+	r, err := audit(ctx, store, time.Now().UTC().Add(-*staleAfter), !*fix)
+	if err != nil {
+		return errors.Wrap(err, "error auditing cache times")
+	}
+
+	encoder := json.NewEncoder(out)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(r)
+}
@@ -1,13 +1,44 @@
 package config
 
 import (
+	"errors"
+	"fmt"
 	"time"
 
 	"github.com/ONSdigital/dp-mongodb/v3/mongodb"
 	"github.com/kelseyhightower/envconfig"
 )
 
-const CacheTimesCollection = "CacheTimesCollection"
+// Well known names of the collections used by the service
+const (
+	CacheTimesCollection         = "CacheTimesCollection"
+	CacheTimesHistoryCollection  = "CacheTimesHistoryCollection"
+	CacheTimesReleasesCollection = "CacheTimesReleasesCollection"
+	MigrationsCollection         = "MigrationsCollection"
+)
+
+// ReleaseRetention is how long the releases fired by the scheduler are kept once sent. It must be longer than the
+// scheduler lookback, so that a release is not fired again while its release time is still looked back at.
+const ReleaseRetention = 30 * 24 * time.Hour
+
+// Store backends that can be selected with STORE_BACKEND
+const (
+	StoreBackendMongo  = "mongo"
+	StoreBackendMemory = "memory"
+)
+
+// Event sinks that can be listed in EVENT_SINKS
+const (
+	EventSinkLog     = "log"
+	EventSinkWebhook = "webhook"
+)
+
+// Trace exporters that can be selected with OTEL_TRACES_EXPORTER
+const (
+	TracesExporterNone   = "none"
+	TracesExporterStdout = "stdout"
+	TracesExporterOTLP   = "otlp"
+)
 
 type MongoConfig = mongodb.MongoDriverConfig
 
@@ -19,6 +50,35 @@ type Config struct {
 	HealthCheckCriticalTimeout time.Duration `envconfig:"HEALTHCHECK_CRITICAL_TIMEOUT"`
 	IsPublishing               bool          `envconfig:"IS_PUBLISHING"`
 	ZebedeeURL                 string        `envconfig:"ZEBEDEE_URL"`
+	DefaultLimit               int           `envconfig:"DEFAULT_LIMIT"`
+	DefaultMaxLimit            int           `envconfig:"DEFAULT_MAXIMUM_LIMIT"`
+	DefaultOffset              int           `envconfig:"DEFAULT_OFFSET"`
+	BatchMaxItems              int           `envconfig:"BATCH_MAX_ITEMS"`
+	BatchMaxBodySize           int64         `envconfig:"BATCH_MAX_BODY_SIZE"`
+	IDPathValidationWarnOnly   bool          `envconfig:"ID_PATH_VALIDATION_WARN_ONLY"`
+	DefaultMaxAge              time.Duration `envconfig:"DEFAULT_MAX_AGE"`
+	MinimumMaxAge              time.Duration `envconfig:"MINIMUM_MAX_AGE"`
+	MaximumMaxAge              time.Duration `envconfig:"MAXIMUM_MAX_AGE"`
+	StoreBackend               string        `envconfig:"STORE_BACKEND"`
+	MigrateOnStartup           bool          `envconfig:"MIGRATE_ON_STARTUP"`
+	CacheEnabled               bool          `envconfig:"CACHE_ENABLED"`
+	CacheSize                  int           `envconfig:"CACHE_SIZE"`
+	CacheTTL                   time.Duration `envconfig:"CACHE_TTL"`
+	CacheNotFoundTTL           time.Duration `envconfig:"CACHE_NOT_FOUND_TTL"`
+	ResponseMaxAge             time.Duration `envconfig:"RESPONSE_MAX_AGE"`
+	EventSinks                 []string      `envconfig:"EVENT_SINKS"`
+	EventQueueSize             int           `envconfig:"EVENT_QUEUE_SIZE"`
+	EventWebhookURL            string        `envconfig:"EVENT_WEBHOOK_URL"`
+	EventWebhookTimeout        time.Duration `envconfig:"EVENT_WEBHOOK_TIMEOUT"`
+	SchedulerEnabled           bool          `envconfig:"SCHEDULER_ENABLED"`
+	SchedulerInterval          time.Duration `envconfig:"SCHEDULER_INTERVAL"`
+	SchedulerLookback          time.Duration `envconfig:"SCHEDULER_LOOKBACK"`
+	RetentionPeriod            time.Duration `envconfig:"RETENTION_PERIOD"`
+	RetentionSweepInterval     time.Duration `envconfig:"RETENTION_SWEEP_INTERVAL"`
+	UpcomingReleasesMaxWindow  time.Duration `envconfig:"UPCOMING_RELEASES_MAX_WINDOW"`
+	OTelServiceName            string        `envconfig:"OTEL_SERVICE_NAME"`
+	OTelTracesExporter         string        `envconfig:"OTEL_TRACES_EXPORTER"`
+	OTelExporterOTLPEndpoint   string        `envconfig:"OTEL_EXPORTER_OTLP_ENDPOINT"`
 	MongoConfig
 }
 
@@ -38,12 +98,41 @@ func Get() (*Config, error) {
 		HealthCheckCriticalTimeout: 90 * time.Second,
 		IsPublishing:               false,
 		ZebedeeURL:                 "http://localhost:8082",
+		DefaultLimit:               20,
+		DefaultMaxLimit:            1000,
+		DefaultOffset:              0,
+		BatchMaxItems:              1000,
+		BatchMaxBodySize:           5 << 20,
+		IDPathValidationWarnOnly:   false,
+		DefaultMaxAge:              15 * time.Minute,
+		MinimumMaxAge:              5 * time.Second,
+		MaximumMaxAge:              24 * time.Hour,
+		StoreBackend:               StoreBackendMongo,
+		MigrateOnStartup:           false,
+		CacheEnabled:               false,
+		CacheSize:                  10000,
+		CacheTTL:                   10 * time.Second,
+		CacheNotFoundTTL:           5 * time.Second,
+		ResponseMaxAge:             10 * time.Second,
+		EventSinks:                 []string{},
+		EventQueueSize:             1000,
+		EventWebhookURL:            "",
+		EventWebhookTimeout:        5 * time.Second,
+		SchedulerEnabled:           false,
+		SchedulerInterval:          5 * time.Second,
+		SchedulerLookback:          time.Hour,
+		RetentionPeriod:            0,
+		RetentionSweepInterval:     time.Hour,
+		UpcomingReleasesMaxWindow:  7 * 24 * time.Hour,
+		OTelServiceName:            "dp-legacy-cache-api",
+		OTelTracesExporter:         TracesExporterNone,
+		OTelExporterOTLPEndpoint:   "http://localhost:4318",
 		MongoConfig: MongoConfig{
 			ClusterEndpoint:               "localhost:27017",
 			Username:                      "",
 			Password:                      "",
 			Database:                      "cache",
-			Collections:                   map[string]string{CacheTimesCollection: "cachetimes"},
+			Collections:                   map[string]string{CacheTimesCollection: "cachetimes", CacheTimesHistoryCollection: "cachetimes_history", CacheTimesReleasesCollection: "cachetimes_releases", MigrationsCollection: "migrations"},
 			ReplicaSet:                    "",
 			IsStrongReadConcernEnabled:    false,
 			IsWriteConcernMajorityEnabled: true,
@@ -55,5 +144,25 @@ func Get() (*Config, error) {
 		},
 	}
 
-	return cfg, envconfig.Process("", cfg)
+	if err := envconfig.Process("", cfg); err != nil {
+		return cfg, err
+	}
+	return cfg, cfg.validate()
+}
+
+// validate returns an error for the first setting that the service cannot run with
+func (c *Config) validate() error {
+	switch {
+	case c.SchedulerInterval <= 0:
+		return errors.New("SCHEDULER_INTERVAL must be positive")
+	case c.SchedulerLookback <= 0:
+		return errors.New("SCHEDULER_LOOKBACK must be positive")
+	case c.SchedulerLookback >= ReleaseRetention:
+		return fmt.Errorf("SCHEDULER_LOOKBACK must be shorter than the %s that releases are kept for", ReleaseRetention)
+	case c.RetentionPeriod < 0:
+		return errors.New("RETENTION_PERIOD must be positive, or 0 to keep cache times forever")
+	case c.RetentionSweepInterval <= 0:
+		return errors.New("RETENTION_SWEEP_INTERVAL must be positive")
+	}
+	return nil
 }
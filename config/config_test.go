@@ -1,6 +1,7 @@
 package config
 
 import (
+	"errors"
 	"os"
 	"testing"
 	"time"
@@ -30,12 +31,41 @@ func TestConfig(t *testing.T) {
 					HealthCheckCriticalTimeout: 90 * time.Second,
 					IsPublishing:               false,
 					ZebedeeURL:                 "http://localhost:8082",
+					DefaultLimit:               20,
+					DefaultMaxLimit:            1000,
+					DefaultOffset:              0,
+					BatchMaxItems:              1000,
+					BatchMaxBodySize:           5 << 20,
+					IDPathValidationWarnOnly:   false,
+					DefaultMaxAge:              15 * time.Minute,
+					MinimumMaxAge:              5 * time.Second,
+					MaximumMaxAge:              24 * time.Hour,
+					StoreBackend:               StoreBackendMongo,
+					MigrateOnStartup:           false,
+					CacheEnabled:               false,
+					CacheSize:                  10000,
+					CacheTTL:                   10 * time.Second,
+					CacheNotFoundTTL:           5 * time.Second,
+					ResponseMaxAge:             10 * time.Second,
+					EventSinks:                 []string{},
+					EventQueueSize:             1000,
+					EventWebhookURL:            "",
+					EventWebhookTimeout:        5 * time.Second,
+					SchedulerEnabled:           false,
+					SchedulerInterval:          5 * time.Second,
+					SchedulerLookback:          time.Hour,
+					RetentionPeriod:            0,
+					RetentionSweepInterval:     time.Hour,
+					UpcomingReleasesMaxWindow:  7 * 24 * time.Hour,
+					OTelServiceName:            "dp-legacy-cache-api",
+					OTelTracesExporter:         TracesExporterNone,
+					OTelExporterOTLPEndpoint:   "http://localhost:4318",
 					MongoConfig: mongodriver.MongoDriverConfig{
 						ClusterEndpoint:               "localhost:27017",
 						Username:                      "",
 						Password:                      "",
 						Database:                      "cache",
-						Collections:                   map[string]string{CacheTimesCollection: "cachetimes"},
+						Collections:                   map[string]string{CacheTimesCollection: "cachetimes", CacheTimesHistoryCollection: "cachetimes_history", CacheTimesReleasesCollection: "cachetimes_releases", MigrationsCollection: "migrations"},
 						ReplicaSet:                    "",
 						IsStrongReadConcernEnabled:    false,
 						IsWriteConcernMajorityEnabled: true,
@@ -57,3 +87,82 @@ func TestConfig(t *testing.T) {
 		})
 	})
 }
+
+func TestValidate(t *testing.T) {
+	Convey("Given a valid config", t, func() {
+		configuration := &Config{
+			SchedulerInterval:      5 * time.Second,
+			SchedulerLookback:      time.Hour,
+			RetentionSweepInterval: time.Hour,
+		}
+
+		Convey("Then it is valid", func() {
+			So(configuration.validate(), ShouldBeNil)
+		})
+
+		Convey("When the scheduler interval is not positive", func() {
+			configuration.SchedulerInterval = 0
+
+			Convey("Then it is rejected", func() {
+				So(configuration.validate(), ShouldResemble, errors.New("SCHEDULER_INTERVAL must be positive"))
+			})
+		})
+
+		Convey("When the scheduler lookback is not positive", func() {
+			configuration.SchedulerLookback = -time.Hour
+
+			Convey("Then it is rejected", func() {
+				So(configuration.validate(), ShouldResemble, errors.New("SCHEDULER_LOOKBACK must be positive"))
+			})
+		})
+
+		Convey("When the scheduler lookback is as long as releases are kept", func() {
+			configuration.SchedulerLookback = ReleaseRetention
+
+			Convey("Then it is rejected", func() {
+				So(configuration.validate().Error(), ShouldEqual, "SCHEDULER_LOOKBACK must be shorter than the 720h0m0s that releases are kept for")
+			})
+		})
+
+		Convey("When the retention period is negative", func() {
+			configuration.RetentionPeriod = -time.Hour
+
+			Convey("Then it is rejected", func() {
+				So(configuration.validate(), ShouldResemble, errors.New("RETENTION_PERIOD must be positive, or 0 to keep cache times forever"))
+			})
+		})
+
+		Convey("When the retention period is positive", func() {
+			configuration.RetentionPeriod = 90 * 24 * time.Hour
+
+			Convey("Then it is valid", func() {
+				So(configuration.validate(), ShouldBeNil)
+			})
+		})
+
+		Convey("When the retention sweep interval is not positive", func() {
+			configuration.RetentionSweepInterval = 0
+
+			Convey("Then it is rejected", func() {
+				So(configuration.validate(), ShouldResemble, errors.New("RETENTION_SWEEP_INTERVAL must be positive"))
+			})
+		})
+	})
+}
+
+func TestGetInvalidConfig(t *testing.T) {
+	Convey("Given an environment setting a scheduler interval of 0", t, func() {
+		os.Clearenv()
+		cfg = nil
+		t.Setenv("SCHEDULER_INTERVAL", "0s")
+		defer func() { cfg = nil }()
+
+		Convey("When the config values are retrieved", func() {
+			_, err := Get()
+
+			Convey("Then an error is returned", func() {
+				So(err, ShouldResemble, errors.New("SCHEDULER_INTERVAL must be positive"))
+			})
+		})
+	})
+}
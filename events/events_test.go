@@ -0,0 +1,188 @@
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ONSdigital/dp-legacy-cache-api/models"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+type recordingSink struct {
+	mu     sync.Mutex
+	events []*models.CacheTimeEvent
+	err    error
+}
+
+func (s *recordingSink) Name() string {
+	return "recording"
+}
+
+func (s *recordingSink) Send(_ context.Context, event *models.CacheTimeEvent) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.events = append(s.events, event)
+	return s.err
+}
+
+func (s *recordingSink) received() []*models.CacheTimeEvent {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return append([]*models.CacheTimeEvent(nil), s.events...)
+}
+
+func newChange(id string, action string) *models.CacheTimeChange {
+	cacheTime := &models.CacheTime{ID: id, Path: "/economy/" + id}
+	switch action {
+	case models.CacheTimeCreated:
+		return models.NewCacheTimeChange(nil, cacheTime, "", time.Now())
+	case models.CacheTimeDeleted:
+		return models.NewCacheTimeChange(cacheTime, nil, "", time.Now())
+	default:
+		return models.NewCacheTimeChange(cacheTime, cacheTime, "", time.Now())
+	}
+}
+
+func TestPublisher(t *testing.T) {
+	Convey("Given a publisher with two sinks, one of them failing", t, func() {
+		failing := &recordingSink{err: errors.New("sink unavailable")}
+		working := &recordingSink{}
+		publisher := NewPublisher(10, failing, working)
+
+		Convey("When changes are published and the publisher is closed", func() {
+			So(publisher.Publish(context.Background(), newChange("a", models.CacheTimeCreated)), ShouldBeNil)
+			So(publisher.Publish(context.Background(), newChange("b", models.CacheTimeUpdated)), ShouldBeNil)
+			So(publisher.Publish(context.Background(), newChange("a", models.CacheTimeDeleted)), ShouldBeNil)
+			So(publisher.Close(context.Background()), ShouldBeNil)
+
+			Convey("Then every sink is sent every event, in order", func() {
+				for _, sink := range []*recordingSink{failing, working} {
+					events := sink.received()
+					So(events, ShouldHaveLength, 3)
+					So(events[0].CacheTimeID, ShouldEqual, "a")
+					So(events[0].Action, ShouldEqual, models.CacheTimeCreated)
+					So(events[1].CacheTimeID, ShouldEqual, "b")
+					So(events[2].Action, ShouldEqual, models.CacheTimeDeleted)
+				}
+			})
+		})
+
+		Convey("When a change is published with a context that is then cancelled", func() {
+			ctx, cancel := context.WithCancel(context.Background())
+			So(publisher.Publish(ctx, newChange("a", models.CacheTimeCreated)), ShouldBeNil)
+			cancel()
+			So(publisher.Close(context.Background()), ShouldBeNil)
+
+			Convey("Then the event is still sent", func() {
+				So(working.received(), ShouldHaveLength, 1)
+			})
+		})
+	})
+
+	Convey("Given a publisher that is closed", t, func() {
+		working := &recordingSink{}
+		publisher := NewPublisher(10, working)
+		So(publisher.Close(context.Background()), ShouldBeNil)
+
+		Convey("When a change is published", func() {
+			err := publisher.Publish(context.Background(), newChange("a", models.CacheTimeCreated))
+
+			Convey("Then an error is returned and nothing is sent", func() {
+				So(err, ShouldEqual, ErrPublisherClosed)
+				So(working.received(), ShouldBeEmpty)
+			})
+		})
+
+		Convey("When the publisher is closed again", func() {
+			Convey("Then it returns straight away", func() {
+				So(publisher.Close(context.Background()), ShouldBeNil)
+			})
+		})
+	})
+
+	Convey("Given a publisher with no sinks", t, func() {
+		publisher := NewPublisher(0)
+
+		Convey("When a change is published", func() {
+			So(publisher.Publish(context.Background(), newChange("a", models.CacheTimeCreated)), ShouldBeNil)
+
+			Convey("Then it is discarded without blocking and the publisher closes", func() {
+				So(publisher.Close(context.Background()), ShouldBeNil)
+			})
+		})
+	})
+}
+
+func TestWebhookSink(t *testing.T) {
+	event := models.NewCacheTimeChangedEvent(newChange("a", models.CacheTimeCreated))
+
+	Convey("Given a webhook accepting events", t, func() {
+		var received models.CacheTimeEvent
+		var contentType string
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			contentType = req.Header.Get("Content-Type")
+			_ = json.NewDecoder(req.Body).Decode(&received)
+			w.WriteHeader(http.StatusAccepted)
+		}))
+		defer server.Close()
+
+		Convey("When an event is sent", func() {
+			err := NewWebhookSink(server.URL, time.Second).Send(context.Background(), event)
+
+			Convey("Then it is posted as JSON", func() {
+				So(err, ShouldBeNil)
+				So(contentType, ShouldEqual, "application/json")
+				So(received.Type, ShouldEqual, models.CacheTimeChangedEventType)
+				So(received.CacheTimeID, ShouldEqual, "a")
+				So(received.Path, ShouldEqual, "/economy/a")
+			})
+		})
+	})
+
+	Convey("Given a webhook failing", t, func() {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}))
+		defer server.Close()
+
+		Convey("When an event is sent", func() {
+			err := NewWebhookSink(server.URL, time.Second).Send(context.Background(), event)
+
+			Convey("Then an error is returned", func() {
+				So(err, ShouldNotBeNil)
+				So(err.Error(), ShouldEqual, "webhook replied with status 503")
+			})
+		})
+	})
+}
+
+func TestKafkaSink(t *testing.T) {
+	Convey("Given a kafka sink backed by an in memory producer", t, func() {
+		producer := &MemoryProducer{}
+		sink := NewKafkaSink(producer)
+
+		Convey("When an event is sent", func() {
+			event := models.NewCacheTimeChangedEvent(newChange("a", models.CacheTimeUpdated))
+			So(sink.Send(context.Background(), event), ShouldBeNil)
+
+			Convey("Then the producer is sent the event as JSON, keyed by cache time id", func() {
+				messages := producer.Messages()
+				So(messages, ShouldHaveLength, 1)
+				So(string(messages[0].Key), ShouldEqual, "a")
+
+				var sent models.CacheTimeEvent
+				So(json.Unmarshal(messages[0].Value, &sent), ShouldBeNil)
+				So(sent.Action, ShouldEqual, models.CacheTimeUpdated)
+				So(sent.Path, ShouldEqual, "/economy/a")
+			})
+		})
+	})
+}
@@ -0,0 +1,70 @@
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+
+	"github.com/ONSdigital/dp-legacy-cache-api/models"
+)
+
+// Producer sends keyed messages to a Kafka topic, or to anything with the same semantics. Messages with the same key
+// are expected to be delivered in order.
+type Producer interface {
+	Send(ctx context.Context, key, value []byte) error
+}
+
+// KafkaSink sends events as JSON messages through a Producer, keyed by cache time id so that the events of a cache
+// time stay in order
+type KafkaSink struct {
+	producer Producer
+}
+
+// NewKafkaSink creates a sink sending events through producer
+func NewKafkaSink(producer Producer) *KafkaSink {
+	return &KafkaSink{producer: producer}
+}
+
+// Name returns the name of the sink
+func (s *KafkaSink) Name() string {
+	return "kafka"
+}
+
+// Send encodes the event and hands it to the producer
+func (s *KafkaSink) Send(ctx context.Context, event *models.CacheTimeEvent) error {
+	value, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	return s.producer.Send(ctx, []byte(event.CacheTimeID), value)
+}
+
+// Message is a message sent through a MemoryProducer
+type Message struct {
+	Key   []byte
+	Value []byte
+}
+
+// MemoryProducer is a Producer keeping the messages it is sent in memory, a stand-in for Kafka in tests and local
+// development
+type MemoryProducer struct {
+	mu       sync.Mutex
+	messages []Message
+}
+
+// Send keeps the message
+func (p *MemoryProducer) Send(_ context.Context, key, value []byte) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.messages = append(p.messages, Message{Key: key, Value: value})
+	return nil
+}
+
+// Messages returns the messages sent so far, oldest first
+func (p *MemoryProducer) Messages() []Message {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	return append([]Message(nil), p.messages...)
+}
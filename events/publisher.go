@@ -0,0 +1,101 @@
+package events
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"github.com/ONSdigital/dp-legacy-cache-api/models"
+	"github.com/ONSdigital/log.go/v2/log"
+)
+
+// Sink delivers cache time changed events to their destination
+type Sink interface {
+	Name() string
+	Send(ctx context.Context, event *models.CacheTimeEvent) error
+}
+
+// ErrPublisherClosed is returned when publishing an event after the publisher is closed
+var ErrPublisherClosed = errors.New("event publisher is closed")
+
+type queuedEvent struct {
+	ctx   context.Context
+	event *models.CacheTimeEvent
+}
+
+// Publisher sends an event to every sink for each change made to a cache time. Events are queued and sent in order by
+// a single background worker, so that a slow sink does not hold up requests. Publishing is best effort: an event is
+// dropped when the queue is full, and a sink failing to send it is logged.
+type Publisher struct {
+	sinks []Sink
+	queue chan queuedEvent
+	done  chan struct{}
+
+	mu     sync.RWMutex
+	closed bool
+}
+
+// NewPublisher creates a publisher sending events to the given sinks, queueing up to queueSize events. With no sinks
+// nothing is published.
+func NewPublisher(queueSize int, sinks ...Sink) *Publisher {
+	p := &Publisher{
+		sinks: sinks,
+		queue: make(chan queuedEvent, queueSize),
+		done:  make(chan struct{}),
+	}
+	go p.run()
+	return p
+}
+
+// Publish queues the event of a change made to a cache time. ErrPublisherClosed is returned once the publisher is
+// closed.
+func (p *Publisher) Publish(ctx context.Context, change *models.CacheTimeChange) error {
+	// The read lock is held while queueing, so that Close cannot close the queue in the meantime
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	if p.closed {
+		return ErrPublisherClosed
+	}
+	if len(p.sinks) == 0 {
+		return nil
+	}
+
+	event := models.NewCacheTimeChangedEvent(change)
+	select {
+	case p.queue <- queuedEvent{ctx: context.WithoutCancel(ctx), event: event}:
+	default:
+		log.Warn(ctx, "event queue is full, dropping cache time changed event", log.Data{"cache_time_id": event.CacheTimeID})
+	}
+	return nil
+}
+
+// Close stops accepting events and waits until the queued events have been sent, or ctx is done. Closing a publisher
+// more than once only waits again.
+func (p *Publisher) Close(ctx context.Context) error {
+	p.mu.Lock()
+	if !p.closed {
+		p.closed = true
+		close(p.queue)
+	}
+	p.mu.Unlock()
+
+	select {
+	case <-p.done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (p *Publisher) run() {
+	defer close(p.done)
+	for queued := range p.queue {
+		for _, sink := range p.sinks {
+			if err := sink.Send(queued.ctx, queued.event); err != nil {
+				log.Error(queued.ctx, "error sending cache time changed event", err,
+					log.Data{"sink": sink.Name(), "cache_time_id": queued.event.CacheTimeID})
+			}
+		}
+	}
+}
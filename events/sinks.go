@@ -0,0 +1,71 @@
+package events
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/ONSdigital/dp-legacy-cache-api/models"
+	"github.com/ONSdigital/log.go/v2/log"
+)
+
+// LogSink writes events to the service log
+type LogSink struct{}
+
+// Name returns the name of the sink
+func (LogSink) Name() string {
+	return "log"
+}
+
+// Send logs the event
+func (LogSink) Send(ctx context.Context, event *models.CacheTimeEvent) error {
+	log.Info(ctx, "cache time changed", log.Data{"event": event})
+	return nil
+}
+
+// WebhookSink posts events as JSON to a URL
+type WebhookSink struct {
+	url    string
+	client *http.Client
+}
+
+// NewWebhookSink creates a sink posting events to url, giving up on a request after timeout
+func NewWebhookSink(url string, timeout time.Duration) *WebhookSink {
+	return &WebhookSink{
+		url:    url,
+		client: &http.Client{Timeout: timeout},
+	}
+}
+
+// Name returns the name of the sink
+func (s *WebhookSink) Name() string {
+	return "webhook"
+}
+
+// Send posts the event, failing unless the webhook replies with a 2xx status
+func (s *WebhookSink) Send(ctx context.Context, event *models.CacheTimeEvent) error {
+	body, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		return fmt.Errorf("webhook replied with status %d", resp.StatusCode)
+	}
+	return nil
+}
@@ -0,0 +1,120 @@
+Feature: Batch Upsert Cache Times
+
+  Scenario: Upsert a batch of Cache Time resources
+    Given the following document exists in the "cachetimes" collection:
+      """
+      {
+        "_id": "f73597c45671bc4a192ea2b20468579c",
+        "path": "/my-path",
+        "collection_id": "test-1a19e3462937d85804752375daa00ba41d1b6625d396f21000e3c4571ebf2606",
+        "release_time": "2024-01-31T01:23:45.678Z"
+      }
+      """
+    And I am authorised
+    When I POST "/v1/cache-times/batch"
+      """
+      [
+        {
+          "_id": "f73597c45671bc4a192ea2b20468579c",
+          "path": "/my-path",
+          "release_time": "2024-02-29T09:30:00Z"
+        },
+        {
+          "_id": "25b93797c534b4c2ef0fe96b1e3da78a",
+          "path": "/my-other-path"
+        },
+        {
+          "_id": "INVALID-ID",
+          "path": "/invalid-path"
+        }
+      ]
+      """
+    Then I should receive the following JSON response with status "200":
+      """
+      {
+        "created": 1,
+        "updated": 1,
+        "invalid": 1,
+        "failed": 0,
+        "items": [
+          {
+            "_id": "f73597c45671bc4a192ea2b20468579c",
+            "status": "updated"
+          },
+          {
+            "_id": "25b93797c534b4c2ef0fe96b1e3da78a",
+            "status": "created"
+          },
+          {
+            "_id": "INVALID-ID",
+            "status": "invalid",
+            "error": "validation errors: [id should be 32 characters in length, id is not lowercase, id is not a valid hexadecimal]"
+          }
+        ]
+      }
+      """
+    And an "updated" event should have been published for "f73597c45671bc4a192ea2b20468579c"
+    And a "created" event should have been published for "25b93797c534b4c2ef0fe96b1e3da78a"
+    And I GET "/v1/cache-times/25b93797c534b4c2ef0fe96b1e3da78a"
+    And I should receive the following JSON response with status "200":
+      """
+      {
+        "_id": "25b93797c534b4c2ef0fe96b1e3da78a",
+        "path": "/my-other-path",
+        "created_at": "{{DYNAMIC_RECENT_TIMESTAMP}}",
+        "last_updated": "{{DYNAMIC_RECENT_TIMESTAMP}}",
+        "last_updated_by": "svc-authenticated",
+        "version": 1
+      }
+      """
+
+  Scenario: Upsert an empty batch
+    Given I am authorised
+    When I POST "/v1/cache-times/batch"
+      """
+      []
+      """
+    Then I should receive the following JSON response with status "400":
+      """
+      {
+        "error": "bad request: empty batch"
+      }
+      """
+
+  Scenario: Upsert a batch giving the same id more than once
+    Given I am authorised
+    When I POST "/v1/cache-times/batch"
+      """
+      [
+        {
+          "_id": "f73597c45671bc4a192ea2b20468579c",
+          "path": "/my-path",
+          "release_time": "2024-02-29T09:30:00Z"
+        },
+        {
+          "_id": "f73597c45671bc4a192ea2b20468579c",
+          "path": "/my-path"
+        }
+      ]
+      """
+    Then I should receive the following JSON response with status "400":
+      """
+      {
+        "error": "bad request: repeated ids: [item 1 repeats the id of item 0]"
+      }
+      """
+    And I GET "/v1/cache-times/f73597c45671bc4a192ea2b20468579c"
+    And the HTTP status code should be "404"
+
+  Scenario: Upsert a batch while not authorised
+    Given I am not authorised
+    When I POST "/v1/cache-times/batch"
+      """
+      [
+        {
+          "_id": "f73597c45671bc4a192ea2b20468579c",
+          "path": "/my-path"
+        }
+      ]
+      """
+    Then the HTTP status code should be "401"
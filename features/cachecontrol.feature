@@ -0,0 +1,26 @@
+Feature: Cache Control
+
+  Scenario: Read Cache Control of a page released far in the future
+    Given the following document exists in the "cachetimes" collection:
+      """
+      {
+        "_id": "f73597c45671bc4a192ea2b20468579c",
+        "path": "/my-path",
+        "release_time": "2100-01-31T01:23:45.678Z"
+      }
+      """
+    When I GET "/v1/cache-control/f73597c45671bc4a192ea2b20468579c"
+    Then the HTTP status code should be "200"
+
+  Scenario: Read Cache Control of a page without a Cache Time
+    When I GET "/v1/cache-control/f73597c45671bc4a192ea2b20468579c"
+    Then the HTTP status code should be "200"
+
+  Scenario: Read Cache Control with invalid ID format
+    When I GET "/v1/cache-control/INVALID-ID"
+    Then I should receive the following JSON response with status "400":
+      """
+      {
+        "error": "validation errors: [id should be 32 characters in length, id is not lowercase, id is not a valid hexadecimal]"
+      }
+      """
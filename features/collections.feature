@@ -0,0 +1,98 @@
+Feature: Collection Cache Times
+
+  Background:
+    Given the following document exists in the "cachetimes" collection:
+      """
+      {
+        "_id": "5d41402abc4b2a76b9719d911017c592",
+        "path": "/my-path",
+        "collection_id": "test-1a19e3462937d85804752375daa00ba41d1b6625d396f21000e3c4571ebf2606",
+        "release_time": "2024-01-31T01:23:45.678Z"
+      }
+      """
+    And the following document exists in the "cachetimes" collection:
+      """
+      {
+        "_id": "7d793037a0760186574b0282f2f435e7",
+        "path": "/my-other-path",
+        "collection_id": "test-1a19e3462937d85804752375daa00ba41d1b6625d396f21000e3c4571ebf2606",
+        "release_time": "2024-01-31T01:23:45.678Z"
+      }
+      """
+
+  Scenario: Reschedule every Cache Time resource in a collection
+    Given I am authorised
+    When I PUT "/v1/collections/test-1a19e3462937d85804752375daa00ba41d1b6625d396f21000e3c4571ebf2606/release-time"
+      """
+      {
+        "release_time": "2024-02-29T09:30:00Z"
+      }
+      """
+    Then I should receive the following JSON response with status "200":
+      """
+      {
+        "count": 2
+      }
+      """
+    And an "updated" event should have been published for "5d41402abc4b2a76b9719d911017c592"
+    And an "updated" event should have been published for "7d793037a0760186574b0282f2f435e7"
+    And I GET "/v1/cache-times/7d793037a0760186574b0282f2f435e7"
+    And I should receive the following JSON response with status "200":
+      """
+      {
+        "_id": "7d793037a0760186574b0282f2f435e7",
+        "path": "/my-other-path",
+        "collection_id": "test-1a19e3462937d85804752375daa00ba41d1b6625d396f21000e3c4571ebf2606",
+        "release_time": "2024-02-29T09:30:00Z",
+        "last_updated": "{{DYNAMIC_RECENT_TIMESTAMP}}",
+        "last_updated_by": "svc-authenticated",
+        "version": 1
+      }
+      """
+
+  Scenario: Reschedule a collection without a release time
+    Given I am authorised
+    When I PUT "/v1/collections/test-1a19e3462937d85804752375daa00ba41d1b6625d396f21000e3c4571ebf2606/release-time"
+      """
+      {}
+      """
+    Then I should receive the following JSON response with status "400":
+      """
+      {
+        "error": "validation errors: [release_time field missing]"
+      }
+      """
+
+  Scenario: Delete every Cache Time resource in a collection
+    Given I am authorised
+    When I DELETE "/v1/collections/test-1a19e3462937d85804752375daa00ba41d1b6625d396f21000e3c4571ebf2606/cache-times"
+    Then I should receive the following JSON response with status "200":
+      """
+      {
+        "count": 2
+      }
+      """
+    And the document with "_id" set to "5d41402abc4b2a76b9719d911017c592" does not exist in the "cachetimes" collection
+    And the document with "_id" set to "7d793037a0760186574b0282f2f435e7" does not exist in the "cachetimes" collection
+    And a "deleted" event should have been published for "5d41402abc4b2a76b9719d911017c592"
+    And a "deleted" event should have been published for "7d793037a0760186574b0282f2f435e7"
+
+  Scenario: Delete Cache Time resources of an unknown collection
+    Given I am authorised
+    When I DELETE "/v1/collections/unknown-collection/cache-times"
+    Then I should receive the following JSON response with status "200":
+      """
+      {
+        "count": 0
+      }
+      """
+
+  Scenario: Reschedule a collection while not authorised
+    Given I am not authorised
+    When I PUT "/v1/collections/test-1a19e3462937d85804752375daa00ba41d1b6625d396f21000e3c4571ebf2606/release-time"
+      """
+      {
+        "release_time": "2024-02-29T09:30:00Z"
+      }
+      """
+    Then the HTTP status code should be "401"
@@ -0,0 +1,87 @@
+Feature: Optimistic Concurrency
+
+  Background:
+    Given the following document exists in the "cachetimes" collection:
+      """
+      {
+        "_id": "f73597c45671bc4a192ea2b20468579c",
+        "path": "/my-path",
+        "release_time": "2024-01-31T01:23:45.678Z",
+        "version": 2
+      }
+      """
+
+  Scenario: Read the entity tag of a Cache Time resource
+    When I GET "/v1/cache-times/f73597c45671bc4a192ea2b20468579c"
+    Then the HTTP status code should be "200"
+    And the response should carry the entity tag of version 2
+
+  Scenario: Read an unchanged Cache Time resource
+    Given I set the "If-None-Match" header to the entity tag of version 2
+    When I GET "/v1/cache-times/f73597c45671bc4a192ea2b20468579c"
+    Then the HTTP status code should be "304"
+    And the response should carry the entity tag of version 2
+    And the response header "Cache-Control" should be "no-cache"
+
+  Scenario: Read a changed Cache Time resource
+    Given I set the "If-None-Match" header to the entity tag of version 1
+    When I GET "/v1/cache-times/f73597c45671bc4a192ea2b20468579c"
+    Then the HTTP status code should be "200"
+    And the response should carry the entity tag of version 2
+
+  Scenario: Update a Cache Time resource at its current version
+    Given I am authorised
+    And I set the "If-Match" header to the entity tag of version 2
+    When I PUT "/v1/cache-times/f73597c45671bc4a192ea2b20468579c"
+      """
+      {
+        "path": "/my-path",
+        "release_time": "2024-02-29T09:30:00Z"
+      }
+      """
+    Then the HTTP status code should be "204"
+    And I GET "/v1/cache-times/f73597c45671bc4a192ea2b20468579c"
+    And the response should carry the entity tag of version 3
+
+  Scenario: Update a Cache Time resource at an outdated version
+    Given I am authorised
+    And I set the "If-Match" header to the entity tag of version 1
+    When I PUT "/v1/cache-times/f73597c45671bc4a192ea2b20468579c"
+      """
+      {
+        "path": "/my-path",
+        "release_time": "2024-02-29T09:30:00Z"
+      }
+      """
+    Then I should receive the following JSON response with status "412":
+      """
+      {
+        "error": "cachetime does not match the precondition"
+      }
+      """
+    And I GET "/v1/cache-times/f73597c45671bc4a192ea2b20468579c"
+    And the response should carry the entity tag of version 2
+
+  Scenario: Create a Cache Time resource that already exists
+    Given I am authorised
+    And I set the "If-None-Match" header to "*"
+    When I PUT "/v1/cache-times/f73597c45671bc4a192ea2b20468579c"
+      """
+      {
+        "path": "/my-path"
+      }
+      """
+    Then the HTTP status code should be "412"
+
+  Scenario: Delete a Cache Time resource at an outdated version
+    Given I am authorised
+    And I set the "If-Match" header to the entity tag of version 1
+    When I DELETE "/v1/cache-times/f73597c45671bc4a192ea2b20468579c"
+    Then the HTTP status code should be "412"
+
+  Scenario: Delete a Cache Time resource at its current version
+    Given I am authorised
+    And I set the "If-Match" header to the entity tag of version 2
+    When I DELETE "/v1/cache-times/f73597c45671bc4a192ea2b20468579c"
+    Then the HTTP status code should be "204"
+    And the document with "_id" set to "f73597c45671bc4a192ea2b20468579c" does not exist in the "cachetimes" collection
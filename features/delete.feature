@@ -0,0 +1,38 @@
+Feature: Delete Cache Time
+
+  Scenario: Delete existing Cache Time resource
+    Given the following document exists in the "cachetimes" collection:
+      """
+      {
+        "_id": "5d41402abc4b2a76b9719d911017c592",
+        "path": "/my-path",
+        "collection_id": "test-1a19e3462937d85804752375daa00ba41d1b6625d396f21000e3c4571ebf2606",
+        "release_time": "2024-01-31T01:23:45.678Z"
+      }
+      """
+    And I am authorised
+    When I DELETE "/v1/cache-times/5d41402abc4b2a76b9719d911017c592"
+    Then the HTTP status code should be "204"
+    And the document with "_id" set to "5d41402abc4b2a76b9719d911017c592" does not exist in the "cachetimes" collection
+    And a "deleted" event should have been published for "5d41402abc4b2a76b9719d911017c592"
+
+  Scenario: Delete non-existing Cache Time resource
+    Given the document with "_id" set to "5d41402abc4b2a76b9719d911017c592" does not exist in the "cachetimes" collection
+    And I am authorised
+    When I DELETE "/v1/cache-times/5d41402abc4b2a76b9719d911017c592"
+    Then the HTTP status code should be "404"
+
+  Scenario: Delete Cache Time resource with invalid ID format
+    Given I am authorised
+    When I DELETE "/v1/cache-times/INVALID-ID"
+    Then I should receive the following JSON response with status "400":
+      """
+      {
+        "error": "validation errors: [id should be 32 characters in length, id is not lowercase, id is not a valid hexadecimal]"
+      }
+      """
+
+  Scenario: Delete Cache Time resource while not authorised
+    Given I am not authorised
+    When I DELETE "/v1/cache-times/5d41402abc4b2a76b9719d911017c592"
+    Then the HTTP status code should be "401"
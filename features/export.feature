@@ -0,0 +1,57 @@
+Feature: Export Cache Times
+
+  Background:
+    Given the following document exists in the "cachetimes" collection:
+      """
+      {
+        "_id": "f73597c45671bc4a192ea2b20468579c",
+        "path": "/my-path",
+        "collection_id": "collection-1",
+        "release_time": "2024-01-31T01:23:45.678Z"
+      }
+      """
+    And the following document exists in the "cachetimes" collection:
+      """
+      {
+        "_id": "25b93797c534b4c2ef0fe96b1e3da78a",
+        "path": "/my-other-path"
+      }
+      """
+
+  Scenario: Export every cache time as NDJSON
+    Given I am authorised
+    When I GET "/v1/cache-times/export"
+    Then the HTTP status code should be "200"
+    And the response header "Content-Type" should be "application/x-ndjson; charset=utf-8"
+    And I should receive the following response:
+      """
+      {"_id":"25b93797c534b4c2ef0fe96b1e3da78a","path":"/my-other-path"}
+      {"_id":"f73597c45671bc4a192ea2b20468579c","path":"/my-path","collection_id":"collection-1","release_time":"2024-01-31T01:23:45.678Z"}
+      """
+
+  Scenario: Export the cache times of a collection as CSV
+    Given I am authorised
+    And I set the "Accept" header to "text/csv"
+    When I GET "/v1/cache-times/export?collection_id=collection-1"
+    Then the HTTP status code should be "200"
+    And the response header "Content-Type" should be "text/csv; charset=utf-8"
+    And I should receive the following response:
+      """
+      _id,path,collection_id,release_time,created_at,last_updated,last_updated_by,version
+      f73597c45671bc4a192ea2b20468579c,/my-path,collection-1,2024-01-31T01:23:45.678Z,,,,
+      """
+
+  Scenario: Export the cache times in an unknown format
+    Given I am authorised
+    When I GET "/v1/cache-times/export?format=xml"
+    Then I should receive the following JSON response with status "400":
+      """
+      {
+        "error": "validation errors: [format should be one of ndjson, csv]"
+      }
+      """
+
+  Scenario: Export the cache times while not authorised
+    Given I am not authorised
+    When I GET "/v1/cache-times/export"
+    Then the HTTP status code should be "401"
@@ -0,0 +1,116 @@
+Feature: List Cache Times
+
+  Background:
+    Given the following document exists in the "cachetimes" collection:
+      """
+      {
+        "_id": "5d41402abc4b2a76b9719d911017c592",
+        "path": "/economy/my-path",
+        "collection_id": "test-1a19e3462937d85804752375daa00ba41d1b6625d396f21000e3c4571ebf2606",
+        "release_time": "2024-01-31T01:23:45.678Z"
+      }
+      """
+    And the following document exists in the "cachetimes" collection:
+      """
+      {
+        "_id": "7d793037a0760186574b0282f2f435e7",
+        "path": "/people/other-path",
+        "collection_id": "other-aa00ba41d1b6625d396f21000e3c4571ebf26061a19e3462937d85804752375d",
+        "release_time": "2024-03-01T09:30:00Z"
+      }
+      """
+
+  Scenario: List all Cache Time resources
+    When I GET "/v1/cache-times"
+    Then I should receive the following JSON response with status "200":
+      """
+      {
+        "items": [
+          {
+            "_id": "5d41402abc4b2a76b9719d911017c592",
+            "path": "/economy/my-path",
+            "collection_id": "test-1a19e3462937d85804752375daa00ba41d1b6625d396f21000e3c4571ebf2606",
+            "release_time": "2024-01-31T01:23:45.678Z"
+          },
+          {
+            "_id": "7d793037a0760186574b0282f2f435e7",
+            "path": "/people/other-path",
+            "collection_id": "other-aa00ba41d1b6625d396f21000e3c4571ebf26061a19e3462937d85804752375d",
+            "release_time": "2024-03-01T09:30:00Z"
+          }
+        ],
+        "count": 2,
+        "offset": 0,
+        "limit": 20,
+        "total_count": 2
+      }
+      """
+
+  Scenario: List Cache Time resources filtered by collection id
+    When I GET "/v1/cache-times?collection_id=test-1a19e3462937d85804752375daa00ba41d1b6625d396f21000e3c4571ebf2606"
+    Then I should receive the following JSON response with status "200":
+      """
+      {
+        "items": [
+          {
+            "_id": "5d41402abc4b2a76b9719d911017c592",
+            "path": "/economy/my-path",
+            "collection_id": "test-1a19e3462937d85804752375daa00ba41d1b6625d396f21000e3c4571ebf2606",
+            "release_time": "2024-01-31T01:23:45.678Z"
+          }
+        ],
+        "count": 1,
+        "offset": 0,
+        "limit": 20,
+        "total_count": 1
+      }
+      """
+
+  Scenario: List Cache Time resources filtered by path prefix and release time
+    When I GET "/v1/cache-times?path_prefix=/people&release_time_after=2024-02-01T00:00:00Z"
+    Then I should receive the following JSON response with status "200":
+      """
+      {
+        "items": [
+          {
+            "_id": "7d793037a0760186574b0282f2f435e7",
+            "path": "/people/other-path",
+            "collection_id": "other-aa00ba41d1b6625d396f21000e3c4571ebf26061a19e3462937d85804752375d",
+            "release_time": "2024-03-01T09:30:00Z"
+          }
+        ],
+        "count": 1,
+        "offset": 0,
+        "limit": 20,
+        "total_count": 1
+      }
+      """
+
+  Scenario: List Cache Time resources with pagination
+    When I GET "/v1/cache-times?offset=1&limit=1"
+    Then I should receive the following JSON response with status "200":
+      """
+      {
+        "items": [
+          {
+            "_id": "7d793037a0760186574b0282f2f435e7",
+            "path": "/people/other-path",
+            "collection_id": "other-aa00ba41d1b6625d396f21000e3c4571ebf26061a19e3462937d85804752375d",
+            "release_time": "2024-03-01T09:30:00Z"
+          }
+        ],
+        "count": 1,
+        "offset": 1,
+        "limit": 1,
+        "total_count": 2
+      }
+      """
+
+  Scenario: List Cache Time resources with an invalid limit
+    When I GET "/v1/cache-times?limit=-1"
+    Then I should receive the following JSON response with status "400":
+      """
+      {
+        "error": "validation errors: [limit should be a positive integer]"
+      }
+      """
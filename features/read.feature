@@ -33,3 +33,28 @@ Feature: Read Cache Time
         "error": "validation errors: [id should be 32 characters in length, id is not lowercase, id is not a valid hexadecimal]"
       }
       """
+
+  Scenario: Read existing Cache Time resource by path
+    Given the following document exists in the "cachetimes" collection:
+      """
+      {
+        "_id": "f73597c45671bc4a192ea2b20468579c",
+        "path": "/my-path",
+        "collection_id": "test-1a19e3462937d85804752375daa00ba41d1b6625d396f21000e3c4571ebf2606",
+        "release_time": "2024-01-31T01:23:45.678Z"
+      }
+      """
+    When I GET "/v1/cache-times?path=my-path/"
+    Then I should receive the following JSON response with status "200":
+      """
+      {
+        "_id": "f73597c45671bc4a192ea2b20468579c",
+        "path": "/my-path",
+        "collection_id": "test-1a19e3462937d85804752375daa00ba41d1b6625d396f21000e3c4571ebf2606",
+        "release_time": "2024-01-31T01:23:45.678Z"
+      }
+      """
+
+  Scenario: Read non-existing Cache Time resource by path
+    When I GET "/v1/cache-times?path=/my-path"
+    Then the HTTP status code should be "404"
@@ -0,0 +1,46 @@
+Feature: Upcoming releases
+
+  Scenario: List the releases within a window
+    Given the following document exists in the "cachetimes" collection:
+      """
+      {
+        "_id": "f73597c45671bc4a192ea2b20468579c",
+        "path": "/my-path",
+        "collection_id": "collection-1",
+        "release_time": "2100-01-31T01:23:45.678Z"
+      }
+      """
+    When I GET "/v1/releases/upcoming?within=24h"
+    Then the HTTP status code should be "200"
+
+  Scenario: List the releases within an invalid window
+    When I GET "/v1/releases/upcoming?within=tomorrow"
+    Then I should receive the following JSON response with status "400":
+      """
+      {
+        "error": "validation errors: [within should be a positive duration, such as 24h]"
+      }
+      """
+
+  Scenario: List the releases within a window longer than the maximum
+    When I GET "/v1/releases/upcoming?within=720h"
+    Then I should receive the following JSON response with status "400":
+      """
+      {
+        "error": "validation errors: [within should not exceed 168h0m0s]"
+      }
+      """
+
+  Scenario: Subscribe to the releases within a window as a calendar
+    When I GET "/v1/releases/upcoming?format=ics&group=page"
+    Then the HTTP status code should be "200"
+    And the response header "Content-Type" should be "text/calendar; charset=utf-8"
+
+  Scenario: List the releases in an unknown format
+    When I GET "/v1/releases/upcoming?format=xml"
+    Then I should receive the following JSON response with status "400":
+      """
+      {
+        "error": "validation errors: [format should be one of json, ics]"
+      }
+      """
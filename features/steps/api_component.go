@@ -7,6 +7,8 @@ import (
 	"time"
 
 	"github.com/ONSdigital/dp-legacy-cache-api/config"
+	"github.com/ONSdigital/dp-legacy-cache-api/events"
+	"github.com/ONSdigital/dp-legacy-cache-api/memory"
 	"github.com/ONSdigital/dp-legacy-cache-api/mongo"
 	"github.com/ONSdigital/dp-legacy-cache-api/service"
 	"github.com/ONSdigital/dp-legacy-cache-api/service/mock"
@@ -26,23 +28,17 @@ type Component struct {
 	apiFeature     *componenttest.APIFeature
 	authFeature    *componenttest.AuthorizationFeature
 	MongoClient    *mongo.Mongo
+	MemoryStore    *memory.Store
+	Producer       *events.MemoryProducer
 }
 
+// NewComponent creates a component whose service is backed by the MongoDB at the given URI
 func NewComponent(mongoURI, mongoDatabaseName string) (*Component, error) {
-	c := &Component{
-		errorChan:      make(chan error),
-		ServiceRunning: false,
-	}
-
-	var err error
-
-	c.Config, err = config.Get()
+	c, err := newComponent()
 	if err != nil {
 		return nil, err
 	}
 
-	c.Config.IsPublishing = true
-
 	// Extract host:port from the MongoDB URI
 	parsedURI, err := url.Parse(mongoURI)
 	if err != nil {
@@ -57,10 +53,42 @@ func NewComponent(mongoURI, mongoDatabaseName string) (*Component, error) {
 		return nil, err
 	}
 
+	return c, nil
+}
+
+// NewMemoryComponent creates a component whose service is backed by the in-memory store, so that the feature tests
+// can run without MongoDB
+func NewMemoryComponent() (*Component, error) {
+	c, err := newComponent()
+	if err != nil {
+		return nil, err
+	}
+
+	c.MemoryStore = memory.NewStore()
+
+	return c, nil
+}
+
+func newComponent() (*Component, error) {
+	c := &Component{
+		errorChan:      make(chan error),
+		ServiceRunning: false,
+	}
+
+	var err error
+
+	c.Config, err = config.Get()
+	if err != nil {
+		return nil, err
+	}
+
+	c.Config.IsPublishing = true
+
 	initMock := &mock.InitialiserMock{
 		DoGetHealthCheckFunc: c.DoGetHealthcheckOk,
 		DoGetHTTPServerFunc:  c.DoGetHTTPServer,
 		DoGetMongoDBFunc:     c.DoGetMongoDB,
+		DoGetEventSinksFunc:  c.DoGetEventSinks,
 	}
 
 	c.svcList = service.NewServiceList(initMock)
@@ -87,8 +115,10 @@ func (c *Component) Close() error {
 
 	if c.svc != nil && c.ServiceRunning {
 		c.authFeature.Close()
-		if err := c.MongoClient.Connection.DropDatabase(ctx); err != nil {
-			return err
+		if c.MongoClient != nil {
+			if err := c.MongoClient.Connection.DropDatabase(ctx); err != nil {
+				return err
+			}
 		}
 		if err := c.svc.Close(ctx); err != nil {
 			return err
@@ -127,5 +157,15 @@ func (c *Component) DoGetHTTPServer(bindAddr string, router http.Handler) servic
 }
 
 func (c *Component) DoGetMongoDB(_ context.Context, _ *config.Config) (service.DataStore, error) {
+	if c.MemoryStore != nil {
+		return c.MemoryStore, nil
+	}
 	return c.MongoClient, nil
 }
+
+// DoGetEventSinks publishes events through a kafka sink backed by an in memory producer, fresh for every scenario, so
+// that the steps can check what was published
+func (c *Component) DoGetEventSinks(_ context.Context, _ *config.Config) ([]events.Sink, error) {
+	c.Producer = &events.MemoryProducer{}
+	return []events.Sink{events.NewKafkaSink(c.Producer)}, nil
+}
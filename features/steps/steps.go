@@ -1,10 +1,97 @@
 package steps
 
 import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	errs "github.com/ONSdigital/dp-legacy-cache-api/apierrors"
+	"github.com/ONSdigital/dp-legacy-cache-api/models"
 	"github.com/cucumber/godog"
 )
 
+const cacheTimesCollection = "cachetimes"
+
 func (c *Component) RegisterSteps(ctx *godog.ScenarioContext) {
 	c.apiFeature.RegisterSteps(ctx)
 	c.authFeature.RegisterSteps(ctx)
+
+	ctx.Step(`^I set the "([^"]*)" header to the entity tag of version (\d+)$`, c.iSetTheHeaderToTheEntityTagOfVersion)
+	ctx.Step(`^the response should carry the entity tag of version (\d+)$`, c.theResponseShouldCarryTheEntityTagOfVersion)
+	ctx.Step(`^an? "([^"]*)" event should have been published for "([^"]*)"$`, c.anEventShouldHaveBeenPublishedFor)
+
+	if c.MemoryStore != nil {
+		c.registerMemoryStoreSteps(ctx)
+	}
+}
+
+// iSetTheHeaderToTheEntityTagOfVersion sets a header to an entity tag, which the generic header step cannot do as
+// entity tags are quoted
+func (c *Component) iSetTheHeaderToTheEntityTagOfVersion(header string, version int) error {
+	return c.apiFeature.ISetTheHeaderTo(header, models.ETag(version))
+}
+
+func (c *Component) theResponseShouldCarryTheEntityTagOfVersion(version int) error {
+	return c.apiFeature.TheResponseHeaderShouldBe("ETag", models.ETag(version))
+}
+
+// anEventShouldHaveBeenPublishedFor waits briefly for the event, as events are published in the background
+func (c *Component) anEventShouldHaveBeenPublishedFor(action, id string) error {
+	deadline := time.Now().Add(time.Second)
+	for {
+		if c.Producer != nil {
+			for _, message := range c.Producer.Messages() {
+				var event models.CacheTimeEvent
+				if err := json.Unmarshal(message.Value, &event); err != nil {
+					return err
+				}
+				if event.Action == action && event.CacheTimeID == id && string(message.Key) == id {
+					return nil
+				}
+			}
+		}
+		if time.Now().After(deadline) {
+			return fmt.Errorf("no %s event was published for %s", action, id)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+// registerMemoryStoreSteps registers the subset of the MongoFeature steps used by the feature files, implemented
+// against the in-memory store
+func (c *Component) registerMemoryStoreSteps(ctx *godog.ScenarioContext) {
+	ctx.Step(`^the following document exists in the "([^"]*)" collection:$`, c.theFollowingDocumentExistsInTheCollection)
+	ctx.Step(`^the document with "([^"]*)" set to "([^"]*)" does not exist in the "([^"]*)" collection$`, c.theDocumentWithSetToDoesNotExistInTheCollection)
+}
+
+func (c *Component) theFollowingDocumentExistsInTheCollection(collectionName string, document *godog.DocString) error {
+	if collectionName != cacheTimesCollection {
+		return fmt.Errorf("collection %s is not supported by the in-memory store", collectionName)
+	}
+
+	var cacheTime models.CacheTime
+	if err := json.Unmarshal([]byte(document.Content), &cacheTime); err != nil {
+		return err
+	}
+
+	c.MemoryStore.Seed(&cacheTime)
+	return nil
+}
+
+func (c *Component) theDocumentWithSetToDoesNotExistInTheCollection(key, value, collectionName string) error {
+	if collectionName != cacheTimesCollection || key != "_id" {
+		return fmt.Errorf("only documents of the %s collection can be looked up by _id in the in-memory store", cacheTimesCollection)
+	}
+
+	_, err := c.MemoryStore.GetCacheTime(context.Background(), value)
+	if errors.Is(err, errs.ErrCacheTimeNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	return fmt.Errorf("document with property %s: %s was found in the collection", key, value)
 }
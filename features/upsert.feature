@@ -1,9 +1,9 @@
 Feature: Upsert Cache Time
 
   Scenario: Create Cache Time resource
-    Given the document with "_id" set to "5d41402abc4b2a76b9719d911017c592" does not exist in the "cachetimes" collection
+    Given the document with "_id" set to "f73597c45671bc4a192ea2b20468579c" does not exist in the "cachetimes" collection
     And I am authorised
-    When I PUT "/v1/cache-times/5d41402abc4b2a76b9719d911017c592"
+    When I PUT "/v1/cache-times/f73597c45671bc4a192ea2b20468579c"
       """
       {
         "path": "/my-path",
@@ -11,53 +11,102 @@ Feature: Upsert Cache Time
         "release_time": "2024-01-31T01:23:45.678Z"
       }
       """
-    Then the HTTP status code should be "204"
+    Then I should receive the following JSON response with status "201":
+      """
+      {
+        "_id": "f73597c45671bc4a192ea2b20468579c",
+        "path": "/my-path",
+        "collection_id": "test-1a19e3462937d85804752375daa00ba41d1b6625d396f21000e3c4571ebf2606",
+        "release_time": "2024-01-31T01:23:45.678Z",
+        "created_at": "{{DYNAMIC_RECENT_TIMESTAMP}}",
+        "last_updated": "{{DYNAMIC_RECENT_TIMESTAMP}}",
+        "last_updated_by": "svc-authenticated",
+        "version": 1
+      }
+      """
+    And the response header "Location" should be "/v1/cache-times/f73597c45671bc4a192ea2b20468579c"
+    And the response should carry the entity tag of version 1
+    And a "created" event should have been published for "f73597c45671bc4a192ea2b20468579c"
 
   Scenario: Update Cache Time resource
     Given the following document exists in the "cachetimes" collection:
       """
       {
-        "_id": "5d41402abc4b2a76b9719d911017c592",
+        "_id": "f73597c45671bc4a192ea2b20468579c",
         "path": "/my-path",
         "collection_id": "test-1a19e3462937d85804752375daa00ba41d1b6625d396f21000e3c4571ebf2606",
         "release_time": "2024-01-31T01:23:45.678Z"
       }
       """
     And I am authorised
-    When I PUT "/v1/cache-times/5d41402abc4b2a76b9719d911017c592"
+    When I PUT "/v1/cache-times/f73597c45671bc4a192ea2b20468579c"
       """
       {
-        "path": "/some/other/path",
+        "path": "/my-path",
         "collection_id": "updatedcollectionid-aa00ba41d1b6625d396f21000e3c4571ebf26061a19e3462937d85804752375d",
         "release_time": "1999-12-23T11:22:33.444Z"
       }
       """
     Then the HTTP status code should be "204"
+    And the response should carry the entity tag of version 1
+    And an "updated" event should have been published for "f73597c45671bc4a192ea2b20468579c"
 
   Scenario: Upsert Cache Time resource with empty body
-    Given the document with "_id" set to "5d41402abc4b2a76b9719d911017c592" does not exist in the "cachetimes" collection
+    Given the document with "_id" set to "f73597c45671bc4a192ea2b20468579c" does not exist in the "cachetimes" collection
+    And I am authorised
+    When I PUT "/v1/cache-times/f73597c45671bc4a192ea2b20468579c"
+      """
+      """
+    Then the HTTP status code should be "400"
+
+  Scenario: Upsert Cache Time resource with an id that does not match the path
+    Given the document with "_id" set to "f73597c45671bc4a192ea2b20468579c" does not exist in the "cachetimes" collection
     And I am authorised
-    When I PUT "/v1/cache-times/5d41402abc4b2a76b9719d911017c592"
+    When I PUT "/v1/cache-times/f73597c45671bc4a192ea2b20468579c"
       """
+      {
+        "path": "/some/other/path"
+      }
       """
     Then the HTTP status code should be "400"
 
+  Scenario: Upsert Cache Time resource setting server managed fields
+    Given I am authorised
+    When I PUT "/v1/cache-times/f73597c45671bc4a192ea2b20468579c"
+      """
+      {
+        "path": "/my-path",
+        "created_at": "2024-01-31T01:23:45.678Z",
+        "last_updated_by": "someone-else"
+      }
+      """
+    Then I should receive the following JSON response with status "400":
+      """
+      {
+        "error": "validation errors: [created_at field is read-only, last_updated_by field is read-only]"
+      }
+      """
+
   Scenario: Upsert Cache Time resource with empty release_time & collection_id
-    Given the document with "_id" set to "5d41402abc4b2a76b9719d911017c592" does not exist in the "cachetimes" collection
+    Given the document with "_id" set to "f73597c45671bc4a192ea2b20468579c" does not exist in the "cachetimes" collection
     And I am authorised
-    When I PUT "/v1/cache-times/5d41402abc4b2a76b9719d911017c592"
+    When I PUT "/v1/cache-times/f73597c45671bc4a192ea2b20468579c"
       """
       {
         "path": "/my-path"
       }
       """
-    Then the HTTP status code should be "204"
-    And I GET "/v1/cache-times/5d41402abc4b2a76b9719d911017c592"
+    Then the HTTP status code should be "201"
+    And I GET "/v1/cache-times/f73597c45671bc4a192ea2b20468579c"
     And I should receive the following JSON response with status "200":
       """
       {
-        "_id": "5d41402abc4b2a76b9719d911017c592",
-        "path": "/my-path"
+        "_id": "f73597c45671bc4a192ea2b20468579c",
+        "path": "/my-path",
+        "created_at": "{{DYNAMIC_RECENT_TIMESTAMP}}",
+        "last_updated": "{{DYNAMIC_RECENT_TIMESTAMP}}",
+        "last_updated_by": "svc-authenticated",
+        "version": 1
       }
       """
 
@@ -65,32 +114,63 @@ Feature: Upsert Cache Time
     Given the following document exists in the "cachetimes" collection:
       """
       {
-        "_id": "5d41402abc4b2a76b9719d911017c592",
+        "_id": "f73597c45671bc4a192ea2b20468579c",
         "path": "/my-path",
         "collection_id": "test-1a19e3462937d85804752375daa00ba41d1b6625d396f21000e3c4571ebf2606",
         "release_time": "2024-01-31T01:23:45.678Z"
       }
       """
     And I am authorised
-    When I PUT "/v1/cache-times/5d41402abc4b2a76b9719d911017c592"
+    When I PUT "/v1/cache-times/f73597c45671bc4a192ea2b20468579c"
       """
       {
-        "path": "/some/other/path"
+        "path": "/my-path"
       }
       """
     Then the HTTP status code should be "204"
-    And I GET "/v1/cache-times/5d41402abc4b2a76b9719d911017c592"
+    And I GET "/v1/cache-times/f73597c45671bc4a192ea2b20468579c"
     And I should receive the following JSON response with status "200":
       """
       {
-        "_id": "5d41402abc4b2a76b9719d911017c592",
-        "path": "/some/other/path"
+        "_id": "f73597c45671bc4a192ea2b20468579c",
+        "path": "/my-path",
+        "last_updated": "{{DYNAMIC_RECENT_TIMESTAMP}}",
+        "last_updated_by": "svc-authenticated",
+        "version": 1
+      }
+      """
+
+  Scenario: Create Cache Time resource by path
+    Given the document with "_id" set to "f73597c45671bc4a192ea2b20468579c" does not exist in the "cachetimes" collection
+    And I am authorised
+    When I PUT "/v1/cache-times"
+      """
+      {
+        "path": "/my-path/",
+        "collection_id": "test-1a19e3462937d85804752375daa00ba41d1b6625d396f21000e3c4571ebf2606",
+        "release_time": "2024-01-31T01:23:45.678Z"
+      }
+      """
+    Then the HTTP status code should be "201"
+    And the response header "Location" should be "/v1/cache-times/f73597c45671bc4a192ea2b20468579c"
+    And I GET "/v1/cache-times/f73597c45671bc4a192ea2b20468579c"
+    And I should receive the following JSON response with status "200":
+      """
+      {
+        "_id": "f73597c45671bc4a192ea2b20468579c",
+        "path": "/my-path",
+        "collection_id": "test-1a19e3462937d85804752375daa00ba41d1b6625d396f21000e3c4571ebf2606",
+        "release_time": "2024-01-31T01:23:45.678Z",
+        "created_at": "{{DYNAMIC_RECENT_TIMESTAMP}}",
+        "last_updated": "{{DYNAMIC_RECENT_TIMESTAMP}}",
+        "last_updated_by": "svc-authenticated",
+        "version": 1
       }
       """
 
   Scenario: Upsert Cache Time resource while not authorised
     Given I am not authorised
-    When I PUT "/v1/cache-times/5d41402abc4b2a76b9719d911017c592"
+    When I PUT "/v1/cache-times/f73597c45671bc4a192ea2b20468579c"
       """
       {
         "path": "/my-path",
@@ -2,12 +2,15 @@ package main
 
 import (
 	"context"
+	"flag"
 	"os"
 	"os/signal"
 	"syscall"
 
 	"github.com/ONSdigital/dp-legacy-cache-api/config"
+	"github.com/ONSdigital/dp-legacy-cache-api/mongo"
 	"github.com/ONSdigital/dp-legacy-cache-api/service"
+	"github.com/ONSdigital/dp-legacy-cache-api/tracing"
 	"github.com/ONSdigital/log.go/v2/log"
 	"github.com/pkg/errors"
 )
@@ -23,9 +26,20 @@ var (
 	Version string
 )
 
+var migrateFlag = flag.Bool("migrate", false, "apply the pending database migrations and create the missing indexes, then exit")
+
 func main() {
 	log.Namespace = serviceName
 	ctx := context.Background()
+	flag.Parse()
+
+	if *migrateFlag {
+		if err := migrate(ctx); err != nil {
+			log.Fatal(ctx, "migration failed", err)
+			os.Exit(1)
+		}
+		return
+	}
 
 	if err := run(ctx); err != nil {
 		log.Fatal(ctx, "fatal runtime error", err)
@@ -48,6 +62,18 @@ func run(ctx context.Context) error {
 	if err != nil {
 		return errors.Wrap(err, "error getting configuration")
 	}
+
+	// Set up tracing, flushing any pending spans on the way out
+	shutdownTracing, err := tracing.Setup(ctx, cfg)
+	if err != nil {
+		return errors.Wrap(err, "error setting up tracing")
+	}
+	defer func() {
+		if err := shutdownTracing(context.Background()); err != nil {
+			log.Error(ctx, "failed to shut down tracing", err)
+		}
+	}()
+
 	// Start service
 	svc, err := service.Run(ctx, cfg, svcList, BuildTime, GitCommit, Version, svcErrors)
 	if err != nil {
@@ -66,3 +92,27 @@ func run(ctx context.Context) error {
 	}
 	return svc.Close(ctx)
 }
+
+// migrate applies the pending database migrations without running the service
+func migrate(ctx context.Context) error {
+	cfg, err := config.Get()
+	if err != nil {
+		return errors.Wrap(err, "error getting configuration")
+	}
+
+	store, err := mongo.NewMongoStore(ctx, cfg.MongoConfig)
+	if err != nil {
+		return errors.Wrap(err, "error connecting to mongo")
+	}
+	defer func() {
+		if closeErr := store.Close(ctx); closeErr != nil {
+			log.Error(ctx, "error closing mongo connection", closeErr)
+		}
+	}()
+
+	if err := store.Migrate(ctx); err != nil {
+		return err
+	}
+	log.Info(ctx, "migration complete")
+	return nil
+}
@@ -7,6 +7,7 @@ import (
 	"testing"
 
 	componenttest "github.com/ONSdigital/dp-component-test"
+	"github.com/ONSdigital/dp-legacy-cache-api/config"
 	"github.com/ONSdigital/dp-legacy-cache-api/features/steps"
 	"github.com/cucumber/godog"
 	"github.com/cucumber/godog/colors"
@@ -16,16 +17,11 @@ var componentFlag = flag.Bool("component", false, "perform component tests")
 
 type ComponentTest struct {
 	MongoFeature *componenttest.MongoFeature
+	InMemory     bool
 }
 
 func (f *ComponentTest) InitializeScenario(ctx *godog.ScenarioContext) {
-	mongoURI, err := f.MongoFeature.GetConnectionString()
-	if err != nil {
-		panic(err)
-	}
-	mongoDatabaseName := f.MongoFeature.Database.Name()
-
-	component, err := steps.NewComponent(mongoURI, mongoDatabaseName)
+	component, err := f.newComponent()
 	if err != nil {
 		panic(err)
 	}
@@ -33,8 +29,10 @@ func (f *ComponentTest) InitializeScenario(ctx *godog.ScenarioContext) {
 	ctx.Before(func(ctx context.Context, sc *godog.Scenario) (context.Context, error) {
 		component.Reset()
 
-		if err := f.MongoFeature.Reset(); err != nil {
-			panic(err)
+		if f.MongoFeature != nil {
+			if err := f.MongoFeature.Reset(); err != nil {
+				panic(err)
+			}
 		}
 
 		return ctx, nil
@@ -49,15 +47,39 @@ func (f *ComponentTest) InitializeScenario(ctx *godog.ScenarioContext) {
 	})
 
 	component.RegisterSteps(ctx)
-	f.MongoFeature.RegisterSteps(ctx)
+	if f.MongoFeature != nil {
+		f.MongoFeature.RegisterSteps(ctx)
+	}
+}
+
+// newComponent creates a component backed by the in-memory store when STORE_BACKEND=memory, and by the test MongoDB
+// otherwise
+func (f *ComponentTest) newComponent() (*steps.Component, error) {
+	if f.InMemory {
+		return steps.NewMemoryComponent()
+	}
+
+	mongoURI, err := f.MongoFeature.GetConnectionString()
+	if err != nil {
+		return nil, err
+	}
+	mongoDatabaseName := f.MongoFeature.Database.Name()
+
+	return steps.NewComponent(mongoURI, mongoDatabaseName)
 }
 
 func (f *ComponentTest) InitializeTestSuite(ctx *godog.TestSuiteContext) {
 	const MongoVersion = "4.4.8"
 	const DatabaseName = "testing"
+	// Transactions require MongoDB to run as a replica set
+	const ReplicaSetName = "rs0"
+
+	if f.InMemory {
+		return
+	}
 
 	ctx.BeforeSuite(func() {
-		f.MongoFeature = componenttest.NewMongoFeature(componenttest.MongoOptions{MongoVersion: MongoVersion, DatabaseName: DatabaseName})
+		f.MongoFeature = componenttest.NewMongoFeature(componenttest.MongoOptions{MongoVersion: MongoVersion, DatabaseName: DatabaseName, ReplicaSetName: ReplicaSetName})
 	})
 	ctx.AfterSuite(func() {
 		err := f.MongoFeature.Close()
@@ -78,7 +100,14 @@ func TestComponent(t *testing.T) {
 			Strict: true,
 		}
 
-		f := &ComponentTest{}
+		cfg, err := config.Get()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		f := &ComponentTest{
+			InMemory: cfg.StoreBackend == config.StoreBackendMemory,
+		}
 
 		status = godog.TestSuite{
 			Name:                 "feature_tests",
@@ -0,0 +1,412 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ONSdigital/dp-healthcheck/healthcheck"
+	errs "github.com/ONSdigital/dp-legacy-cache-api/apierrors"
+	"github.com/ONSdigital/dp-legacy-cache-api/models"
+	dprequest "github.com/ONSdigital/dp-net/v3/request"
+)
+
+const healthyMessage = "in-memory store is always available"
+
+// Store is an in-memory implementation of api.DataStore for local development and tests. Its contents are lost when
+// the service stops.
+type Store struct {
+	mu         sync.RWMutex
+	cacheTimes map[string]*models.CacheTime
+	history    []*models.CacheTimeChange
+	releases   map[string]*models.Release
+	now        func() time.Time
+}
+
+// NewStore creates an empty in-memory store
+func NewStore() *Store {
+	return &Store{
+		cacheTimes: make(map[string]*models.CacheTime),
+		releases:   make(map[string]*models.Release),
+		now:        time.Now,
+	}
+}
+
+// Checker always reports the in-memory store as healthy
+func (s *Store) Checker(_ context.Context, state *healthcheck.CheckState) error {
+	return state.Update(healthcheck.StatusOK, healthyMessage, 0)
+}
+
+// Close does nothing as the in-memory store holds no connection
+func (s *Store) Close(_ context.Context) error {
+	return nil
+}
+
+// IsConnected always returns true as the in-memory store holds no connection
+func (s *Store) IsConnected(_ context.Context) bool {
+	return true
+}
+
+// Seed stores copies of the given cache times as they are, without stamping them or recording their history, as if
+// they had been written to the database directly
+func (s *Store) Seed(cacheTimes ...*models.CacheTime) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, cacheTime := range cacheTimes {
+		s.cacheTimes[cacheTime.ID] = copyCacheTime(cacheTime)
+	}
+}
+
+// GetCacheTime returns a cache time with its given id
+func (s *Store) GetCacheTime(_ context.Context, id string) (*models.CacheTime, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	cacheTime, ok := s.cacheTimes[id]
+	if !ok {
+		return nil, errs.ErrCacheTimeNotFound
+	}
+	return copyCacheTime(cacheTime), nil
+}
+
+// GetCacheTimes returns a page of cache times matching the given filter, in id order, along with the total number of
+// matches
+func (s *Store) GetCacheTimes(_ context.Context, filter models.CacheTimesFilter, offset, limit int) ([]*models.CacheTime, int, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var matches []*models.CacheTime
+	for _, cacheTime := range s.cacheTimes {
+		if matchesFilter(cacheTime, filter) {
+			matches = append(matches, cacheTime)
+		}
+	}
+	sort.Slice(matches, func(i, j int) bool { return matches[i].ID < matches[j].ID })
+
+	results := []*models.CacheTime{}
+	for i := offset; i < len(matches) && len(results) < limit; i++ {
+		results = append(results, copyCacheTime(matches[i]))
+	}
+	return results, len(matches), nil
+}
+
+// ExportCacheTimes calls export with a copy of every cache time matching the given filter, in id order. The matches are
+// copied before the first call, so that export can call the store. An error returned by export stops the export and is
+// returned as is.
+func (s *Store) ExportCacheTimes(_ context.Context, filter models.CacheTimesFilter, export func(*models.CacheTime) error) error {
+	s.mu.RLock()
+	var matches []*models.CacheTime
+	for _, cacheTime := range s.sortedCacheTimes() {
+		if matchesFilter(cacheTime, filter) {
+			matches = append(matches, copyCacheTime(cacheTime))
+		}
+	}
+	s.mu.RUnlock()
+
+	for _, cacheTime := range matches {
+		if err := export(cacheTime); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// GetCacheTimesReleasedBetween returns every cache time whose release time is between from and to, both included, in
+// release time order
+func (s *Store) GetCacheTimesReleasedBetween(_ context.Context, from, to time.Time) ([]*models.CacheTime, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	results := []*models.CacheTime{}
+	for _, cacheTime := range s.sortedCacheTimes() {
+		if cacheTime.ReleaseTime != nil && !cacheTime.ReleaseTime.Before(from) && !cacheTime.ReleaseTime.After(to) {
+			results = append(results, copyCacheTime(cacheTime))
+		}
+	}
+	sort.SliceStable(results, func(i, j int) bool { return results[i].ReleaseTime.Before(*results[j].ReleaseTime) })
+	return results, nil
+}
+
+func matchesFilter(cacheTime *models.CacheTime, filter models.CacheTimesFilter) bool {
+	if filter.CollectionID != "" && cacheTime.CollectionID != filter.CollectionID {
+		return false
+	}
+	if filter.PathPrefix != "" && !strings.HasPrefix(cacheTime.Path, filter.PathPrefix) {
+		return false
+	}
+	if filter.ReleaseTimeBefore != nil && (cacheTime.ReleaseTime == nil || !cacheTime.ReleaseTime.Before(*filter.ReleaseTimeBefore)) {
+		return false
+	}
+	if filter.ReleaseTimeAfter != nil && (cacheTime.ReleaseTime == nil || !cacheTime.ReleaseTime.After(*filter.ReleaseTimeAfter)) {
+		return false
+	}
+	return true
+}
+
+// UpsertCacheTime adds or overrides an existing cache time, provided the stored cache time matches the precondition
+// and no other cache time has the same path, and returns the change made
+func (s *Store) UpsertCacheTime(ctx context.Context, cacheTime *models.CacheTime, precondition models.Precondition) (*models.CacheTimeChange, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !precondition.Matches(s.cacheTimes[cacheTime.ID]) {
+		return nil, errs.ErrPreconditionFailed
+	}
+	if s.hasPathConflict(cacheTime) {
+		return nil, errs.ErrPathConflict
+	}
+	return copyCacheTimeChange(s.put(ctx, cacheTime)), nil
+}
+
+// UpsertCacheTimes adds or overrides the given cache times. The returned slice reports, for each cache time in the
+// same order, the change made to it or why it was not stored. As with UpsertCacheTime, a cache time is not
+// stored when another cache time has the same path.
+func (s *Store) UpsertCacheTimes(ctx context.Context, cacheTimes []*models.CacheTime) ([]models.UpsertResult, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	results := make([]models.UpsertResult, len(cacheTimes))
+	for i, cacheTime := range cacheTimes {
+		if s.hasPathConflict(cacheTime) {
+			results[i].Err = errs.ErrPathConflict
+			continue
+		}
+		results[i].Change = copyCacheTimeChange(s.put(ctx, cacheTime))
+	}
+	return results, nil
+}
+
+// hasPathConflict reports whether a cache time other than the given one has its path
+func (s *Store) hasPathConflict(cacheTime *models.CacheTime) bool {
+	for id, stored := range s.cacheTimes {
+		if id != cacheTime.ID && stored.Path == cacheTime.Path {
+			return true
+		}
+	}
+	return false
+}
+
+// DeleteCacheTime removes the cache time with the given id, provided it matches the precondition, and returns the
+// change made
+func (s *Store) DeleteCacheTime(ctx context.Context, id string, precondition models.Precondition) (*models.CacheTimeChange, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	cacheTime, ok := s.cacheTimes[id]
+	switch {
+	case !precondition.Matches(cacheTime):
+		return nil, errs.ErrPreconditionFailed
+	case !ok:
+		return nil, errs.ErrCacheTimeNotFound
+	}
+	return copyCacheTimeChange(s.remove(ctx, id)), nil
+}
+
+// UpdateCollectionReleaseTime sets the release time of every cache time in the given collection, returning the changes
+// made
+func (s *Store) UpdateCollectionReleaseTime(ctx context.Context, collectionID string, releaseTime *time.Time) ([]*models.CacheTimeChange, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	changes := []*models.CacheTimeChange{}
+	for _, cacheTime := range s.sortedCacheTimes() {
+		if cacheTime.CollectionID == collectionID {
+			updated := copyCacheTime(cacheTime)
+			updated.ReleaseTime = releaseTime
+			changes = append(changes, copyCacheTimeChange(s.put(ctx, updated)))
+		}
+	}
+	return changes, nil
+}
+
+// DeleteCollectionCacheTimes removes every cache time in the given collection, returning the changes made
+func (s *Store) DeleteCollectionCacheTimes(ctx context.Context, collectionID string) ([]*models.CacheTimeChange, error) {
+	return s.deleteCacheTimes(ctx, func(cacheTime *models.CacheTime) bool {
+		return cacheTime.CollectionID == collectionID
+	}), nil
+}
+
+// DeleteCacheTimesReleasedBefore removes every cache time whose release time is before the given time, returning the
+// number of cache times deleted
+func (s *Store) DeleteCacheTimesReleasedBefore(ctx context.Context, before time.Time) (int, error) {
+	changes := s.deleteCacheTimes(ctx, func(cacheTime *models.CacheTime) bool {
+		return cacheTime.ReleaseTime != nil && cacheTime.ReleaseTime.Before(before)
+	})
+	return len(changes), nil
+}
+
+func (s *Store) deleteCacheTimes(ctx context.Context, matches func(*models.CacheTime) bool) []*models.CacheTimeChange {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	changes := []*models.CacheTimeChange{}
+	for _, cacheTime := range s.sortedCacheTimes() {
+		if matches(cacheTime) {
+			changes = append(changes, copyCacheTimeChange(s.remove(ctx, cacheTime.ID)))
+		}
+	}
+	return changes
+}
+
+// GetCacheTimeHistory returns a page of the changes made to the cache time with the given id, most recent first, along
+// with the total number of changes
+func (s *Store) GetCacheTimeHistory(_ context.Context, id string, offset, limit int) ([]*models.CacheTimeChange, int, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var matches []*models.CacheTimeChange
+	for i := len(s.history) - 1; i >= 0; i-- {
+		if s.history[i].CacheTimeID == id {
+			matches = append(matches, s.history[i])
+		}
+	}
+
+	results := []*models.CacheTimeChange{}
+	for i := offset; i < len(matches) && len(results) < limit; i++ {
+		results = append(results, copyCacheTimeChange(matches[i]))
+	}
+	return results, len(matches), nil
+}
+
+// RecordRelease stores a copy of a pending release, unless a release with the same id has already been recorded
+func (s *Store) RecordRelease(_ context.Context, release *models.Release) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.releases[release.ID]; ok {
+		return errs.ErrReleaseRecorded
+	}
+	copied := *release
+	s.releases[release.ID] = &copied
+	return nil
+}
+
+// ClaimRelease records that a pending release is fired again by firedBy at firedAt, provided it is still pending and was
+// last fired when the given release says. ErrReleaseRecorded is returned otherwise.
+func (s *Store) ClaimRelease(_ context.Context, release *models.Release, firedBy string, firedAt time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	existing, ok := s.releases[release.ID]
+	if !ok || !existing.IsPending() || !existing.FiredAt.Equal(release.FiredAt) {
+		return errs.ErrReleaseRecorded
+	}
+	existing.FiredAt = firedAt
+	existing.FiredBy = firedBy
+	return nil
+}
+
+// MarkReleaseSent records that the release with the given id was sent to every sink at sentAt
+func (s *Store) MarkReleaseSent(_ context.Context, id string, sentAt time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if release, ok := s.releases[id]; ok {
+		release.SentAt = &sentAt
+	}
+	return nil
+}
+
+// GetPendingReleases returns copies of the releases not sent yet that were last fired no later than firedBefore, in
+// release time order
+func (s *Store) GetPendingReleases(_ context.Context, firedBefore time.Time) ([]*models.Release, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	results := []*models.Release{}
+	for _, release := range s.releases {
+		if release.IsPending() && !release.FiredAt.After(firedBefore) {
+			copied := *release
+			results = append(results, &copied)
+		}
+	}
+	sort.Slice(results, func(i, j int) bool {
+		if !results[i].ReleaseTime.Equal(results[j].ReleaseTime) {
+			return results[i].ReleaseTime.Before(results[j].ReleaseTime)
+		}
+		return results[i].ID < results[j].ID
+	})
+	return results, nil
+}
+
+// GetLatestReleaseTime returns the latest release time recorded, or nil if no release is recorded
+func (s *Store) GetLatestReleaseTime(_ context.Context) (*time.Time, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var latest *time.Time
+	for _, release := range s.releases {
+		if latest == nil || release.ReleaseTime.After(*latest) {
+			releaseTime := release.ReleaseTime
+			latest = &releaseTime
+		}
+	}
+	return latest, nil
+}
+
+// put stores a copy of a cache time, stamped with the identity of the caller, and records the change in its history,
+// which is returned. The caller must hold the write lock.
+func (s *Store) put(ctx context.Context, cacheTime *models.CacheTime) *models.CacheTimeChange {
+	var previous *models.CacheTime
+	if existing, ok := s.cacheTimes[cacheTime.ID]; ok {
+		previous = copyCacheTime(existing)
+	}
+
+	changedBy, changedAt := dprequest.Caller(ctx), s.now().UTC()
+	current := copyCacheTime(models.StampCacheTime(cacheTime, previous, changedBy, changedAt))
+
+	change := models.NewCacheTimeChange(previous, copyCacheTime(current), changedBy, changedAt)
+	s.cacheTimes[cacheTime.ID] = current
+	s.history = append(s.history, change)
+	return change
+}
+
+// remove deletes a stored cache time and records the change in its history, which is returned. The caller must hold
+// the write lock.
+func (s *Store) remove(ctx context.Context, id string) *models.CacheTimeChange {
+	change := models.NewCacheTimeChange(s.cacheTimes[id], nil, dprequest.Caller(ctx), s.now().UTC())
+	delete(s.cacheTimes, id)
+	s.history = append(s.history, change)
+	return change
+}
+
+// sortedCacheTimes returns the stored cache times in id order, so that bulk changes are recorded in a stable order
+func (s *Store) sortedCacheTimes() []*models.CacheTime {
+	cacheTimes := make([]*models.CacheTime, 0, len(s.cacheTimes))
+	for _, cacheTime := range s.cacheTimes {
+		cacheTimes = append(cacheTimes, cacheTime)
+	}
+	sort.Slice(cacheTimes, func(i, j int) bool { return cacheTimes[i].ID < cacheTimes[j].ID })
+	return cacheTimes
+}
+
+// copyCacheTime returns a deep copy of a cache time, so that callers can never modify the stored value
+func copyCacheTime(cacheTime *models.CacheTime) *models.CacheTime {
+	copied := *cacheTime
+	copied.ReleaseTime = copyTime(cacheTime.ReleaseTime)
+	copied.CreatedAt = copyTime(cacheTime.CreatedAt)
+	copied.LastUpdated = copyTime(cacheTime.LastUpdated)
+	return &copied
+}
+
+func copyCacheTimeChange(change *models.CacheTimeChange) *models.CacheTimeChange {
+	copied := *change
+	if change.Previous != nil {
+		copied.Previous = copyCacheTime(change.Previous)
+	}
+	if change.Current != nil {
+		copied.Current = copyCacheTime(change.Current)
+	}
+	return &copied
+}
+
+func copyTime(t *time.Time) *time.Time {
+	if t == nil {
+		return nil
+	}
+	copied := *t
+	return &copied
+}
@@ -0,0 +1,565 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ONSdigital/dp-healthcheck/healthcheck"
+	errs "github.com/ONSdigital/dp-legacy-cache-api/apierrors"
+	"github.com/ONSdigital/dp-legacy-cache-api/models"
+	dprequest "github.com/ONSdigital/dp-net/v3/request"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+var (
+	ctx         = context.Background()
+	releaseTime = time.Date(2024, time.January, 31, 1, 23, 45, 0, time.UTC)
+	laterTime   = releaseTime.Add(24 * time.Hour)
+	createdTime = time.Date(2024, time.January, 1, 9, 0, 0, 0, time.UTC)
+	updatedTime = createdTime.Add(time.Hour)
+)
+
+func newTestStore() *Store {
+	store := NewStore()
+	store.now = func() time.Time { return createdTime }
+	_, _ = store.UpsertCacheTimes(ctx, []*models.CacheTime{
+		{ID: "a", Path: "/economy/a", CollectionID: "collection-1", ReleaseTime: &releaseTime},
+		{ID: "b", Path: "/economy/b", CollectionID: "collection-2", ReleaseTime: &laterTime},
+		{ID: "c", Path: "/people/c", CollectionID: "collection-1"},
+	})
+	return store
+}
+
+func TestChecker(t *testing.T) {
+	Convey("Given an in-memory store", t, func() {
+		store := NewStore()
+
+		Convey("When its health is checked", func() {
+			state := healthcheck.NewCheckState("store")
+			err := store.Checker(ctx, state)
+
+			Convey("Then it is always reported as healthy", func() {
+				So(err, ShouldBeNil)
+				So(state.Status(), ShouldEqual, healthcheck.StatusOK)
+				So(store.IsConnected(ctx), ShouldBeTrue)
+			})
+		})
+	})
+}
+
+func TestGetCacheTime(t *testing.T) {
+	Convey("Given a store holding cache times", t, func() {
+		store := newTestStore()
+
+		Convey("When an existing cache time is requested", func() {
+			cacheTime, err := store.GetCacheTime(ctx, "a")
+
+			Convey("Then a copy of it is returned", func() {
+				So(err, ShouldBeNil)
+				So(cacheTime, ShouldResemble, &models.CacheTime{
+					ID: "a", Path: "/economy/a", CollectionID: "collection-1", ReleaseTime: &releaseTime,
+					CreatedAt: &createdTime, LastUpdated: &createdTime, Version: 1,
+				})
+
+				cacheTime.Path = "/modified"
+				stored, _ := store.GetCacheTime(ctx, "a")
+				So(stored.Path, ShouldEqual, "/economy/a")
+			})
+		})
+
+		Convey("When an unknown cache time is requested", func() {
+			cacheTime, err := store.GetCacheTime(ctx, "unknown")
+
+			Convey("Then ErrCacheTimeNotFound is returned", func() {
+				So(cacheTime, ShouldBeNil)
+				So(err, ShouldEqual, errs.ErrCacheTimeNotFound)
+			})
+		})
+	})
+}
+
+func TestGetCacheTimes(t *testing.T) {
+	Convey("Given a store holding cache times", t, func() {
+		store := newTestStore()
+
+		Convey("When every cache time is listed", func() {
+			cacheTimes, totalCount, err := store.GetCacheTimes(ctx, models.CacheTimesFilter{}, 0, 10)
+
+			Convey("Then they are returned in id order", func() {
+				So(err, ShouldBeNil)
+				So(totalCount, ShouldEqual, 3)
+				So(ids(cacheTimes), ShouldResemble, []string{"a", "b", "c"})
+			})
+		})
+
+		Convey("When a page of cache times is listed", func() {
+			cacheTimes, totalCount, err := store.GetCacheTimes(ctx, models.CacheTimesFilter{}, 1, 1)
+
+			Convey("Then only that page is returned along with the total count", func() {
+				So(err, ShouldBeNil)
+				So(totalCount, ShouldEqual, 3)
+				So(ids(cacheTimes), ShouldResemble, []string{"b"})
+			})
+		})
+
+		Convey("When cache times are filtered", func() {
+			before := laterTime
+			byCollection, _, _ := store.GetCacheTimes(ctx, models.CacheTimesFilter{CollectionID: "collection-1"}, 0, 10)
+			byPrefix, _, _ := store.GetCacheTimes(ctx, models.CacheTimesFilter{PathPrefix: "/economy"}, 0, 10)
+			byReleaseTime, _, _ := store.GetCacheTimes(ctx, models.CacheTimesFilter{ReleaseTimeBefore: &before}, 0, 10)
+			afterReleaseTime, _, _ := store.GetCacheTimes(ctx, models.CacheTimesFilter{ReleaseTimeAfter: &releaseTime}, 0, 10)
+
+			Convey("Then only the matching cache times are returned", func() {
+				So(ids(byCollection), ShouldResemble, []string{"a", "c"})
+				So(ids(byPrefix), ShouldResemble, []string{"a", "b"})
+				So(ids(byReleaseTime), ShouldResemble, []string{"a"})
+				So(ids(afterReleaseTime), ShouldResemble, []string{"b"})
+			})
+		})
+
+		Convey("When the offset is beyond the number of matches", func() {
+			cacheTimes, totalCount, err := store.GetCacheTimes(ctx, models.CacheTimesFilter{}, 5, 10)
+
+			Convey("Then an empty list is returned", func() {
+				So(err, ShouldBeNil)
+				So(totalCount, ShouldEqual, 3)
+				So(cacheTimes, ShouldBeEmpty)
+				So(cacheTimes, ShouldNotBeNil)
+			})
+		})
+	})
+}
+
+func TestExportCacheTimes(t *testing.T) {
+	Convey("Given a store holding cache times", t, func() {
+		store := newTestStore()
+
+		Convey("When the cache times of a collection are exported", func() {
+			var exported []*models.CacheTime
+			err := store.ExportCacheTimes(ctx, models.CacheTimesFilter{CollectionID: "collection-1"}, func(cacheTime *models.CacheTime) error {
+				exported = append(exported, cacheTime)
+				return nil
+			})
+
+			Convey("Then every match is exported in id order", func() {
+				So(err, ShouldBeNil)
+				So(ids(exported), ShouldResemble, []string{"a", "c"})
+			})
+		})
+
+		Convey("When the export fails part way", func() {
+			exportErr := errors.New("client went away")
+			var exported []*models.CacheTime
+			err := store.ExportCacheTimes(ctx, models.CacheTimesFilter{}, func(cacheTime *models.CacheTime) error {
+				exported = append(exported, cacheTime)
+				return exportErr
+			})
+
+			Convey("Then the export stops and its error is returned", func() {
+				So(err, ShouldEqual, exportErr)
+				So(ids(exported), ShouldResemble, []string{"a"})
+			})
+		})
+
+		Convey("When the store is changed while the cache times are exported", func() {
+			err := store.ExportCacheTimes(ctx, models.CacheTimesFilter{}, func(cacheTime *models.CacheTime) error {
+				_, err := store.DeleteCacheTime(ctx, cacheTime.ID, models.Precondition{})
+				return err
+			})
+
+			Convey("Then the export does not block", func() {
+				So(err, ShouldBeNil)
+				cacheTimes, _, _ := store.GetCacheTimes(ctx, models.CacheTimesFilter{}, 0, 10)
+				So(cacheTimes, ShouldBeEmpty)
+			})
+		})
+	})
+}
+
+func TestGetCacheTimesReleasedBetween(t *testing.T) {
+	Convey("Given a store holding cache times released at different times", t, func() {
+		store := newTestStore()
+		earlyTime := releaseTime.Add(-time.Hour)
+		store.Seed(&models.CacheTime{ID: "d", Path: "/people/d", ReleaseTime: &earlyTime})
+
+		Convey("When the cache times released within a window are requested", func() {
+			cacheTimes, err := store.GetCacheTimesReleasedBetween(ctx, earlyTime, laterTime)
+
+			Convey("Then they are returned in release time order, including those released at either end", func() {
+				So(err, ShouldBeNil)
+				So(ids(cacheTimes), ShouldResemble, []string{"d", "a", "b"})
+			})
+		})
+
+		Convey("When no cache time is released within the window", func() {
+			cacheTimes, err := store.GetCacheTimesReleasedBetween(ctx, laterTime.Add(time.Second), laterTime.Add(time.Hour))
+
+			Convey("Then an empty list is returned", func() {
+				So(err, ShouldBeNil)
+				So(cacheTimes, ShouldBeEmpty)
+				So(cacheTimes, ShouldNotBeNil)
+			})
+		})
+	})
+}
+
+func TestUpsertCacheTimes(t *testing.T) {
+	Convey("Given a store holding cache times", t, func() {
+		store := newTestStore()
+
+		Convey("When existing and new cache times are upserted", func() {
+			store.now = func() time.Time { return updatedTime }
+			results, err := store.UpsertCacheTimes(dprequest.SetCaller(ctx, "publisher@ons.gov.uk"), []*models.CacheTime{
+				{ID: "a", Path: "/economy/a"},
+				{ID: "d", Path: "/people/d"},
+			})
+
+			Convey("Then only the new cache times are reported as created and the existing ones are replaced", func() {
+				So(err, ShouldBeNil)
+				So(results, ShouldHaveLength, 2)
+				So(results[0].Change.Action, ShouldEqual, models.CacheTimeUpdated)
+				So(results[1].Change.Action, ShouldEqual, models.CacheTimeCreated)
+				So(results[1].Change.Current.Path, ShouldEqual, "/people/d")
+
+				cacheTime, _ := store.GetCacheTime(ctx, "a")
+				So(cacheTime, ShouldResemble, &models.CacheTime{
+					ID: "a", Path: "/economy/a",
+					CreatedAt: &createdTime, LastUpdated: &updatedTime, LastUpdatedBy: "publisher@ons.gov.uk", Version: 2,
+				})
+
+				cacheTime, _ = store.GetCacheTime(ctx, "d")
+				So(*cacheTime.CreatedAt, ShouldEqual, updatedTime)
+			})
+		})
+
+		Convey("When a cache time is upserted with the path of another cache time", func() {
+			results, err := store.UpsertCacheTimes(ctx, []*models.CacheTime{
+				{ID: "d", Path: "/economy/a"},
+				{ID: "e", Path: "/people/e"},
+			})
+
+			Convey("Then it is reported as a path conflict and the other cache times are stored", func() {
+				So(err, ShouldBeNil)
+				So(results, ShouldHaveLength, 2)
+				So(results[0], ShouldResemble, models.UpsertResult{Err: errs.ErrPathConflict})
+				So(results[1].Change.Action, ShouldEqual, models.CacheTimeCreated)
+
+				_, err = store.GetCacheTime(ctx, "d")
+				So(err, ShouldEqual, errs.ErrCacheTimeNotFound)
+				_, err = store.GetCacheTime(ctx, "e")
+				So(err, ShouldBeNil)
+			})
+		})
+	})
+}
+
+func TestDeleteCacheTime(t *testing.T) {
+	Convey("Given a store holding cache times", t, func() {
+		store := newTestStore()
+
+		Convey("When an existing cache time is deleted", func() {
+			_, err := store.DeleteCacheTime(ctx, "a", models.Precondition{})
+
+			Convey("Then it can no longer be found", func() {
+				So(err, ShouldBeNil)
+				_, err = store.GetCacheTime(ctx, "a")
+				So(err, ShouldEqual, errs.ErrCacheTimeNotFound)
+			})
+		})
+
+		Convey("When an unknown cache time is deleted", func() {
+			_, err := store.DeleteCacheTime(ctx, "unknown", models.Precondition{})
+
+			Convey("Then ErrCacheTimeNotFound is returned", func() {
+				So(err, ShouldEqual, errs.ErrCacheTimeNotFound)
+			})
+		})
+	})
+}
+
+func TestUpsertCacheTimePathConflict(t *testing.T) {
+	Convey("Given a store holding cache times", t, func() {
+		store := newTestStore()
+
+		Convey("When a cache time is stored with the path of another", func() {
+			_, err := store.UpsertCacheTime(ctx, &models.CacheTime{ID: "d", Path: "/economy/a"}, models.Precondition{})
+
+			Convey("Then ErrPathConflict is returned and the cache time is not stored", func() {
+				So(err, ShouldEqual, errs.ErrPathConflict)
+				_, err = store.GetCacheTime(ctx, "d")
+				So(err, ShouldEqual, errs.ErrCacheTimeNotFound)
+			})
+		})
+
+		Convey("When a cache time is stored again with its own path", func() {
+			_, err := store.UpsertCacheTime(ctx, &models.CacheTime{ID: "a", Path: "/economy/a"}, models.Precondition{})
+
+			Convey("Then it is updated", func() {
+				So(err, ShouldBeNil)
+			})
+		})
+	})
+}
+
+func TestPreconditions(t *testing.T) {
+	Convey("Given a store holding cache times at version 1", t, func() {
+		store := newTestStore()
+		cacheTime := &models.CacheTime{ID: "a", Path: "/economy/a"}
+
+		Convey("When a cache time is upserted at its current version", func() {
+			change, err := store.UpsertCacheTime(ctx, cacheTime, models.Precondition{Versions: []int{1}})
+
+			Convey("Then it is updated and returned at the next version", func() {
+				So(err, ShouldBeNil)
+				So(change.Action, ShouldEqual, models.CacheTimeUpdated)
+				So(change.Previous.Version, ShouldEqual, 1)
+				So(change.Current.Version, ShouldEqual, 2)
+				So(change.Current.CreatedAt, ShouldResemble, &createdTime)
+			})
+		})
+
+		Convey("When a cache time is upserted at an outdated version", func() {
+			_, err := store.UpsertCacheTime(ctx, cacheTime, models.Precondition{Versions: []int{0}})
+
+			Convey("Then ErrPreconditionFailed is returned and the cache time is unchanged", func() {
+				So(err, ShouldEqual, errs.ErrPreconditionFailed)
+				stored, _ := store.GetCacheTime(ctx, "a")
+				So(stored.CollectionID, ShouldEqual, "collection-1")
+			})
+		})
+
+		Convey("When a cache time that already exists is created", func() {
+			_, err := store.UpsertCacheTime(ctx, cacheTime, models.Precondition{MustNotExist: true})
+
+			Convey("Then ErrPreconditionFailed is returned", func() {
+				So(err, ShouldEqual, errs.ErrPreconditionFailed)
+			})
+		})
+
+		Convey("When a cache time that does not exist yet is created", func() {
+			change, err := store.UpsertCacheTime(ctx, &models.CacheTime{ID: "d", Path: "/economy/d"}, models.Precondition{MustNotExist: true})
+
+			Convey("Then it is returned as created at version 1", func() {
+				So(err, ShouldBeNil)
+				So(change.Action, ShouldEqual, models.CacheTimeCreated)
+				So(change.Current.Version, ShouldEqual, 1)
+				So(change.Current.CreatedAt, ShouldResemble, &createdTime)
+			})
+		})
+
+		Convey("When a cache time is deleted at an outdated version", func() {
+			_, err := store.DeleteCacheTime(ctx, "a", models.Precondition{Versions: []int{2}})
+
+			Convey("Then ErrPreconditionFailed is returned and the cache time is kept", func() {
+				So(err, ShouldEqual, errs.ErrPreconditionFailed)
+				_, err = store.GetCacheTime(ctx, "a")
+				So(err, ShouldBeNil)
+			})
+		})
+
+		Convey("When an unknown cache time is deleted at a given version", func() {
+			_, err := store.DeleteCacheTime(ctx, "unknown", models.Precondition{Versions: []int{1}})
+
+			Convey("Then ErrPreconditionFailed is returned", func() {
+				So(err, ShouldEqual, errs.ErrPreconditionFailed)
+			})
+		})
+	})
+}
+
+func TestCollectionOperations(t *testing.T) {
+	Convey("Given a store holding cache times", t, func() {
+		store := newTestStore()
+
+		Convey("When the release time of a collection is updated", func() {
+			changes, err := store.UpdateCollectionReleaseTime(ctx, "collection-1", &laterTime)
+
+			Convey("Then every cache time of the collection is updated and the changes are returned", func() {
+				So(err, ShouldBeNil)
+				So(changes, ShouldHaveLength, 2)
+				So(*changes[0].Current.ReleaseTime, ShouldEqual, laterTime)
+				cacheTime, _ := store.GetCacheTime(ctx, "c")
+				So(*cacheTime.ReleaseTime, ShouldEqual, laterTime)
+			})
+		})
+
+		Convey("When the cache times of a collection are deleted", func() {
+			changes, err := store.DeleteCollectionCacheTimes(ctx, "collection-1")
+
+			Convey("Then only the cache times of other collections remain and the deletions are returned", func() {
+				So(err, ShouldBeNil)
+				So(changes, ShouldHaveLength, 2)
+				So(changes[0].Action, ShouldEqual, models.CacheTimeDeleted)
+				cacheTimes, _, _ := store.GetCacheTimes(ctx, models.CacheTimesFilter{}, 0, 10)
+				So(ids(cacheTimes), ShouldResemble, []string{"b"})
+			})
+		})
+
+		Convey("When the cache times released before a time are deleted", func() {
+			count, err := store.DeleteCacheTimesReleasedBefore(ctx, laterTime)
+
+			Convey("Then only the cache times released later or never remain, and the deletion is in their history", func() {
+				So(err, ShouldBeNil)
+				So(count, ShouldEqual, 1)
+				cacheTimes, _, _ := store.GetCacheTimes(ctx, models.CacheTimesFilter{}, 0, 10)
+				So(ids(cacheTimes), ShouldResemble, []string{"b", "c"})
+				changes, _, _ := store.GetCacheTimeHistory(ctx, "a", 0, 10)
+				So(actions(changes), ShouldResemble, []string{models.CacheTimeDeleted, models.CacheTimeCreated})
+			})
+		})
+	})
+}
+
+func ids(cacheTimes []*models.CacheTime) []string {
+	result := make([]string, len(cacheTimes))
+	for i, cacheTime := range cacheTimes {
+		result[i] = cacheTime.ID
+	}
+	return result
+}
+
+func TestGetCacheTimeHistory(t *testing.T) {
+	Convey("Given a store holding cache times", t, func() {
+		store := newTestStore()
+		callerCtx := dprequest.SetCaller(ctx, "publisher@ons.gov.uk")
+
+		Convey("When a cache time is updated and then deleted", func() {
+			_, err := store.UpsertCacheTime(callerCtx, &models.CacheTime{ID: "a", Path: "/economy/a"}, models.Precondition{})
+			So(err, ShouldBeNil)
+			_, err = store.DeleteCacheTime(callerCtx, "a", models.Precondition{})
+			So(err, ShouldBeNil)
+
+			Convey("Then its history lists every change, most recent first", func() {
+				changes, totalCount, err := store.GetCacheTimeHistory(ctx, "a", 0, 10)
+				So(err, ShouldBeNil)
+				So(totalCount, ShouldEqual, 3)
+				So(actions(changes), ShouldResemble, []string{models.CacheTimeDeleted, models.CacheTimeUpdated, models.CacheTimeCreated})
+
+				So(changes[0].Previous.Path, ShouldEqual, "/economy/a")
+				So(changes[0].Previous.LastUpdatedBy, ShouldEqual, "publisher@ons.gov.uk")
+				So(changes[0].Current, ShouldBeNil)
+				So(changes[0].ChangedBy, ShouldEqual, "publisher@ons.gov.uk")
+				So(changes[1].Previous.CollectionID, ShouldEqual, "collection-1")
+				So(changes[1].Current.CollectionID, ShouldBeEmpty)
+				So(changes[2].ChangedBy, ShouldBeEmpty)
+			})
+
+			Convey("And a page of its history only returns that page along with the total count", func() {
+				changes, totalCount, err := store.GetCacheTimeHistory(ctx, "a", 1, 1)
+				So(err, ShouldBeNil)
+				So(totalCount, ShouldEqual, 3)
+				So(actions(changes), ShouldResemble, []string{models.CacheTimeUpdated})
+			})
+		})
+
+		Convey("When the cache times of a collection are changed", func() {
+			_, _ = store.UpdateCollectionReleaseTime(callerCtx, "collection-1", &laterTime)
+			_, _ = store.DeleteCollectionCacheTimes(callerCtx, "collection-1")
+
+			Convey("Then the history of each cache time in the collection records the changes", func() {
+				changes, _, _ := store.GetCacheTimeHistory(ctx, "c", 0, 10)
+				So(actions(changes), ShouldResemble, []string{models.CacheTimeDeleted, models.CacheTimeUpdated, models.CacheTimeCreated})
+				So(*changes[1].Current.ReleaseTime, ShouldEqual, laterTime)
+
+				others, _, _ := store.GetCacheTimeHistory(ctx, "b", 0, 10)
+				So(actions(others), ShouldResemble, []string{models.CacheTimeCreated})
+			})
+		})
+
+		Convey("When the history of a cache time with no changes is requested", func() {
+			changes, totalCount, err := store.GetCacheTimeHistory(ctx, "unknown", 0, 10)
+
+			Convey("Then an empty list is returned", func() {
+				So(err, ShouldBeNil)
+				So(totalCount, ShouldEqual, 0)
+				So(changes, ShouldBeEmpty)
+				So(changes, ShouldNotBeNil)
+			})
+		})
+	})
+}
+
+func TestReleases(t *testing.T) {
+	Convey("Given a recorded release", t, func() {
+		store := NewStore()
+		firedAt := time.Date(2024, time.January, 31, 9, 30, 0, 0, time.UTC)
+		release := &models.Release{ID: "a@2024-01-31T09:30:00Z", CacheTimeID: "a", ReleaseTime: firedAt, FiredAt: firedAt, FiredBy: "host-1"}
+		So(store.RecordRelease(ctx, release), ShouldBeNil)
+
+		Convey("When the same release is recorded again", func() {
+			err := store.RecordRelease(ctx, &models.Release{ID: release.ID, CacheTimeID: "a", FiredBy: "host-2"})
+
+			Convey("Then it is rejected", func() {
+				So(err, ShouldEqual, errs.ErrReleaseRecorded)
+			})
+		})
+
+		Convey("When the pending releases fired no later than the release are requested", func() {
+			releases, err := store.GetPendingReleases(ctx, firedAt)
+
+			Convey("Then the release is returned", func() {
+				So(err, ShouldBeNil)
+				So(releases, ShouldResemble, []*models.Release{release})
+			})
+
+			Convey("And only one of two instances can claim it", func() {
+				So(store.ClaimRelease(ctx, releases[0], "host-2", firedAt.Add(time.Minute)), ShouldBeNil)
+				So(store.ClaimRelease(ctx, releases[0], "host-3", firedAt.Add(time.Minute)), ShouldEqual, errs.ErrReleaseRecorded)
+
+				claimed, err := store.GetPendingReleases(ctx, firedAt.Add(time.Minute))
+				So(err, ShouldBeNil)
+				So(claimed, ShouldHaveLength, 1)
+				So(claimed[0].FiredBy, ShouldEqual, "host-2")
+			})
+		})
+
+		Convey("When the pending releases fired before the release are requested", func() {
+			releases, err := store.GetPendingReleases(ctx, firedAt.Add(-time.Second))
+
+			Convey("Then none is returned", func() {
+				So(err, ShouldBeNil)
+				So(releases, ShouldBeEmpty)
+			})
+		})
+
+		Convey("When the release is marked as sent", func() {
+			So(store.MarkReleaseSent(ctx, release.ID, firedAt.Add(time.Second)), ShouldBeNil)
+
+			Convey("Then it is no longer pending and cannot be claimed", func() {
+				releases, err := store.GetPendingReleases(ctx, firedAt.Add(time.Hour))
+				So(err, ShouldBeNil)
+				So(releases, ShouldBeEmpty)
+				So(store.ClaimRelease(ctx, release, "host-2", firedAt.Add(time.Minute)), ShouldEqual, errs.ErrReleaseRecorded)
+			})
+		})
+
+		Convey("When the latest release time is requested", func() {
+			So(store.RecordRelease(ctx, &models.Release{ID: "b", ReleaseTime: firedAt.Add(-time.Hour)}), ShouldBeNil)
+			latest, err := store.GetLatestReleaseTime(ctx)
+
+			Convey("Then the release time of the latest release is returned", func() {
+				So(err, ShouldBeNil)
+				So(*latest, ShouldEqual, firedAt)
+			})
+		})
+	})
+
+	Convey("Given a store without releases", t, func() {
+		latest, err := NewStore().GetLatestReleaseTime(ctx)
+
+		Convey("Then there is no latest release time", func() {
+			So(err, ShouldBeNil)
+			So(latest, ShouldBeNil)
+		})
+	})
+}
+
+func actions(changes []*models.CacheTimeChange) []string {
+	result := make([]string, len(changes))
+	for i, change := range changes {
+		result[i] = change.Action
+	}
+	return result
+}
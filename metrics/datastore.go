@@ -0,0 +1,155 @@
+package metrics
+
+import (
+	"context"
+	"time"
+
+	"github.com/ONSdigital/dp-legacy-cache-api/api"
+	"github.com/ONSdigital/dp-legacy-cache-api/models"
+	"github.com/ONSdigital/dp-legacy-cache-api/scheduler"
+)
+
+// DataStore wraps an api.DataStore, timing its operations and counting the ones that fail. A cache time not being
+// found is an expected outcome and is not counted as an error.
+type DataStore struct {
+	api.DataStore
+
+	metrics *Metrics
+}
+
+// NewDataStore creates a DataStore recording the operations of dataStore in m
+func NewDataStore(dataStore api.DataStore, m *Metrics) *DataStore {
+	return &DataStore{
+		DataStore: dataStore,
+		metrics:   m,
+	}
+}
+
+func (d *DataStore) observe(operation string, start time.Time, err error) {
+	d.metrics.observe(operation, start, err)
+}
+
+// GetCacheTime returns a cache time with its given id
+func (d *DataStore) GetCacheTime(ctx context.Context, id string) (cacheTime *models.CacheTime, err error) {
+	defer func(start time.Time) { d.observe("GetCacheTime", start, err) }(time.Now())
+	return d.DataStore.GetCacheTime(ctx, id)
+}
+
+// GetCacheTimes returns a page of the cache times matching the given filter and the total number of matches
+func (d *DataStore) GetCacheTimes(ctx context.Context, filter models.CacheTimesFilter, offset, limit int) (cacheTimes []*models.CacheTime, totalCount int, err error) {
+	defer func(start time.Time) { d.observe("GetCacheTimes", start, err) }(time.Now())
+	return d.DataStore.GetCacheTimes(ctx, filter, offset, limit)
+}
+
+// ExportCacheTimes calls export with every cache time matching the given filter
+func (d *DataStore) ExportCacheTimes(ctx context.Context, filter models.CacheTimesFilter, export func(*models.CacheTime) error) (err error) {
+	defer func(start time.Time) { d.observe("ExportCacheTimes", start, err) }(time.Now())
+	return d.DataStore.ExportCacheTimes(ctx, filter, export)
+}
+
+// GetCacheTimesReleasedBetween returns every cache time whose release time is between from and to
+func (d *DataStore) GetCacheTimesReleasedBetween(ctx context.Context, from, to time.Time) (cacheTimes []*models.CacheTime, err error) {
+	defer func(start time.Time) { d.observe("GetCacheTimesReleasedBetween", start, err) }(time.Now())
+	return d.DataStore.GetCacheTimesReleasedBetween(ctx, from, to)
+}
+
+// UpsertCacheTime adds or overrides an existing cache time
+func (d *DataStore) UpsertCacheTime(ctx context.Context, cacheTime *models.CacheTime, precondition models.Precondition) (change *models.CacheTimeChange, err error) {
+	defer func(start time.Time) { d.observe("UpsertCacheTime", start, err) }(time.Now())
+	return d.DataStore.UpsertCacheTime(ctx, cacheTime, precondition)
+}
+
+// UpsertCacheTimes adds or overrides the given cache times
+func (d *DataStore) UpsertCacheTimes(ctx context.Context, cacheTimes []*models.CacheTime) (results []models.UpsertResult, err error) {
+	defer func(start time.Time) { d.observe("UpsertCacheTimes", start, err) }(time.Now())
+	return d.DataStore.UpsertCacheTimes(ctx, cacheTimes)
+}
+
+// DeleteCacheTime removes the cache time with the given id
+func (d *DataStore) DeleteCacheTime(ctx context.Context, id string, precondition models.Precondition) (change *models.CacheTimeChange, err error) {
+	defer func(start time.Time) { d.observe("DeleteCacheTime", start, err) }(time.Now())
+	return d.DataStore.DeleteCacheTime(ctx, id, precondition)
+}
+
+// UpdateCollectionReleaseTime sets the release time of every cache time in the given collection
+func (d *DataStore) UpdateCollectionReleaseTime(ctx context.Context, collectionID string, releaseTime *time.Time) (changes []*models.CacheTimeChange, err error) {
+	defer func(start time.Time) { d.observe("UpdateCollectionReleaseTime", start, err) }(time.Now())
+	return d.DataStore.UpdateCollectionReleaseTime(ctx, collectionID, releaseTime)
+}
+
+// DeleteCollectionCacheTimes removes every cache time in the given collection
+func (d *DataStore) DeleteCollectionCacheTimes(ctx context.Context, collectionID string) (changes []*models.CacheTimeChange, err error) {
+	defer func(start time.Time) { d.observe("DeleteCollectionCacheTimes", start, err) }(time.Now())
+	return d.DataStore.DeleteCollectionCacheTimes(ctx, collectionID)
+}
+
+// GetCacheTimeHistory returns a page of the changes made to the cache time with the given id and the total number of
+// changes
+func (d *DataStore) GetCacheTimeHistory(ctx context.Context, id string, offset, limit int) (changes []*models.CacheTimeChange, totalCount int, err error) {
+	defer func(start time.Time) { d.observe("GetCacheTimeHistory", start, err) }(time.Now())
+	return d.DataStore.GetCacheTimeHistory(ctx, id, offset, limit)
+}
+
+// ReleaseStore is the part of a data store used by the release scheduler and the retention sweeper
+type ReleaseStore interface {
+	scheduler.Store
+	DeleteCacheTimesReleasedBefore(ctx context.Context, before time.Time) (int, error)
+}
+
+// ReleaseDataStore wraps a ReleaseStore, timing its operations and counting the ones that fail, in the same metrics as
+// DataStore
+type ReleaseDataStore struct {
+	ReleaseStore
+
+	metrics *Metrics
+}
+
+// NewReleaseDataStore creates a ReleaseDataStore recording the operations of store in m
+func NewReleaseDataStore(store ReleaseStore, m *Metrics) *ReleaseDataStore {
+	return &ReleaseDataStore{
+		ReleaseStore: store,
+		metrics:      m,
+	}
+}
+
+// GetCacheTimes returns a page of the cache times matching the given filter and the total number of matches
+func (d *ReleaseDataStore) GetCacheTimes(ctx context.Context, filter models.CacheTimesFilter, offset, limit int) (cacheTimes []*models.CacheTime, totalCount int, err error) {
+	defer func(start time.Time) { d.metrics.observe("GetCacheTimes", start, err) }(time.Now())
+	return d.ReleaseStore.GetCacheTimes(ctx, filter, offset, limit)
+}
+
+// RecordRelease records that a release is being fired
+func (d *ReleaseDataStore) RecordRelease(ctx context.Context, release *models.Release) (err error) {
+	defer func(start time.Time) { d.metrics.observe("RecordRelease", start, err) }(time.Now())
+	return d.ReleaseStore.RecordRelease(ctx, release)
+}
+
+// ClaimRelease records that a pending release is fired again
+func (d *ReleaseDataStore) ClaimRelease(ctx context.Context, release *models.Release, firedBy string, firedAt time.Time) (err error) {
+	defer func(start time.Time) { d.metrics.observe("ClaimRelease", start, err) }(time.Now())
+	return d.ReleaseStore.ClaimRelease(ctx, release, firedBy, firedAt)
+}
+
+// MarkReleaseSent records that a release was sent to every sink
+func (d *ReleaseDataStore) MarkReleaseSent(ctx context.Context, id string, sentAt time.Time) (err error) {
+	defer func(start time.Time) { d.metrics.observe("MarkReleaseSent", start, err) }(time.Now())
+	return d.ReleaseStore.MarkReleaseSent(ctx, id, sentAt)
+}
+
+// GetPendingReleases returns the releases not sent yet that were last fired no later than firedBefore
+func (d *ReleaseDataStore) GetPendingReleases(ctx context.Context, firedBefore time.Time) (releases []*models.Release, err error) {
+	defer func(start time.Time) { d.metrics.observe("GetPendingReleases", start, err) }(time.Now())
+	return d.ReleaseStore.GetPendingReleases(ctx, firedBefore)
+}
+
+// GetLatestReleaseTime returns the latest release time recorded
+func (d *ReleaseDataStore) GetLatestReleaseTime(ctx context.Context) (latest *time.Time, err error) {
+	defer func(start time.Time) { d.metrics.observe("GetLatestReleaseTime", start, err) }(time.Now())
+	return d.ReleaseStore.GetLatestReleaseTime(ctx)
+}
+
+// DeleteCacheTimesReleasedBefore removes every cache time released before the given time
+func (d *ReleaseDataStore) DeleteCacheTimesReleasedBefore(ctx context.Context, before time.Time) (deleted int, err error) {
+	defer func(start time.Time) { d.metrics.observe("DeleteCacheTimesReleasedBefore", start, err) }(time.Now())
+	return d.ReleaseStore.DeleteCacheTimesReleasedBefore(ctx, before)
+}
@@ -0,0 +1,167 @@
+package metrics
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	errs "github.com/ONSdigital/dp-legacy-cache-api/apierrors"
+	"github.com/ONSdigital/dp-legacy-cache-api/cache"
+	"github.com/gorilla/mux"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const namespace = "dp_legacy_cache_api"
+
+// Metrics holds the Prometheus collectors of the service in a registry of its own, so that more than one instance can
+// exist in the same process
+type Metrics struct {
+	registry          *prometheus.Registry
+	requests          *prometheus.CounterVec
+	requestDuration   *prometheus.HistogramVec
+	operationDuration *prometheus.HistogramVec
+	operationErrors   *prometheus.CounterVec
+}
+
+// New creates a Metrics with the HTTP request and data store collectors registered, along with the standard Go runtime
+// and process collectors
+func New() *Metrics {
+	m := &Metrics{
+		registry: prometheus.NewRegistry(),
+		requests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "http_requests_total",
+			Help:      "Number of HTTP requests handled, by route, method and status code.",
+		}, []string{"route", "method", "code"}),
+		requestDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "http_request_duration_seconds",
+			Help:      "Time taken to handle HTTP requests, by route, method and status code.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"route", "method", "code"}),
+		operationDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "datastore_operation_duration_seconds",
+			Help:      "Time taken by data store operations, by operation.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"operation"}),
+		operationErrors: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "datastore_operation_errors_total",
+			Help:      "Number of data store operations that failed, by operation.",
+		}, []string{"operation"}),
+	}
+
+	m.registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		m.requests,
+		m.requestDuration,
+		m.operationDuration,
+		m.operationErrors,
+	)
+	return m
+}
+
+// RegisterCache registers the hit and miss counters and the number of entries of a read cache, read from stats
+// whenever the metrics are collected
+func (m *Metrics) RegisterCache(stats func() cache.Stats) {
+	m.registry.MustRegister(
+		prometheus.NewCounterFunc(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "cache_hits_total",
+			Help:      "Number of cache time lookups served from the read cache.",
+		}, func() float64 { return float64(stats().Hits) }),
+		prometheus.NewCounterFunc(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "cache_misses_total",
+			Help:      "Number of cache time lookups not found in the read cache.",
+		}, func() float64 { return float64(stats().Misses) }),
+		prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Name:      "cache_entries",
+			Help:      "Number of entries held in the read cache.",
+		}, func() float64 { return float64(stats().Entries) }),
+	)
+}
+
+// observe records the duration of a data store operation started at start and whether it failed. A cache time not
+// being found or not matching a precondition, and a release already recorded by another instance, are expected outcomes
+// rather than failures.
+func (m *Metrics) observe(operation string, start time.Time, err error) {
+	m.operationDuration.WithLabelValues(operation).Observe(time.Since(start).Seconds())
+	if err != nil && !errors.Is(err, errs.ErrCacheTimeNotFound) && !errors.Is(err, errs.ErrPreconditionFailed) &&
+		!errors.Is(err, errs.ErrReleaseRecorded) {
+		m.operationErrors.WithLabelValues(operation).Inc()
+	}
+}
+
+// Handler returns the handler serving the collected metrics in the Prometheus exposition format
+func (m *Metrics) Handler() http.Handler {
+	return promhttp.HandlerFor(m.registry, promhttp.HandlerOpts{})
+}
+
+// Middleware is a router middleware counting and timing requests. Requests are labelled with the path template of the
+// matched route rather than the request path, to keep the number of label values bounded. The router does not run its
+// middleware for requests matching no route, so its not found and method not allowed handlers must be wrapped in
+// Middleware too for those requests to be counted, as "unmatched".
+func (m *Metrics) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		start := time.Now()
+		rec := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+
+		next.ServeHTTP(rec, req)
+
+		labels := prometheus.Labels{
+			"route":  routeTemplate(req),
+			"method": req.Method,
+			"code":   strconv.Itoa(rec.status),
+		}
+		m.requests.With(labels).Inc()
+		m.requestDuration.With(labels).Observe(time.Since(start).Seconds())
+	})
+}
+
+func routeTemplate(req *http.Request) string {
+	route := mux.CurrentRoute(req)
+	if route == nil {
+		return "unmatched"
+	}
+	template, err := route.GetPathTemplate()
+	if err != nil {
+		return "unmatched"
+	}
+	return template
+}
+
+// statusRecorder captures the status code written by a handler
+type statusRecorder struct {
+	http.ResponseWriter
+	status      int
+	wroteHeader bool
+}
+
+func (r *statusRecorder) WriteHeader(status int) {
+	if !r.wroteHeader {
+		r.status = status
+		r.wroteHeader = true
+	}
+	r.ResponseWriter.WriteHeader(status)
+}
+
+func (r *statusRecorder) Write(b []byte) (int, error) {
+	r.wroteHeader = true
+	return r.ResponseWriter.Write(b)
+}
+
+// Flush sends any buffered data to the client, if the wrapped writer supports it, so that streamed responses are not
+// held back by the middleware
+func (r *statusRecorder) Flush() {
+	if flusher, ok := r.ResponseWriter.(http.Flusher); ok {
+		r.wroteHeader = true
+		flusher.Flush()
+	}
+}
@@ -0,0 +1,245 @@
+package metrics
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/ONSdigital/dp-legacy-cache-api/api/mock"
+	errs "github.com/ONSdigital/dp-legacy-cache-api/apierrors"
+	"github.com/ONSdigital/dp-legacy-cache-api/cache"
+	"github.com/ONSdigital/dp-legacy-cache-api/models"
+	"github.com/gorilla/mux"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+const testID = "374690207ce40c08d0c3b1a1869ef66e"
+
+var ctx = context.Background()
+
+// scrape returns the metrics served by the handler of m in the Prometheus exposition format
+func scrape(m *Metrics) string {
+	w := httptest.NewRecorder()
+	m.Handler().ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/metrics", http.NoBody))
+	So(w.Code, ShouldEqual, http.StatusOK)
+	return w.Body.String()
+}
+
+func TestMiddleware(t *testing.T) {
+	Convey("Given a router using the metrics middleware", t, func() {
+		m := New()
+		router := mux.NewRouter()
+		router.Use(m.Middleware)
+		router.HandleFunc("/v1/cache-times/{id}", func(w http.ResponseWriter, req *http.Request) {
+			if mux.Vars(req)["id"] == testID {
+				w.WriteHeader(http.StatusOK)
+				return
+			}
+			w.WriteHeader(http.StatusNotFound)
+		}).Methods(http.MethodGet)
+
+		Convey("When requests are made to a route", func() {
+			for _, id := range []string{testID, testID, "unknown"} {
+				router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/v1/cache-times/"+id, http.NoBody))
+			}
+
+			Convey("Then the requests are counted and timed by route template and status code", func() {
+				body := scrape(m)
+				So(body, ShouldContainSubstring, `dp_legacy_cache_api_http_requests_total{code="200",method="GET",route="/v1/cache-times/{id}"} 2`)
+				So(body, ShouldContainSubstring, `dp_legacy_cache_api_http_requests_total{code="404",method="GET",route="/v1/cache-times/{id}"} 1`)
+				So(body, ShouldContainSubstring, `dp_legacy_cache_api_http_request_duration_seconds_count{code="200",method="GET",route="/v1/cache-times/{id}"} 2`)
+			})
+		})
+	})
+
+	Convey("Given a handler that writes a body without setting a status code", t, func() {
+		m := New()
+		router := mux.NewRouter()
+		router.Use(m.Middleware)
+		router.HandleFunc("/health", func(w http.ResponseWriter, req *http.Request) {
+			w.Write([]byte("{}")) //nolint:errcheck // test handler
+		})
+
+		Convey("When a request is made", func() {
+			router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/health", http.NoBody))
+
+			Convey("Then the request is counted with a 200 status code", func() {
+				So(scrape(m), ShouldContainSubstring, `dp_legacy_cache_api_http_requests_total{code="200",method="GET",route="/health"} 1`)
+			})
+		})
+	})
+
+	Convey("Given a router counting the requests matching no route", t, func() {
+		m := New()
+		router := mux.NewRouter()
+		router.Use(m.Middleware)
+		router.NotFoundHandler = m.Middleware(http.NotFoundHandler())
+		router.MethodNotAllowedHandler = m.Middleware(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+		}))
+		router.HandleFunc("/health", func(w http.ResponseWriter, req *http.Request) {}).Methods(http.MethodGet)
+
+		Convey("When requests are made to an unknown path and with a method the route does not allow", func() {
+			router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/unknown", http.NoBody))
+			router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodPost, "/health", http.NoBody))
+
+			Convey("Then the requests are counted as unmatched rather than by request path", func() {
+				body := scrape(m)
+				So(body, ShouldContainSubstring, `dp_legacy_cache_api_http_requests_total{code="404",method="GET",route="unmatched"} 1`)
+				So(body, ShouldContainSubstring, `dp_legacy_cache_api_http_requests_total{code="405",method="POST",route="unmatched"} 1`)
+				So(body, ShouldNotContainSubstring, `route="/unknown"`)
+			})
+		})
+	})
+
+	Convey("Given a handler that flushes a streamed response", t, func() {
+		m := New()
+		router := mux.NewRouter()
+		router.Use(m.Middleware)
+		router.HandleFunc("/export", func(w http.ResponseWriter, req *http.Request) {
+			w.Write([]byte("{}\n")) //nolint:errcheck // test handler
+			flusher, ok := w.(http.Flusher)
+			So(ok, ShouldBeTrue)
+			flusher.Flush()
+		})
+
+		Convey("When a request is made", func() {
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/export", http.NoBody))
+
+			Convey("Then the flush reaches the underlying writer", func() {
+				So(w.Flushed, ShouldBeTrue)
+				So(scrape(m), ShouldContainSubstring, `dp_legacy_cache_api_http_requests_total{code="200",method="GET",route="/export"} 1`)
+			})
+		})
+	})
+}
+
+func TestRegisterCache(t *testing.T) {
+	Convey("Given metrics with the counters of a read cache registered", t, func() {
+		m := New()
+		m.RegisterCache(func() cache.Stats { return cache.Stats{Hits: 3, Misses: 2, Entries: 1} })
+
+		Convey("When the metrics are scraped", func() {
+			body := scrape(m)
+
+			Convey("Then the cache hits, misses and entries are reported", func() {
+				So(body, ShouldContainSubstring, "dp_legacy_cache_api_cache_hits_total 3")
+				So(body, ShouldContainSubstring, "dp_legacy_cache_api_cache_misses_total 2")
+				So(body, ShouldContainSubstring, "dp_legacy_cache_api_cache_entries 1")
+			})
+		})
+	})
+}
+
+func TestDataStore(t *testing.T) {
+	Convey("Given a data store wrapped with metrics", t, func() {
+		m := New()
+		dataStoreMock := &mock.DataStoreMock{
+			GetCacheTimeFunc: func(ctx context.Context, id string) (*models.CacheTime, error) {
+				if id == testID {
+					return &models.CacheTime{ID: testID}, nil
+				}
+				return nil, errs.ErrCacheTimeNotFound
+			},
+			DeleteCacheTimeFunc: func(ctx context.Context, id string, precondition models.Precondition) (*models.CacheTimeChange, error) {
+				return nil, errs.ErrDataStore
+			},
+		}
+		dataStore := NewDataStore(dataStoreMock, m)
+
+		Convey("When cache times are found and not found", func() {
+			cacheTime, err := dataStore.GetCacheTime(ctx, testID)
+			So(err, ShouldBeNil)
+			So(cacheTime.ID, ShouldEqual, testID)
+
+			_, err = dataStore.GetCacheTime(ctx, "unknown")
+			So(errors.Is(err, errs.ErrCacheTimeNotFound), ShouldBeTrue)
+
+			Convey("Then both operations are timed and none are counted as errors", func() {
+				body := scrape(m)
+				So(body, ShouldContainSubstring, `dp_legacy_cache_api_datastore_operation_duration_seconds_count{operation="GetCacheTime"} 2`)
+				So(body, ShouldNotContainSubstring, `dp_legacy_cache_api_datastore_operation_errors_total{operation="GetCacheTime"}`)
+			})
+		})
+
+		Convey("When an operation fails", func() {
+			_, err := dataStore.DeleteCacheTime(ctx, testID, models.Precondition{})
+
+			Convey("Then the error is returned unchanged and counted", func() {
+				So(err, ShouldEqual, errs.ErrDataStore)
+				So(len(dataStoreMock.DeleteCacheTimeCalls()), ShouldEqual, 1)
+				body := scrape(m)
+				So(body, ShouldContainSubstring, `dp_legacy_cache_api_datastore_operation_duration_seconds_count{operation="DeleteCacheTime"} 1`)
+				So(body, ShouldContainSubstring, `dp_legacy_cache_api_datastore_operation_errors_total{operation="DeleteCacheTime"} 1`)
+			})
+		})
+	})
+}
+
+// releaseStore is a ReleaseStore whose every operation returns err
+type releaseStore struct {
+	err error
+}
+
+func (s *releaseStore) GetCacheTimes(_ context.Context, _ models.CacheTimesFilter, _, _ int) ([]*models.CacheTime, int, error) {
+	return nil, 0, s.err
+}
+
+func (s *releaseStore) RecordRelease(_ context.Context, _ *models.Release) error {
+	return s.err
+}
+
+func (s *releaseStore) ClaimRelease(_ context.Context, _ *models.Release, _ string, _ time.Time) error {
+	return s.err
+}
+
+func (s *releaseStore) MarkReleaseSent(_ context.Context, _ string, _ time.Time) error {
+	return s.err
+}
+
+func (s *releaseStore) GetPendingReleases(_ context.Context, _ time.Time) ([]*models.Release, error) {
+	return nil, s.err
+}
+
+func (s *releaseStore) GetLatestReleaseTime(_ context.Context) (*time.Time, error) {
+	return nil, s.err
+}
+
+func (s *releaseStore) DeleteCacheTimesReleasedBefore(_ context.Context, _ time.Time) (int, error) {
+	return 0, s.err
+}
+
+func TestReleaseDataStore(t *testing.T) {
+	Convey("Given a release store wrapped with metrics", t, func() {
+		m := New()
+		store := &releaseStore{}
+		releaseDataStore := NewReleaseDataStore(store, m)
+
+		Convey("When a release is already recorded by another instance", func() {
+			store.err = errs.ErrReleaseRecorded
+			err := releaseDataStore.RecordRelease(ctx, &models.Release{})
+
+			Convey("Then the operation is timed and not counted as an error", func() {
+				So(err, ShouldEqual, errs.ErrReleaseRecorded)
+				body := scrape(m)
+				So(body, ShouldContainSubstring, `dp_legacy_cache_api_datastore_operation_duration_seconds_count{operation="RecordRelease"} 1`)
+				So(body, ShouldNotContainSubstring, `dp_legacy_cache_api_datastore_operation_errors_total{operation="RecordRelease"}`)
+			})
+		})
+
+		Convey("When a retention sweep fails", func() {
+			store.err = errs.ErrDataStore
+			_, err := releaseDataStore.DeleteCacheTimesReleasedBefore(ctx, time.Now())
+
+			Convey("Then the error is returned unchanged and counted", func() {
+				So(err, ShouldEqual, errs.ErrDataStore)
+				body := scrape(m)
+				So(body, ShouldContainSubstring, `dp_legacy_cache_api_datastore_operation_errors_total{operation="DeleteCacheTimesReleasedBefore"} 1`)
+			})
+		})
+	})
+}
@@ -0,0 +1,52 @@
+package models
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// CacheControlPolicy holds the max-age values used to turn a cache time into cache control headers
+type CacheControlPolicy struct {
+	DefaultMaxAge time.Duration
+	MinimumMaxAge time.Duration
+	MaximumMaxAge time.Duration
+}
+
+// CacheControl holds the Cache-Control and Expires header values computed for a page
+type CacheControl struct {
+	CacheControl string `json:"cache_control"`
+	Expires      string `json:"expires"`
+	MaxAge       int    `json:"max_age"`
+}
+
+// MaxAge returns how long a page can be cached for at the given time. A page with an upcoming release can be cached
+// until its release, bounded by the minimum and maximum max-age. Any other page, including one without a cache time,
+// is cached for the default max-age.
+func (p CacheControlPolicy) MaxAge(cacheTime *CacheTime, now time.Time) time.Duration {
+	if cacheTime == nil || cacheTime.ReleaseTime == nil || !cacheTime.ReleaseTime.After(now) {
+		return p.DefaultMaxAge
+	}
+
+	maxAge := cacheTime.ReleaseTime.Sub(now)
+	if maxAge < p.MinimumMaxAge {
+		return p.MinimumMaxAge
+	}
+	if maxAge > p.MaximumMaxAge {
+		return p.MaximumMaxAge
+	}
+	return maxAge
+}
+
+// CacheControl returns the cache control headers for a page at the given time. The max-age is rounded down to whole
+// seconds. A release due sooner than the minimum max-age is still cached for the minimum, so such a page can be served
+// for up to that long after its release.
+func (p CacheControlPolicy) CacheControl(cacheTime *CacheTime, now time.Time) *CacheControl {
+	maxAge := int(p.MaxAge(cacheTime, now) / time.Second)
+
+	return &CacheControl{
+		CacheControl: fmt.Sprintf("public, max-age=%d", maxAge),
+		Expires:      now.Add(time.Duration(maxAge) * time.Second).UTC().Format(http.TimeFormat),
+		MaxAge:       maxAge,
+	}
+}
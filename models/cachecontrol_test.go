@@ -0,0 +1,75 @@
+package models
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestCacheControlPolicyMaxAge(t *testing.T) {
+	policy := CacheControlPolicy{
+		DefaultMaxAge: 15 * time.Minute,
+		MinimumMaxAge: 10 * time.Second,
+		MaximumMaxAge: 24 * time.Hour,
+	}
+	now := time.Date(2024, time.January, 31, 9, 30, 0, 0, time.UTC)
+	releasedAt := func(d time.Duration) *CacheTime {
+		releaseTime := now.Add(d)
+		return &CacheTime{ID: "id", Path: "/path", ReleaseTime: &releaseTime}
+	}
+
+	Convey("Given a cache control policy", t, func() {
+		Convey("Then a page without a cache time gets the default max-age", func() {
+			So(policy.MaxAge(nil, now), ShouldEqual, 15*time.Minute)
+		})
+
+		Convey("Then a page without a release time gets the default max-age", func() {
+			So(policy.MaxAge(&CacheTime{ID: "id", Path: "/path"}, now), ShouldEqual, 15*time.Minute)
+		})
+
+		Convey("Then a page released in the past gets the default max-age", func() {
+			So(policy.MaxAge(releasedAt(-time.Hour), now), ShouldEqual, 15*time.Minute)
+			So(policy.MaxAge(releasedAt(0), now), ShouldEqual, 15*time.Minute)
+		})
+
+		Convey("Then a page with an upcoming release is cached until its release", func() {
+			So(policy.MaxAge(releasedAt(2*time.Minute), now), ShouldEqual, 2*time.Minute)
+			So(policy.MaxAge(releasedAt(3*time.Hour), now), ShouldEqual, 3*time.Hour)
+		})
+
+		Convey("Then a page released very soon gets the minimum max-age", func() {
+			So(policy.MaxAge(releasedAt(time.Second), now), ShouldEqual, 10*time.Second)
+		})
+
+		Convey("Then a page released far in the future gets the maximum max-age", func() {
+			So(policy.MaxAge(releasedAt(30*24*time.Hour), now), ShouldEqual, 24*time.Hour)
+		})
+	})
+}
+
+func TestCacheControlPolicyCacheControl(t *testing.T) {
+	policy := CacheControlPolicy{
+		DefaultMaxAge: 15 * time.Minute,
+		MinimumMaxAge: 10 * time.Second,
+		MaximumMaxAge: 24 * time.Hour,
+	}
+	now := time.Date(2024, time.January, 31, 9, 30, 0, 0, time.UTC)
+
+	Convey("Given a page released in a fraction of a second over two minutes", t, func() {
+		releaseTime := now.Add(2*time.Minute + 500*time.Millisecond)
+		cacheTime := &CacheTime{ID: "id", Path: "/path", ReleaseTime: &releaseTime}
+
+		Convey("When the cache control headers are computed", func() {
+			cacheControl := policy.CacheControl(cacheTime, now)
+
+			Convey("Then the max-age is rounded down to whole seconds", func() {
+				So(cacheControl, ShouldResemble, &CacheControl{
+					CacheControl: "public, max-age=120",
+					Expires:      "Wed, 31 Jan 2024 09:32:00 GMT",
+					MaxAge:       120,
+				})
+			})
+		})
+	})
+}
@@ -3,8 +3,85 @@ package models
 import "time"
 
 type CacheTime struct {
-	ID           string     `bson:"_id" json:"_id"`                                         // MD5 of the path
-	Path         string     `bson:"path" json:"path"`                                       // Path for which caching is set
-	CollectionID string     `bson:"collection_id,omitempty" json:"collection_id,omitempty"` // Collection ID - used for grouping and filtering of cache-time objects.
-	ReleaseTime  *time.Time `bson:"release_time,omitempty" json:"release_time,omitempty"`   // Release time in ISO-8601 format
+	ID            string     `bson:"_id" json:"_id"`                                             // MD5 of the path
+	Path          string     `bson:"path" json:"path"`                                           // Path for which caching is set
+	CollectionID  string     `bson:"collection_id,omitempty" json:"collection_id,omitempty"`     // Collection ID - used for grouping and filtering of cache-time objects.
+	ReleaseTime   *time.Time `bson:"release_time,omitempty" json:"release_time,omitempty"`       // Release time in ISO-8601 format
+	CreatedAt     *time.Time `bson:"created_at,omitempty" json:"created_at,omitempty"`           // Set by the server when the cache time is created
+	LastUpdated   *time.Time `bson:"last_updated,omitempty" json:"last_updated,omitempty"`       // Set by the server whenever the cache time changes
+	LastUpdatedBy string     `bson:"last_updated_by,omitempty" json:"last_updated_by,omitempty"` // Identity of the caller who last changed the cache time
+	Version       int        `bson:"version,omitempty" json:"version,omitempty"`                 // Incremented by the server whenever the cache time changes
+}
+
+// StampCacheTime returns a copy of cacheTime carrying the metadata of a change made by changedBy at changedAt. The
+// creation time and version are carried on from previous, the value being replaced, unless the cache time is new.
+func StampCacheTime(cacheTime, previous *CacheTime, changedBy string, changedAt time.Time) *CacheTime {
+	stamped := *cacheTime
+	if previous == nil {
+		stamped.CreatedAt = &changedAt
+		stamped.Version = 1
+	} else {
+		stamped.CreatedAt = previous.CreatedAt
+		stamped.Version = previous.Version + 1
+	}
+	stamped.LastUpdated = &changedAt
+	stamped.LastUpdatedBy = changedBy
+	return &stamped
+}
+
+// CacheTimesList is a paginated list of cache times
+type CacheTimesList struct {
+	Items      []*CacheTime `json:"items"`
+	Count      int          `json:"count"`
+	Offset     int          `json:"offset"`
+	Limit      int          `json:"limit"`
+	TotalCount int          `json:"total_count"`
+}
+
+// CacheTimesFilter holds the optional criteria used to filter a list of cache times
+type CacheTimesFilter struct {
+	CollectionID      string
+	PathPrefix        string
+	ReleaseTimeBefore *time.Time
+	ReleaseTimeAfter  *time.Time
+}
+
+// CollectionReleaseTime is the request body used to reschedule every cache time in a collection
+type CollectionReleaseTime struct {
+	ReleaseTime *time.Time `json:"release_time"`
+}
+
+// BulkOperationResult reports the number of cache times affected by a bulk operation
+type BulkOperationResult struct {
+	Count int `json:"count"`
+}
+
+// Possible statuses of an item in a batch upsert
+const (
+	BatchItemCreated = "created"
+	BatchItemUpdated = "updated"
+	BatchItemInvalid = "invalid"
+	BatchItemFailed  = "failed"
+)
+
+// BatchResult reports the outcome of a batch upsert, item by item and in total
+type BatchResult struct {
+	Created int               `json:"created"`
+	Updated int               `json:"updated"`
+	Invalid int               `json:"invalid"`
+	Failed  int               `json:"failed"`
+	Items   []BatchItemResult `json:"items"`
+}
+
+// UpsertResult is the outcome of storing a single cache time of a batch
+type UpsertResult struct {
+	Change *CacheTimeChange // Change made to the cache time, when it was stored
+	Err    error            // Error that prevented the cache time from being stored, such as another cache time having its path
+}
+
+// BatchItemResult reports the outcome of a single item of a batch upsert
+type BatchItemResult struct {
+	ID     string `json:"_id,omitempty"`
+	Status string `json:"status"`
+	Error  string `json:"error,omitempty"`
 }
@@ -0,0 +1,43 @@
+package models
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestStampCacheTime(t *testing.T) {
+	Convey("Given a cache time sent by a client", t, func() {
+		createdAt := time.Date(2024, time.January, 1, 9, 0, 0, 0, time.UTC)
+		changedAt := createdAt.Add(time.Hour)
+		cacheTime := &CacheTime{ID: "a", Path: "/economy/a"}
+
+		Convey("When it is stamped as a new cache time", func() {
+			stamped := StampCacheTime(cacheTime, nil, "publisher@ons.gov.uk", changedAt)
+
+			Convey("Then it is created and last updated at the time of the change", func() {
+				So(*stamped.CreatedAt, ShouldEqual, changedAt)
+				So(*stamped.LastUpdated, ShouldEqual, changedAt)
+				So(stamped.LastUpdatedBy, ShouldEqual, "publisher@ons.gov.uk")
+				So(stamped.Version, ShouldEqual, 1)
+			})
+
+			Convey("And the cache time sent is left untouched", func() {
+				So(cacheTime, ShouldResemble, &CacheTime{ID: "a", Path: "/economy/a"})
+			})
+		})
+
+		Convey("When it is stamped as replacing an existing cache time", func() {
+			previous := &CacheTime{ID: "a", Path: "/economy/a", CreatedAt: &createdAt, LastUpdated: &createdAt, LastUpdatedBy: "someone", Version: 4}
+			stamped := StampCacheTime(cacheTime, previous, "publisher@ons.gov.uk", changedAt)
+
+			Convey("Then it keeps the creation time of the existing cache time and moves on to its next version", func() {
+				So(*stamped.CreatedAt, ShouldEqual, createdAt)
+				So(stamped.Version, ShouldEqual, 5)
+				So(*stamped.LastUpdated, ShouldEqual, changedAt)
+				So(stamped.LastUpdatedBy, ShouldEqual, "publisher@ons.gov.uk")
+			})
+		})
+	})
+}
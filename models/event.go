@@ -0,0 +1,55 @@
+package models
+
+import "time"
+
+// Types of the events published about cache times
+const (
+	CacheTimeChangedEventType  = "cache-time-changed"
+	CacheTimeReleasedEventType = "cache-time-released"
+)
+
+// CacheTimeReleased is the action of the event published when the release time of a cache time is reached
+const CacheTimeReleased = "released"
+
+// CacheTimeEvent tells downstream caches that the release time of a page may have changed, or has been reached
+type CacheTimeEvent struct {
+	Type                string     `json:"type"`
+	Action              string     `json:"action"` // One of created, updated, deleted or released
+	CacheTimeID         string     `json:"cache_time_id"`
+	Path                string     `json:"path"`
+	PreviousReleaseTime *time.Time `json:"previous_release_time,omitempty"` // Release time before the change, if any
+	ReleaseTime         *time.Time `json:"release_time,omitempty"`          // Release time after the change, if any
+	OccurredAt          time.Time  `json:"occurred_at"`                     // Time of the change, or of the release
+}
+
+// NewCacheTimeChangedEvent creates the event announcing a change made to a cache time
+func NewCacheTimeChangedEvent(change *CacheTimeChange) *CacheTimeEvent {
+	event := &CacheTimeEvent{
+		Type:        CacheTimeChangedEventType,
+		Action:      change.Action,
+		CacheTimeID: change.CacheTimeID,
+		OccurredAt:  change.ChangedAt,
+	}
+	if change.Previous != nil {
+		event.Path = change.Previous.Path
+		event.PreviousReleaseTime = change.Previous.ReleaseTime
+	}
+	if change.Current != nil {
+		event.Path = change.Current.Path
+		event.ReleaseTime = change.Current.ReleaseTime
+	}
+	return event
+}
+
+// NewCacheTimeReleasedEvent creates the event announcing that the release time of a cache time has been reached
+func NewCacheTimeReleasedEvent(release *Release) *CacheTimeEvent {
+	releaseTime := release.ReleaseTime
+	return &CacheTimeEvent{
+		Type:        CacheTimeReleasedEventType,
+		Action:      CacheTimeReleased,
+		CacheTimeID: release.CacheTimeID,
+		Path:        release.Path,
+		ReleaseTime: &releaseTime,
+		OccurredAt:  release.FiredAt,
+	}
+}
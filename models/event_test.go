@@ -0,0 +1,67 @@
+package models
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestNewCacheTimeChangedEvent(t *testing.T) {
+	Convey("Given a cache time whose release time is moved", t, func() {
+		changedAt := time.Date(2024, time.January, 31, 1, 23, 45, 0, time.UTC)
+		before, after := changedAt.Add(time.Hour), changedAt.Add(2*time.Hour)
+		previous := &CacheTime{ID: "a", Path: "/economy/a", ReleaseTime: &before}
+		current := &CacheTime{ID: "a", Path: "/economy/a", ReleaseTime: &after}
+
+		Convey("When the event of the update is created", func() {
+			event := NewCacheTimeChangedEvent(NewCacheTimeChange(previous, current, "publisher@ons.gov.uk", changedAt))
+
+			Convey("Then it carries both release times", func() {
+				So(event, ShouldResemble, &CacheTimeEvent{
+					Type:                CacheTimeChangedEventType,
+					Action:              CacheTimeUpdated,
+					CacheTimeID:         "a",
+					Path:                "/economy/a",
+					PreviousReleaseTime: &before,
+					ReleaseTime:         &after,
+					OccurredAt:          changedAt,
+				})
+			})
+		})
+
+		Convey("When the event of the deletion is created", func() {
+			event := NewCacheTimeChangedEvent(NewCacheTimeChange(previous, nil, "publisher@ons.gov.uk", changedAt))
+
+			Convey("Then it carries the path and the release time that was removed", func() {
+				So(event.Action, ShouldEqual, CacheTimeDeleted)
+				So(event.Path, ShouldEqual, "/economy/a")
+				So(event.PreviousReleaseTime, ShouldEqual, &before)
+				So(event.ReleaseTime, ShouldBeNil)
+			})
+		})
+	})
+}
+
+func TestNewCacheTimeReleasedEvent(t *testing.T) {
+	Convey("Given the release of a cache time", t, func() {
+		releaseTime := time.Date(2024, time.January, 31, 9, 30, 0, 0, time.UTC)
+		firedAt := releaseTime.Add(2 * time.Second)
+		release := NewRelease(&CacheTime{ID: "a", Path: "/economy/a", ReleaseTime: &releaseTime}, "host-1", firedAt)
+
+		Convey("When its event is created", func() {
+			event := NewCacheTimeReleasedEvent(release)
+
+			Convey("Then it carries the release time reached and the time it was fired", func() {
+				So(event, ShouldResemble, &CacheTimeEvent{
+					Type:        CacheTimeReleasedEventType,
+					Action:      CacheTimeReleased,
+					CacheTimeID: "a",
+					Path:        "/economy/a",
+					ReleaseTime: &releaseTime,
+					OccurredAt:  firedAt,
+				})
+			})
+		})
+	})
+}
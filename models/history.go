@@ -0,0 +1,53 @@
+package models
+
+import "time"
+
+// Changes that can be made to a cache time
+const (
+	CacheTimeCreated = "created"
+	CacheTimeUpdated = "updated"
+	CacheTimeDeleted = "deleted"
+)
+
+// CacheTimeChange is an entry in the history of a cache time, recording its value before and after a single change
+type CacheTimeChange struct {
+	CacheTimeID string     `bson:"cache_time_id" json:"cache_time_id"`
+	Action      string     `bson:"action" json:"action"`                             // One of created, updated or deleted
+	Previous    *CacheTime `bson:"previous,omitempty" json:"previous,omitempty"`     // Value before the change, unless created
+	Current     *CacheTime `bson:"current,omitempty" json:"current,omitempty"`       // Value after the change, unless deleted
+	ChangedBy   string     `bson:"changed_by,omitempty" json:"changed_by,omitempty"` // Identity of the caller who made the change
+	ChangedAt   time.Time  `bson:"changed_at" json:"changed_at"`                     // Time of the change in ISO-8601 format
+}
+
+// CacheTimeHistory is a paginated list of the changes made to a cache time, most recent first
+type CacheTimeHistory struct {
+	Items      []*CacheTimeChange `json:"items"`
+	Count      int                `json:"count"`
+	Offset     int                `json:"offset"`
+	Limit      int                `json:"limit"`
+	TotalCount int                `json:"total_count"`
+}
+
+// NewCacheTimeChange creates the history entry of a change from previous to current, either of which is nil when the
+// cache time is created or deleted
+func NewCacheTimeChange(previous, current *CacheTime, changedBy string, changedAt time.Time) *CacheTimeChange {
+	change := &CacheTimeChange{
+		Previous:  previous,
+		Current:   current,
+		ChangedBy: changedBy,
+		ChangedAt: changedAt,
+	}
+
+	switch {
+	case previous == nil:
+		change.Action = CacheTimeCreated
+		change.CacheTimeID = current.ID
+	case current == nil:
+		change.Action = CacheTimeDeleted
+		change.CacheTimeID = previous.ID
+	default:
+		change.Action = CacheTimeUpdated
+		change.CacheTimeID = current.ID
+	}
+	return change
+}
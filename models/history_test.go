@@ -0,0 +1,52 @@
+package models
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestNewCacheTimeChange(t *testing.T) {
+	Convey("Given the value of a cache time before and after a change", t, func() {
+		changedAt := time.Date(2024, time.January, 31, 1, 23, 45, 0, time.UTC)
+		before := &CacheTime{ID: "a", Path: "/economy/a"}
+		after := &CacheTime{ID: "a", Path: "/economy/a", CollectionID: "collection-1"}
+
+		Convey("When there is no previous value", func() {
+			change := NewCacheTimeChange(nil, after, "publisher@ons.gov.uk", changedAt)
+
+			Convey("Then the change is recorded as a creation", func() {
+				So(change, ShouldResemble, &CacheTimeChange{
+					CacheTimeID: "a",
+					Action:      CacheTimeCreated,
+					Current:     after,
+					ChangedBy:   "publisher@ons.gov.uk",
+					ChangedAt:   changedAt,
+				})
+			})
+		})
+
+		Convey("When there are both previous and current values", func() {
+			change := NewCacheTimeChange(before, after, "publisher@ons.gov.uk", changedAt)
+
+			Convey("Then the change is recorded as an update", func() {
+				So(change.CacheTimeID, ShouldEqual, "a")
+				So(change.Action, ShouldEqual, CacheTimeUpdated)
+				So(change.Previous, ShouldEqual, before)
+				So(change.Current, ShouldEqual, after)
+			})
+		})
+
+		Convey("When there is no current value", func() {
+			change := NewCacheTimeChange(before, nil, "", changedAt)
+
+			Convey("Then the change is recorded as a deletion", func() {
+				So(change.CacheTimeID, ShouldEqual, "a")
+				So(change.Action, ShouldEqual, CacheTimeDeleted)
+				So(change.Previous, ShouldEqual, before)
+				So(change.Current, ShouldBeNil)
+			})
+		})
+	})
+}
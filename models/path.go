@@ -0,0 +1,98 @@
+package models
+
+import (
+	"crypto/md5" //nolint:gosec // MD5 is used to derive ids, not for security
+	"encoding/hex"
+	"strings"
+)
+
+// NormalisePath returns the canonical form of a page path, so that every caller derives the same id for a page.
+// Surrounding whitespace, any query string or fragment and trailing slashes are removed, repeated slashes are
+// collapsed and a leading slash is ensured. An empty path stays empty.
+func NormalisePath(path string) string {
+	path = strings.TrimSpace(path)
+	if i := strings.IndexAny(path, "?#"); i >= 0 {
+		path = path[:i]
+	}
+	if path == "" {
+		return ""
+	}
+
+	var b strings.Builder
+	b.Grow(len(path) + 1)
+	b.WriteByte('/')
+	for _, segment := range strings.Split(path, "/") {
+		if segment == "" {
+			continue
+		}
+		if b.Len() > 1 {
+			b.WriteByte('/')
+		}
+		b.WriteString(segment)
+	}
+	return b.String()
+}
+
+// HashPath returns the MD5 hash of a path as a lowercase hexadecimal string
+func HashPath(path string) string {
+	sum := md5.Sum([]byte(path)) //nolint:gosec // MD5 is used to derive ids, not for security
+	return hex.EncodeToString(sum[:])
+}
+
+// CacheTimeID returns the id of the cache time for a page path, that is the MD5 hash of the normalised path
+func CacheTimeID(path string) string {
+	return HashPath(NormalisePath(path))
+}
+
+// GroupByPath groups cache times by normalised path, returning the paths in the order they first appear
+func GroupByPath(cacheTimes []*CacheTime) ([]string, map[string][]*CacheTime) {
+	var paths []string
+	groups := make(map[string][]*CacheTime)
+	for _, cacheTime := range cacheTimes {
+		path := NormalisePath(cacheTime.Path)
+		if _, ok := groups[path]; !ok {
+			paths = append(paths, path)
+		}
+		groups[path] = append(groups[path], cacheTime)
+	}
+	return paths, groups
+}
+
+// PreferredCacheTime picks the cache time to keep out of a set sharing the same normalised path: one already stored
+// under the id of its normalised path, then one whose id is the hash of its path as written, then the one released
+// last, then the first in id order
+func PreferredCacheTime(group []*CacheTime) *CacheTime {
+	rank := func(cacheTime *CacheTime) int {
+		switch {
+		case cacheTime.ID == HashPath(cacheTime.Path) && cacheTime.Path == NormalisePath(cacheTime.Path):
+			return 2
+		case cacheTime.ID == HashPath(cacheTime.Path):
+			return 1
+		default:
+			return 0
+		}
+	}
+
+	preferred := group[0]
+	for _, cacheTime := range group[1:] {
+		switch rankDiff := rank(cacheTime) - rank(preferred); {
+		case rankDiff > 0:
+			preferred = cacheTime
+		case rankDiff < 0:
+		case releasedAfter(cacheTime, preferred):
+			preferred = cacheTime
+		case !releasedAfter(preferred, cacheTime) && cacheTime.ID < preferred.ID:
+			preferred = cacheTime
+		}
+	}
+	return preferred
+}
+
+// releasedAfter reports whether a is released after b, a cache time without a release time never being released after
+// another
+func releasedAfter(a, b *CacheTime) bool {
+	if a.ReleaseTime == nil {
+		return false
+	}
+	return b.ReleaseTime == nil || a.ReleaseTime.After(*b.ReleaseTime)
+}
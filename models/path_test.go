@@ -0,0 +1,102 @@
+package models
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestNormalisePath(t *testing.T) {
+	Convey("Given a set of paths written in different ways", t, func() {
+		paths := map[string]string{
+			"/economy/inflation":              "/economy/inflation",
+			"economy/inflation":               "/economy/inflation",
+			"  /economy/inflation/  ":         "/economy/inflation",
+			"//economy///inflation":           "/economy/inflation",
+			"/economy/inflation?foo=bar#top":  "/economy/inflation",
+			"/economy/inflation/#top":         "/economy/inflation",
+			"/":                               "/",
+			"///":                             "/",
+			"":                                "",
+			"   ":                             "",
+			"?foo=bar":                        "",
+			"/economy/Inflation/DATA.json":    "/economy/Inflation/DATA.json",
+			"/economy/inflation/bulletins/a/": "/economy/inflation/bulletins/a",
+		}
+
+		Convey("When each path is normalised", func() {
+			Convey("Then the canonical form of the path is returned", func() {
+				for path, expected := range paths {
+					So(NormalisePath(path), ShouldEqual, expected)
+				}
+			})
+		})
+	})
+}
+
+func TestCacheTimeID(t *testing.T) {
+	Convey("Given a path", t, func() {
+		path := "/economy/inflation/"
+
+		Convey("When its cache time id is computed", func() {
+			id := CacheTimeID(path)
+
+			Convey("Then the id is the lowercase hexadecimal MD5 hash of the normalised path", func() {
+				So(id, ShouldEqual, HashPath("/economy/inflation"))
+				So(id, ShouldEqual, "4836470a4e61477475682454751b9af0")
+			})
+		})
+	})
+
+	Convey("Given a path hashed without normalisation", t, func() {
+		Convey("Then the hash matches the MD5 of the raw path", func() {
+			So(HashPath("hello"), ShouldEqual, "5d41402abc4b2a76b9719d911017c592")
+		})
+	})
+}
+
+func TestGroupByPath(t *testing.T) {
+	Convey("Given cache times whose paths are written in different ways", t, func() {
+		a, b, c := &CacheTime{ID: "a", Path: "/economy/a"}, &CacheTime{ID: "b", Path: "/economy/b"}, &CacheTime{ID: "c", Path: "economy/a/"}
+
+		Convey("When they are grouped by path", func() {
+			paths, groups := GroupByPath([]*CacheTime{a, b, c})
+
+			Convey("Then the cache times sharing a normalised path are grouped, in the order their paths first appear", func() {
+				So(paths, ShouldResemble, []string{"/economy/a", "/economy/b"})
+				So(groups["/economy/a"], ShouldResemble, []*CacheTime{a, c})
+				So(groups["/economy/b"], ShouldResemble, []*CacheTime{b})
+			})
+		})
+	})
+}
+
+func TestPreferredCacheTime(t *testing.T) {
+	earlier := time.Date(2024, time.January, 31, 9, 30, 0, 0, time.UTC)
+	later := earlier.Add(time.Hour)
+
+	Convey("Given cache times sharing a normalised path", t, func() {
+		normalised := &CacheTime{ID: HashPath("/economy/a"), Path: "/economy/a"}
+		hashedAsWritten := &CacheTime{ID: HashPath("economy/a/"), Path: "economy/a/", ReleaseTime: &later}
+		released := &CacheTime{ID: "b", Path: "/economy/a", ReleaseTime: &earlier}
+		unreleased := &CacheTime{ID: "a", Path: "/economy/a"}
+
+		Convey("Then the one stored under the hash of its normalised path is preferred", func() {
+			So(PreferredCacheTime([]*CacheTime{hashedAsWritten, released, normalised}), ShouldEqual, normalised)
+		})
+
+		Convey("Then otherwise the one stored under the hash of its path as written is preferred", func() {
+			So(PreferredCacheTime([]*CacheTime{released, hashedAsWritten}), ShouldEqual, hashedAsWritten)
+		})
+
+		Convey("Then otherwise the one released last is preferred", func() {
+			So(PreferredCacheTime([]*CacheTime{unreleased, released}), ShouldEqual, released)
+		})
+
+		Convey("Then otherwise the first in id order is preferred", func() {
+			other := &CacheTime{ID: "c", Path: "/economy/a"}
+			So(PreferredCacheTime([]*CacheTime{other, unreleased}), ShouldEqual, unreleased)
+		})
+	})
+}
@@ -0,0 +1,37 @@
+package models
+
+import (
+	"slices"
+	"strconv"
+)
+
+// Precondition restricts a write to a cache time to when the stored cache time is in an expected state, as requested
+// with the If-Match and If-None-Match headers. The zero value allows every write.
+type Precondition struct {
+	Versions     []int // The stored cache time must have one of these versions
+	MustExist    bool  // A cache time must be stored, whatever its version
+	MustNotExist bool  // No cache time may be stored, only applies to upserts
+}
+
+// RequiresExisting reports whether the precondition can only hold when a cache time is stored
+func (p Precondition) RequiresExisting() bool {
+	return p.MustExist || len(p.Versions) > 0
+}
+
+// Matches reports whether the precondition holds for stored, the cache time currently stored or nil when there is none
+func (p Precondition) Matches(stored *CacheTime) bool {
+	switch {
+	case p.MustNotExist:
+		return stored == nil
+	case p.MustExist:
+		return stored != nil
+	case len(p.Versions) > 0:
+		return stored != nil && slices.Contains(p.Versions, stored.Version)
+	}
+	return true
+}
+
+// ETag returns the entity tag of a cache time at the given version
+func ETag(version int) string {
+	return strconv.Quote(strconv.Itoa(version))
+}
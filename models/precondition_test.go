@@ -0,0 +1,35 @@
+package models
+
+import (
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestPreconditionMatches(t *testing.T) {
+	Convey("Given a stored cache time at version 3", t, func() {
+		stored := &CacheTime{ID: "a", Version: 3}
+
+		Convey("Then the precondition is checked against it, or against no cache time being stored", func() {
+			So(Precondition{}.Matches(stored), ShouldBeTrue)
+			So(Precondition{}.Matches(nil), ShouldBeTrue)
+
+			So(Precondition{Versions: []int{2, 3}}.Matches(stored), ShouldBeTrue)
+			So(Precondition{Versions: []int{2}}.Matches(stored), ShouldBeFalse)
+			So(Precondition{Versions: []int{3}}.Matches(nil), ShouldBeFalse)
+
+			So(Precondition{MustExist: true}.Matches(stored), ShouldBeTrue)
+			So(Precondition{MustExist: true}.Matches(nil), ShouldBeFalse)
+
+			So(Precondition{MustNotExist: true}.Matches(stored), ShouldBeFalse)
+			So(Precondition{MustNotExist: true}.Matches(nil), ShouldBeTrue)
+		})
+	})
+}
+
+func TestETag(t *testing.T) {
+	Convey("The entity tag of a version is the quoted version number", t, func() {
+		So(ETag(3), ShouldEqual, `"3"`)
+		So(ETag(0), ShouldEqual, `"0"`)
+	})
+}
@@ -0,0 +1,39 @@
+package models
+
+import "time"
+
+// Release records that the release time of a cache time was reached and fired by the scheduler. A release is
+// identified by its cache time and release time, so that a cache time whose release time is moved fires again. A
+// release is pending until it has been sent to every sink.
+type Release struct {
+	ID          string     `bson:"_id" json:"id"`                              // Id of the cache time and release time
+	CacheTimeID string     `bson:"cache_time_id" json:"cache_time_id"`         // Id of the cache time released
+	Path        string     `bson:"path" json:"path"`                           // Path of the cache time released
+	ReleaseTime time.Time  `bson:"release_time" json:"release_time"`           // Release time reached
+	FiredAt     time.Time  `bson:"fired_at" json:"fired_at"`                   // Time the release was last fired
+	FiredBy     string     `bson:"fired_by" json:"fired_by"`                   // Name of the instance that last fired the release
+	SentAt      *time.Time `bson:"sent_at,omitempty" json:"sent_at,omitempty"` // Time the release was sent to every sink, if it was
+}
+
+// IsPending reports whether the release has not been sent to every sink yet
+func (r *Release) IsPending() bool {
+	return r.SentAt == nil
+}
+
+// NewRelease creates the release of a cache time, which must have a release time, fired by firedBy at firedAt
+func NewRelease(cacheTime *CacheTime, firedBy string, firedAt time.Time) *Release {
+	releaseTime := cacheTime.ReleaseTime.UTC()
+	return &Release{
+		ID:          ReleaseID(cacheTime.ID, releaseTime),
+		CacheTimeID: cacheTime.ID,
+		Path:        cacheTime.Path,
+		ReleaseTime: releaseTime,
+		FiredAt:     firedAt,
+		FiredBy:     firedBy,
+	}
+}
+
+// ReleaseID returns the id of the release of the cache time with the given id at releaseTime
+func ReleaseID(cacheTimeID string, releaseTime time.Time) string {
+	return cacheTimeID + "@" + releaseTime.UTC().Format(time.RFC3339Nano)
+}
@@ -0,0 +1,33 @@
+package models
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestNewRelease(t *testing.T) {
+	Convey("Given a cache time whose release time is set in another time zone", t, func() {
+		releaseTime := time.Date(2024, time.January, 31, 10, 30, 0, 0, time.FixedZone("CET", 3600))
+		cacheTime := &CacheTime{ID: "a", Path: "/economy/a", ReleaseTime: &releaseTime}
+
+		Convey("When its release is created", func() {
+			release := NewRelease(cacheTime, "host-1", releaseTime)
+
+			Convey("Then it is identified by the cache time and the release time in UTC", func() {
+				So(release.ID, ShouldEqual, "a@2024-01-31T09:30:00Z")
+				So(release.ReleaseTime.Location(), ShouldEqual, time.UTC)
+				So(release.FiredBy, ShouldEqual, "host-1")
+			})
+		})
+
+		Convey("When its release time is moved", func() {
+			moved := releaseTime.Add(time.Hour)
+
+			Convey("Then its release has a different id", func() {
+				So(ReleaseID("a", moved), ShouldNotEqual, ReleaseID("a", releaseTime))
+			})
+		})
+	})
+}
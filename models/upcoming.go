@@ -0,0 +1,44 @@
+package models
+
+import "time"
+
+// UpcomingReleases lists the cache times released within a window of time, grouped by collection
+type UpcomingReleases struct {
+	From        time.Time             `json:"from"`        // Start of the window in ISO-8601 format
+	To          time.Time             `json:"to"`          // End of the window in ISO-8601 format
+	Count       int                   `json:"count"`       // Number of cache times released within the window
+	Collections []*CollectionReleases `json:"collections"` // Collections in order of their first release time
+}
+
+// CollectionReleases lists the cache times of a collection released within a window of time, in release time order
+type CollectionReleases struct {
+	CollectionID string       `json:"collection_id,omitempty"` // Empty for the cache times without a collection
+	ReleaseTime  time.Time    `json:"release_time"`            // First release time of the collection in the window
+	Count        int          `json:"count"`
+	Items        []*CacheTime `json:"items"`
+}
+
+// NewUpcomingReleases groups the cache times released between from and to, which must be given in release time order,
+// by collection. Collections are listed in order of their first release time, so the order of the cache times is kept
+// within each collection.
+func NewUpcomingReleases(cacheTimes []*CacheTime, from, to time.Time) *UpcomingReleases {
+	upcoming := &UpcomingReleases{
+		From:        from,
+		To:          to,
+		Count:       len(cacheTimes),
+		Collections: []*CollectionReleases{},
+	}
+
+	collections := make(map[string]*CollectionReleases)
+	for _, cacheTime := range cacheTimes {
+		collection, ok := collections[cacheTime.CollectionID]
+		if !ok {
+			collection = &CollectionReleases{CollectionID: cacheTime.CollectionID, ReleaseTime: *cacheTime.ReleaseTime}
+			collections[cacheTime.CollectionID] = collection
+			upcoming.Collections = append(upcoming.Collections, collection)
+		}
+		collection.Items = append(collection.Items, cacheTime)
+		collection.Count++
+	}
+	return upcoming
+}
@@ -0,0 +1,57 @@
+package models
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestNewUpcomingReleases(t *testing.T) {
+	Convey("Given cache times of several collections in release time order", t, func() {
+		from := time.Date(2024, time.January, 31, 9, 0, 0, 0, time.UTC)
+		to := from.Add(24 * time.Hour)
+		first, second, third := from.Add(time.Hour), from.Add(2*time.Hour), from.Add(3*time.Hour)
+		cacheTimes := []*CacheTime{
+			{ID: "a", Path: "/economy/a", CollectionID: "collection-2", ReleaseTime: &first},
+			{ID: "b", Path: "/economy/b", CollectionID: "collection-1", ReleaseTime: &second},
+			{ID: "c", Path: "/economy/c", ReleaseTime: &second},
+			{ID: "d", Path: "/economy/d", CollectionID: "collection-2", ReleaseTime: &third},
+		}
+
+		Convey("When they are grouped", func() {
+			upcoming := NewUpcomingReleases(cacheTimes, from, to)
+
+			Convey("Then the collections are listed in order of their first release time", func() {
+				So(upcoming.From, ShouldEqual, from)
+				So(upcoming.To, ShouldEqual, to)
+				So(upcoming.Count, ShouldEqual, 4)
+				So(upcoming.Collections, ShouldHaveLength, 3)
+
+				So(upcoming.Collections[0].CollectionID, ShouldEqual, "collection-2")
+				So(upcoming.Collections[0].ReleaseTime, ShouldEqual, first)
+				So(upcoming.Collections[0].Count, ShouldEqual, 2)
+				So(upcoming.Collections[0].Items, ShouldResemble, []*CacheTime{cacheTimes[0], cacheTimes[3]})
+
+				So(upcoming.Collections[1].CollectionID, ShouldEqual, "collection-1")
+				So(upcoming.Collections[1].Items, ShouldResemble, []*CacheTime{cacheTimes[1]})
+
+				Convey("And the cache times without a collection are grouped together", func() {
+					So(upcoming.Collections[2].CollectionID, ShouldBeEmpty)
+					So(upcoming.Collections[2].ReleaseTime, ShouldEqual, second)
+					So(upcoming.Collections[2].Items, ShouldResemble, []*CacheTime{cacheTimes[2]})
+				})
+			})
+		})
+
+		Convey("When there are none", func() {
+			upcoming := NewUpcomingReleases(nil, from, to)
+
+			Convey("Then an empty list of collections is returned", func() {
+				So(upcoming.Count, ShouldEqual, 0)
+				So(upcoming.Collections, ShouldNotBeNil)
+				So(upcoming.Collections, ShouldBeEmpty)
+			})
+		})
+	})
+}
@@ -1 +1,3 @@
 db.createCollection('cachetimes')
+db.createCollection('cachetimes_history')
+db.cachetimes_history.createIndex({ cache_time_id: 1, changed_at: -1 })
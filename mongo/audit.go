@@ -0,0 +1,112 @@
+package mongo
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	errs "github.com/ONSdigital/dp-legacy-cache-api/apierrors"
+	"github.com/ONSdigital/dp-legacy-cache-api/config"
+	"github.com/ONSdigital/dp-legacy-cache-api/models"
+	"github.com/ONSdigital/dp-legacy-cache-api/tracing"
+	dprequest "github.com/ONSdigital/dp-net/v3/request"
+	"github.com/ONSdigital/log.go/v2/log"
+	"go.mongodb.org/mongo-driver/bson"
+	driver "go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// ScanCacheTimes calls fn for every cache time in the collection, in id order, without loading the whole collection
+// into memory. Scanning stops at the first error returned by fn.
+func (m *Mongo) ScanCacheTimes(ctx context.Context, fn func(*models.CacheTime) error) (err error) {
+	ctx, span := m.startSpan(ctx, config.CacheTimesCollection, "ScanCacheTimes")
+	defer func() { tracing.End(span, err) }()
+
+	cursor, err := m.collection(config.CacheTimesCollection).Find(ctx, bson.M{}, options.Find().SetSort(bson.M{"_id": 1}))
+	if err != nil {
+		log.Error(ctx, "error targeting dataStore.ScanCacheTimes", err)
+		return errs.ErrDataStore
+	}
+	defer cursor.Close(ctx)
+
+	for cursor.Next(ctx) {
+		var cacheTime models.CacheTime
+		if err = cursor.Decode(&cacheTime); err != nil {
+			return err
+		}
+		if err = fn(&cacheTime); err != nil {
+			return err
+		}
+	}
+	return cursor.Err()
+}
+
+// MoveCacheTime removes the document stored under oldID and stores the given cache time under its id in a single
+// transaction, so that the unique index on path allows the new document to take the path of the old one, and the old
+// document is kept if the new one cannot be stored. The new document is stamped as a change of the old one, so that its
+// version is incremented. Both changes are recorded in the history once committed. The insert fails rather than
+// overwrite a cache time that already uses the new id.
+func (m *Mongo) MoveCacheTime(ctx context.Context, oldID string, cacheTime *models.CacheTime) (err error) {
+	ctx, span := m.startSpan(ctx, config.CacheTimesCollection, "MoveCacheTime")
+	defer func() { tracing.End(span, err) }()
+
+	collection := m.collection(config.CacheTimesCollection)
+	changedBy, changedAt := dprequest.Caller(ctx), time.Now().UTC()
+
+	var previous, moved *models.CacheTime
+	err = m.inTransaction(ctx, func(ctx context.Context) error {
+		previous = &models.CacheTime{}
+		err := collection.FindOneAndDelete(ctx, bson.M{"_id": oldID}).Decode(previous)
+		switch {
+		case errors.Is(err, driver.ErrNoDocuments):
+			previous = nil
+		case err != nil:
+			return err
+		}
+
+		// Without the old document, the given cache time, which is a copy of it, is the value being replaced
+		if previous != nil {
+			moved = models.StampCacheTime(cacheTime, previous, changedBy, changedAt)
+		} else {
+			moved = models.StampCacheTime(cacheTime, cacheTime, changedBy, changedAt)
+		}
+		_, err = collection.InsertOne(ctx, moved)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	if previous != nil {
+		m.recordChange(ctx, previous, nil)
+	}
+	m.recordChange(ctx, nil, moved)
+	return nil
+}
+
+// ClearReleaseTime removes the release time of the cache time with the given id, recording the change in its history
+func (m *Mongo) ClearReleaseTime(ctx context.Context, id string) (err error) {
+	ctx, span := m.startSpan(ctx, config.CacheTimesCollection, "ClearReleaseTime")
+	defer func() { tracing.End(span, err) }()
+
+	changedBy, changedAt := dprequest.Caller(ctx), time.Now().UTC()
+	update := bson.M{
+		"$unset": bson.M{"release_time": ""},
+		"$set":   bson.M{"last_updated": changedAt, "last_updated_by": changedBy},
+		"$inc":   bson.M{"version": 1},
+	}
+
+	previous := &models.CacheTime{}
+	err = m.collection(config.CacheTimesCollection).FindOneAndUpdate(ctx, bson.M{"_id": id}, update).Decode(previous)
+	if err != nil {
+		if errors.Is(err, driver.ErrNoDocuments) {
+			return errs.ErrCacheTimeNotFound
+		}
+		return err
+	}
+
+	current := models.StampCacheTime(previous, previous, changedBy, changedAt)
+	current.ReleaseTime = nil
+	m.recordChanges(ctx, models.NewCacheTimeChange(previous, current, changedBy, changedAt))
+	return nil
+}
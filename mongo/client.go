@@ -0,0 +1,96 @@
+package mongo
+
+import (
+	"context"
+
+	mongoDriver "github.com/ONSdigital/dp-mongodb/v3/mongodb"
+	"go.mongodb.org/mongo-driver/bson"
+	driver "go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.mongodb.org/mongo-driver/mongo/readconcern"
+	"go.mongodb.org/mongo-driver/mongo/readpref"
+	"go.mongodb.org/mongo-driver/mongo/writeconcern"
+)
+
+// openClient connects to mongo using the same client options as dp-mongodb's Open. The resulting client backs both
+// the dp-mongodb connection, used for health checks and transactions, and the driver collections every operation on
+// the data goes through, as the dp-mongodb wrapper does not provide some of them, such as bulk writes.
+func openClient(cfg *mongoDriver.MongoDriverConfig) (*driver.Client, error) {
+	tlsConfig, err := cfg.GetTLSConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	connectionURI, err := cfg.GetConnectionURI()
+	if err != nil {
+		return nil, err
+	}
+
+	clientOptions := options.Client().
+		ApplyURI(connectionURI).
+		SetTLSConfig(tlsConfig).
+		SetRetryWrites(false)
+
+	if cfg.IsStrongReadConcernEnabled {
+		clientOptions = clientOptions.SetReadPreference(readpref.Primary()).SetReadConcern(readconcern.Majority())
+	} else {
+		clientOptions = clientOptions.SetReadPreference(readpref.SecondaryPreferred())
+	}
+
+	if cfg.IsWriteConcernMajorityEnabled {
+		clientOptions = clientOptions.SetWriteConcern(writeconcern.Majority())
+	} else {
+		clientOptions = clientOptions.SetWriteConcern(writeconcern.W1())
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), cfg.ConnectTimeout)
+	defer cancel()
+
+	client, err := driver.Connect(ctx, clientOptions)
+	if err != nil {
+		return nil, err
+	}
+
+	// Force a connection to verify the connection string
+	if err = client.Ping(ctx, nil); err != nil {
+		return nil, err
+	}
+
+	return client, nil
+}
+
+// collection returns the driver collection for the given well known collection name
+func (m *Mongo) collection(name string) *driver.Collection {
+	return m.client.Database(m.Database).Collection(m.ActualCollectionName(name))
+}
+
+// findPage decodes into results the page of the documents of the named collection matching filter, in the given
+// order, and returns the total number of matching documents. The limit must be positive, as the driver treats a limit
+// of 0 as no limit.
+func (m *Mongo) findPage(ctx context.Context, name string, filter interface{}, sort bson.D, offset, limit int, results interface{}) (int, error) {
+	collection := m.collection(name)
+	totalCount, err := collection.CountDocuments(ctx, filter)
+	if err != nil {
+		return 0, err
+	}
+	if totalCount == 0 || int64(offset) >= totalCount {
+		return int(totalCount), nil
+	}
+
+	opts := options.Find().SetSort(sort).SetSkip(int64(offset)).SetLimit(int64(limit))
+	cursor, err := collection.Find(ctx, filter, opts)
+	if err != nil {
+		return 0, err
+	}
+	return int(totalCount), cursor.All(ctx, results)
+}
+
+// inTransaction runs fn in a transaction, which is retried as a whole on transient errors such as a write conflict
+// with a concurrent request. The operations of fn must use the context it is given to take part in the transaction.
+// Transactions require MongoDB to run as a replica set.
+func (m *Mongo) inTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	_, err := m.Connection.RunTransaction(ctx, true, func(transactionCtx context.Context) (interface{}, error) {
+		return nil, fn(transactionCtx)
+	})
+	return err
+}
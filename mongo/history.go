@@ -0,0 +1,55 @@
+package mongo
+
+import (
+	"context"
+	"time"
+
+	errs "github.com/ONSdigital/dp-legacy-cache-api/apierrors"
+	"github.com/ONSdigital/dp-legacy-cache-api/config"
+	"github.com/ONSdigital/dp-legacy-cache-api/models"
+	"github.com/ONSdigital/dp-legacy-cache-api/tracing"
+	dprequest "github.com/ONSdigital/dp-net/v3/request"
+	"github.com/ONSdigital/log.go/v2/log"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// GetCacheTimeHistory returns a page of the changes made to the cache time with the given id, most recent first, along
+// with the total number of changes
+func (m *Mongo) GetCacheTimeHistory(ctx context.Context, id string, offset, limit int) (_ []*models.CacheTimeChange, _ int, err error) {
+	ctx, span := m.startSpan(ctx, config.CacheTimesHistoryCollection, "GetCacheTimeHistory")
+	defer func() { tracing.End(span, err) }()
+
+	results := []*models.CacheTimeChange{}
+	totalCount, err := m.findPage(ctx, config.CacheTimesHistoryCollection, bson.M{"cache_time_id": id},
+		bson.D{{Key: "changed_at", Value: -1}, {Key: "_id", Value: -1}}, offset, limit, &results)
+	if err != nil {
+		log.Error(ctx, "error targeting api.dataStore.GetCacheTimeHistory", err)
+		return nil, 0, errs.ErrDataStore
+	}
+	return results, totalCount, nil
+}
+
+// recordChange appends the change from previous to current to the history of the cache time
+func (m *Mongo) recordChange(ctx context.Context, previous, current *models.CacheTime) {
+	m.recordChanges(ctx, models.NewCacheTimeChange(previous, current, dprequest.Caller(ctx), time.Now().UTC()))
+}
+
+// recordChanges appends the given changes to the history collection. The changes have already been made by then, so a
+// failure to record them is logged rather than returned.
+func (m *Mongo) recordChanges(ctx context.Context, changes ...*models.CacheTimeChange) {
+	if len(changes) == 0 {
+		return
+	}
+
+	documents := make([]interface{}, len(changes))
+	for i, change := range changes {
+		documents[i] = change
+	}
+
+	ctx, span := m.startSpan(ctx, config.CacheTimesHistoryCollection, "RecordChanges")
+	_, err := m.collection(config.CacheTimesHistoryCollection).InsertMany(ctx, documents)
+	tracing.End(span, err)
+	if err != nil {
+		log.Error(ctx, "error recording cache time history", err, log.Data{"changes": len(changes)})
+	}
+}
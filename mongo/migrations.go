@@ -0,0 +1,181 @@
+package mongo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/ONSdigital/dp-legacy-cache-api/config"
+	"github.com/ONSdigital/dp-legacy-cache-api/tracing"
+	dprequest "github.com/ONSdigital/dp-net/v3/request"
+	"github.com/ONSdigital/log.go/v2/log"
+	"go.mongodb.org/mongo-driver/bson"
+	driver "go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const (
+	// migrationsCaller is the identity recorded in the history of the cache times changed by migrations
+	migrationsCaller = "migrations"
+
+	// migrationWait is how long an instance waits for a migration being applied by another instance
+	migrationWait         = 10 * time.Minute
+	migrationPollInterval = time.Second
+)
+
+// Migration is a numbered change made to the data once, in order, by whichever instance applies it first
+type Migration struct {
+	Version     int
+	Description string
+	Apply       func(ctx context.Context, m *Mongo) error
+}
+
+// migrationRecord tracks a migration in the migrations collection. A migration is claimed by recording when it
+// started, and is applied once it records when it was applied.
+type migrationRecord struct {
+	Version     int        `bson:"_id"`
+	Description string     `bson:"description"`
+	StartedAt   time.Time  `bson:"started_at"`
+	AppliedAt   *time.Time `bson:"applied_at,omitempty"`
+}
+
+// migrations lists the data migrations in version order. A migration that has been released must never be changed:
+// add a new one instead. Migrations must not delete cache times, which is left to the cachetime-audit command, as it
+// reports what it would delete before doing so.
+var migrations = []Migration{}
+
+// indexes lists the indexes needed by the service, by well known collection name. Their names are left to MongoDB, so
+// that indexes created beforehand with the same keys are recognised.
+var indexes = map[string][]driver.IndexModel{
+	config.CacheTimesCollection: {
+		{Keys: bson.D{{Key: "path", Value: 1}}, Options: options.Index().SetUnique(true)},
+		{Keys: bson.D{{Key: "collection_id", Value: 1}}},
+		{Keys: bson.D{{Key: "release_time", Value: 1}}},
+	},
+	config.CacheTimesHistoryCollection: {
+		{Keys: bson.D{{Key: "cache_time_id", Value: 1}, {Key: "changed_at", Value: -1}}},
+	},
+	config.CacheTimesReleasesCollection: {
+		// Pending releases have no sent_at, so they are never expired
+		{Keys: bson.D{{Key: "sent_at", Value: 1}}, Options: options.Index().SetExpireAfterSeconds(int32(config.ReleaseRetention / time.Second))},
+		{Keys: bson.D{{Key: "release_time", Value: -1}}},
+	},
+}
+
+// Migrate applies the data migrations not applied yet, in order, then creates the indexes that do not exist yet. Each
+// migration is claimed in the migrations collection before being applied, so that it is applied once however many
+// instances migrate at the same time: an instance finding a migration claimed by another waits for it to be applied.
+func (m *Mongo) Migrate(ctx context.Context) error {
+	ctx = dprequest.SetCaller(ctx, migrationsCaller)
+
+	for _, migration := range migrations {
+		if err := m.applyMigration(ctx, migration); err != nil {
+			return fmt.Errorf("error applying migration %d: %w", migration.Version, err)
+		}
+	}
+
+	if err := m.ensureIndexes(ctx); err != nil {
+		return fmt.Errorf("error creating indexes: %w", err)
+	}
+	return nil
+}
+
+func (m *Mongo) applyMigration(ctx context.Context, migration Migration) (err error) {
+	ctx, span := m.startSpan(ctx, config.MigrationsCollection, "ApplyMigration")
+	defer func() { tracing.End(span, err) }()
+
+	collection := m.collection(config.MigrationsCollection)
+	logData := log.Data{"version": migration.Version, "description": migration.Description}
+
+	record := migrationRecord{Version: migration.Version, Description: migration.Description, StartedAt: time.Now().UTC()}
+	_, err = collection.InsertOne(ctx, record)
+	switch {
+	case driver.IsDuplicateKeyError(err):
+		return m.awaitMigration(ctx, migration)
+	case err != nil:
+		return err
+	}
+
+	log.Info(ctx, "applying migration", logData)
+	if err = migration.Apply(ctx, m); err != nil {
+		// Release the claim, so that the migration is applied again on the next attempt
+		if _, deleteErr := collection.DeleteOne(ctx, bson.M{"_id": migration.Version}); deleteErr != nil {
+			log.Error(ctx, "error releasing failed migration", deleteErr, logData)
+		}
+		return err
+	}
+
+	_, err = collection.UpdateOne(ctx, bson.M{"_id": migration.Version}, bson.M{"$set": bson.M{"applied_at": time.Now().UTC()}})
+	if err != nil {
+		return err
+	}
+	log.Info(ctx, "migration applied", logData)
+	return nil
+}
+
+// awaitMigration waits until a migration claimed by another instance, or by a previous run, is applied. A migration
+// whose claim is released is applied by this instance instead.
+func (m *Mongo) awaitMigration(ctx context.Context, migration Migration) error {
+	deadline := time.Now().Add(migrationWait)
+	for {
+		var record migrationRecord
+		err := m.collection(config.MigrationsCollection).FindOne(ctx, bson.M{"_id": migration.Version}).Decode(&record)
+		switch {
+		case errors.Is(err, driver.ErrNoDocuments):
+			return m.applyMigration(ctx, migration)
+		case err != nil:
+			return err
+		case record.AppliedAt != nil:
+			return nil
+		case time.Now().After(deadline):
+			return fmt.Errorf("migration started at %s has not been applied, delete its record from the %s collection to apply it again",
+				record.StartedAt.Format(time.RFC3339), m.ActualCollectionName(config.MigrationsCollection))
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(migrationPollInterval):
+		}
+	}
+}
+
+// ensureIndexes creates the indexes needed by the service, which does nothing for the indexes that already exist
+func (m *Mongo) ensureIndexes(ctx context.Context) error {
+	for name, indexModels := range indexes {
+		_, err := m.collection(name).Indexes().CreateMany(ctx, indexModels)
+		switch {
+		case name == config.CacheTimesCollection && driver.IsDuplicateKeyError(err):
+			return fmt.Errorf("%s: cache times share a path, run cachetime-audit --fix to delete the duplicates: %w",
+				m.ActualCollectionName(name), err)
+		case err != nil:
+			return fmt.Errorf("%s: %w", m.ActualCollectionName(name), err)
+		}
+	}
+	return nil
+}
+
+// HasUniquePathIndex reports whether the unique index on the path of the cache times exists, without which paths
+// shared by several cache times are not rejected
+func (m *Mongo) HasUniquePathIndex(ctx context.Context) (bool, error) {
+	cursor, err := m.collection(config.CacheTimesCollection).Indexes().List(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	var specs []struct {
+		Key    bson.D `bson:"key"`
+		Unique bool   `bson:"unique"`
+	}
+	if err = cursor.All(ctx, &specs); err != nil {
+		return false, err
+	}
+
+	for _, spec := range specs {
+		if spec.Unique && len(spec.Key) == 1 && spec.Key[0].Key == "path" {
+			return true, nil
+		}
+	}
+	return false, nil
+}
@@ -0,0 +1,17 @@
+package mongo
+
+import (
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestMigrationVersions(t *testing.T) {
+	Convey("The migrations are numbered in order from 1, without gaps", t, func() {
+		for i, migration := range migrations {
+			So(migration.Version, ShouldEqual, i+1)
+			So(migration.Description, ShouldNotBeEmpty)
+			So(migration.Apply, ShouldNotBeNil)
+		}
+	})
+}
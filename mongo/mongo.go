@@ -3,35 +3,44 @@ package mongo
 import (
 	"context"
 	"errors"
+	"regexp"
+	"sort"
+	"time"
 
 	"github.com/ONSdigital/dp-healthcheck/healthcheck"
 	errs "github.com/ONSdigital/dp-legacy-cache-api/apierrors"
 	"github.com/ONSdigital/dp-legacy-cache-api/config"
 	"github.com/ONSdigital/dp-legacy-cache-api/models"
+	"github.com/ONSdigital/dp-legacy-cache-api/tracing"
 	mongoHealth "github.com/ONSdigital/dp-mongodb/v3/health"
 	mongoDriver "github.com/ONSdigital/dp-mongodb/v3/mongodb"
+	dprequest "github.com/ONSdigital/dp-net/v3/request"
 	"github.com/ONSdigital/log.go/v2/log"
 	"go.mongodb.org/mongo-driver/bson"
+	driver "go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
 type Mongo struct {
 	mongoDriver.MongoDriverConfig
 
 	Connection   *mongoDriver.MongoConnection
+	client       *driver.Client
 	healthClient *mongoHealth.CheckMongoClient
 }
 
 // NewMongoStore creates a connection to mongo database
 func NewMongoStore(_ context.Context, cfg config.MongoConfig) (m *Mongo, err error) {
 	m = &Mongo{MongoDriverConfig: cfg}
-	m.Connection, err = mongoDriver.Open(&m.MongoDriverConfig)
-
+	m.client, err = openClient(&m.MongoDriverConfig)
 	if err != nil {
 		return nil, err
 	}
+	m.Connection = mongoDriver.NewMongoConnection(m.client, m.Database)
 	databaseCollectionBuilder := map[mongoHealth.Database][]mongoHealth.Collection{
 		mongoHealth.Database(m.Database): {
 			mongoHealth.Collection(m.ActualCollectionName(config.CacheTimesCollection)),
+			mongoHealth.Collection(m.ActualCollectionName(config.CacheTimesHistoryCollection)),
 		},
 	}
 
@@ -62,13 +71,16 @@ func (m *Mongo) IsConnected(ctx context.Context) bool {
 }
 
 // GetCacheTime returns a cache time with its given id
-func (m *Mongo) GetCacheTime(ctx context.Context, id string) (*models.CacheTime, error) {
+func (m *Mongo) GetCacheTime(ctx context.Context, id string) (_ *models.CacheTime, err error) {
+	ctx, span := m.startSpan(ctx, config.CacheTimesCollection, "GetCacheTime")
+	defer func() { tracing.End(span, err) }()
+
 	filter := bson.M{"_id": id}
 
 	var result models.CacheTime
-	err := m.Connection.Collection(m.ActualCollectionName(config.CacheTimesCollection)).FindOne(ctx, filter, &result)
+	err = m.collection(config.CacheTimesCollection).FindOne(ctx, filter).Decode(&result)
 	if err != nil {
-		if errors.Is(err, mongoDriver.ErrNoDocumentFound) {
+		if errors.Is(err, driver.ErrNoDocuments) {
 			log.Info(ctx, "api.dataStore.GetCacheTime document not found")
 			return nil, errs.ErrCacheTimeNotFound
 		}
@@ -78,14 +90,418 @@ func (m *Mongo) GetCacheTime(ctx context.Context, id string) (*models.CacheTime,
 	return &result, nil
 }
 
-// UpsertCacheTime adds or overrides an existing cache time
-func (m *Mongo) UpsertCacheTime(ctx context.Context, cacheTime *models.CacheTime) (err error) {
+// GetCacheTimes returns a page of cache times matching the given filter, along with the total number of matches
+func (m *Mongo) GetCacheTimes(ctx context.Context, filter models.CacheTimesFilter, offset, limit int) (_ []*models.CacheTime, _ int, err error) {
+	ctx, span := m.startSpan(ctx, config.CacheTimesCollection, "GetCacheTimes")
+	defer func() { tracing.End(span, err) }()
+
+	results := []*models.CacheTime{}
+	totalCount, err := m.findPage(ctx, config.CacheTimesCollection, buildCacheTimesQuery(filter), bson.D{{Key: "_id", Value: 1}},
+		offset, limit, &results)
+	if err != nil {
+		log.Error(ctx, "error targeting api.dataStore.GetCacheTimes", err)
+		return nil, 0, errs.ErrDataStore
+	}
+	return results, totalCount, nil
+}
+
+func buildCacheTimesQuery(filter models.CacheTimesFilter) bson.M {
+	query := bson.M{}
+	if filter.CollectionID != "" {
+		query["collection_id"] = filter.CollectionID
+	}
+	if filter.PathPrefix != "" {
+		query["path"] = bson.M{"$regex": "^" + regexp.QuoteMeta(filter.PathPrefix)}
+	}
+
+	releaseTime := bson.M{}
+	if filter.ReleaseTimeBefore != nil {
+		releaseTime["$lt"] = filter.ReleaseTimeBefore
+	}
+	if filter.ReleaseTimeAfter != nil {
+		releaseTime["$gt"] = filter.ReleaseTimeAfter
+	}
+	if len(releaseTime) > 0 {
+		query["release_time"] = releaseTime
+	}
+	return query
+}
+
+// ExportCacheTimes calls export with every cache time matching the given filter, in id order. The cache times are
+// read from a cursor, one batch at a time, so that the whole collection is never held in memory. An error returned by
+// export stops the export and is returned as is.
+func (m *Mongo) ExportCacheTimes(ctx context.Context, filter models.CacheTimesFilter, export func(*models.CacheTime) error) (err error) {
+	ctx, span := m.startSpan(ctx, config.CacheTimesCollection, "ExportCacheTimes")
+	defer func() { tracing.End(span, err) }()
+
+	opts := options.Find().SetSort(bson.D{{Key: "_id", Value: 1}})
+	cursor, err := m.collection(config.CacheTimesCollection).Find(ctx, buildCacheTimesQuery(filter), opts)
+	if err != nil {
+		log.Error(ctx, "error targeting api.dataStore.ExportCacheTimes", err)
+		return errs.ErrDataStore
+	}
+	defer func() {
+		if closeErr := cursor.Close(ctx); closeErr != nil {
+			log.Error(ctx, "error closing api.dataStore.ExportCacheTimes cursor", closeErr)
+		}
+	}()
+
+	for cursor.Next(ctx) {
+		var cacheTime models.CacheTime
+		if err = cursor.Decode(&cacheTime); err != nil {
+			log.Error(ctx, "error targeting api.dataStore.ExportCacheTimes", err)
+			return errs.ErrDataStore
+		}
+		if err = export(&cacheTime); err != nil {
+			return err
+		}
+	}
+	if err = cursor.Err(); err != nil {
+		log.Error(ctx, "error targeting api.dataStore.ExportCacheTimes", err)
+		return errs.ErrDataStore
+	}
+	return nil
+}
+
+// GetCacheTimesReleasedBetween returns every cache time whose release time is between from and to, both included, in
+// release time order. The range query is served by the index on release_time.
+func (m *Mongo) GetCacheTimesReleasedBetween(ctx context.Context, from, to time.Time) (_ []*models.CacheTime, err error) {
+	ctx, span := m.startSpan(ctx, config.CacheTimesCollection, "GetCacheTimesReleasedBetween")
+	defer func() { tracing.End(span, err) }()
+
+	query := bson.M{"release_time": bson.M{"$gte": from, "$lte": to}}
+	opts := options.Find().SetSort(bson.D{{Key: "release_time", Value: 1}, {Key: "_id", Value: 1}})
+
+	cursor, err := m.collection(config.CacheTimesCollection).Find(ctx, query, opts)
+	if err != nil {
+		log.Error(ctx, "error targeting api.dataStore.GetCacheTimesReleasedBetween", err)
+		return nil, errs.ErrDataStore
+	}
+
+	results := []*models.CacheTime{}
+	if err = cursor.All(ctx, &results); err != nil {
+		log.Error(ctx, "error targeting api.dataStore.GetCacheTimesReleasedBetween", err)
+		return nil, errs.ErrDataStore
+	}
+	return results, nil
+}
+
+// UpsertCacheTime adds or overrides an existing cache time, stamping it with the identity of the caller and recording
+// the change in its history. The precondition is part of the query of the update, so that it is checked atomically.
+// The change made is returned.
+func (m *Mongo) UpsertCacheTime(ctx context.Context, cacheTime *models.CacheTime, precondition models.Precondition) (_ *models.CacheTimeChange, err error) {
+	ctx, span := m.startSpan(ctx, config.CacheTimesCollection, "UpsertCacheTime")
+	defer func() { tracing.End(span, err) }()
+
+	changedBy, changedAt := dprequest.Caller(ctx), time.Now().UTC()
+	selector := preconditionSelector(cacheTime.ID, precondition)
+	update := upsertUpdate(cacheTime, changedBy, changedAt)
+	if precondition.MustNotExist {
+		// Only setting fields on insert leaves a cache time that already exists untouched
+		update = createOnlyUpdate(cacheTime, changedBy, changedAt)
+	}
+	opts := options.FindOneAndUpdate().SetUpsert(!precondition.RequiresExisting()).SetReturnDocument(options.Before)
+
+	previous := &models.CacheTime{}
+	err = m.collection(config.CacheTimesCollection).FindOneAndUpdate(ctx, selector, update, opts).Decode(previous)
+	switch {
+	case errors.Is(err, driver.ErrNoDocuments) && precondition.RequiresExisting():
+		return nil, errs.ErrPreconditionFailed
+	case errors.Is(err, driver.ErrNoDocuments):
+		previous = nil
+	case driver.IsDuplicateKeyError(err) && precondition.MustNotExist:
+		// A concurrent request created the cache time first
+		return nil, errs.ErrPreconditionFailed
+	case driver.IsDuplicateKeyError(err):
+		// The unique index on path rejects a cache time whose path is already stored under another id
+		return nil, errs.ErrPathConflict
+	case err != nil:
+		return nil, err
+	case precondition.MustNotExist:
+		return nil, errs.ErrPreconditionFailed
+	}
+
+	current := models.StampCacheTime(cacheTime, previous, changedBy, changedAt)
+	change := models.NewCacheTimeChange(previous, current, changedBy, changedAt)
+	m.recordChanges(ctx, change)
+	return change, nil
+}
+
+// UpsertCacheTimes adds or overrides the given cache times in a single unordered bulk write. The returned slice
+// reports, for each cache time in the same order, the change made to it, or the error that prevented it from being
+// written without preventing the others. Only the changes made are recorded in the history. As the writes are unordered,
+// the ids must be distinct. The values recorded before each change are read just before the bulk write rather than in
+// a transaction, which a single path conflict would abort as a whole, so a change made concurrently in between is
+// missing from the history.
+func (m *Mongo) UpsertCacheTimes(ctx context.Context, cacheTimes []*models.CacheTime) (_ []models.UpsertResult, err error) {
+	ctx, span := m.startSpan(ctx, config.CacheTimesCollection, "UpsertCacheTimes")
+	defer func() { tracing.End(span, err) }()
+
+	results := make([]models.UpsertResult, len(cacheTimes))
+	if len(cacheTimes) == 0 {
+		return results, nil
+	}
+
+	existing, err := m.findCacheTimes(ctx, bson.M{"_id": bson.M{"$in": cacheTimeIDs(cacheTimes)}})
+	if err != nil {
+		log.Error(ctx, "error targeting api.dataStore.UpsertCacheTimes", err)
+		return nil, errs.ErrDataStore
+	}
+
+	changedBy, changedAt := dprequest.Caller(ctx), time.Now().UTC()
+	writes := make([]driver.WriteModel, len(cacheTimes))
+	for i, cacheTime := range cacheTimes {
+		writes[i] = driver.NewUpdateOneModel().
+			SetFilter(bson.M{"_id": cacheTime.ID}).
+			SetUpdate(upsertUpdate(cacheTime, changedBy, changedAt)).
+			SetUpsert(true)
+	}
+
+	_, err = m.collection(config.CacheTimesCollection).BulkWrite(ctx, writes, options.BulkWrite().SetOrdered(false))
+	writeErrors, ok := bulkWriteErrors(err)
+	if !ok {
+		log.Error(ctx, "error targeting api.dataStore.UpsertCacheTimes", err)
+		return nil, errs.ErrDataStore
+	}
+	err = nil
+
+	changes := make([]*models.CacheTimeChange, 0, len(cacheTimes))
+	for i, cacheTime := range cacheTimes {
+		if writeErr, failed := writeErrors[i]; failed {
+			results[i].Err = upsertError(ctx, cacheTime, writeErr)
+			continue
+		}
+		current := models.StampCacheTime(cacheTime, existing[cacheTime.ID], changedBy, changedAt)
+		results[i].Change = models.NewCacheTimeChange(existing[cacheTime.ID], current, changedBy, changedAt)
+		changes = append(changes, results[i].Change)
+	}
+	m.recordChanges(ctx, changes...)
+
+	return results, nil
+}
+
+// bulkWriteErrors returns the errors of the writes of an unordered bulk write that failed, by index. It reports false
+// if the bulk write failed as a whole, in which case whether each write was made is unknown.
+func bulkWriteErrors(err error) (map[int]error, bool) {
+	if err == nil {
+		return nil, true
+	}
+
+	var bulkWriteException driver.BulkWriteException
+	if !errors.As(err, &bulkWriteException) || bulkWriteException.WriteConcernError != nil || len(bulkWriteException.WriteErrors) == 0 {
+		return nil, false
+	}
+
+	writeErrors := make(map[int]error, len(bulkWriteException.WriteErrors))
+	for _, writeErr := range bulkWriteException.WriteErrors {
+		writeErrors[writeErr.Index] = writeErr.WriteError
+	}
+	return writeErrors, true
+}
+
+// upsertError returns the error reported for a cache time that failed to be written in a batch
+func upsertError(ctx context.Context, cacheTime *models.CacheTime, writeErr error) error {
+	if driver.IsDuplicateKeyError(writeErr) {
+		// The unique index on path rejects a cache time whose path is already stored under another id
+		return errs.ErrPathConflict
+	}
+	log.Error(ctx, "error targeting api.dataStore.UpsertCacheTimes", writeErr, log.Data{"cache_time_id": cacheTime.ID})
+	return errs.ErrDataStore
+}
+
+func cacheTimeIDs(cacheTimes []*models.CacheTime) []string {
+	ids := make([]string, len(cacheTimes))
+	for i, cacheTime := range cacheTimes {
+		ids[i] = cacheTime.ID
+	}
+	return ids
+}
+
+// findCacheTimes returns the cache times matching the given filter, keyed by id. Within a transaction, the cache times
+// are read from the primary as of the start of the transaction.
+func (m *Mongo) findCacheTimes(ctx context.Context, filter bson.M) (map[string]*models.CacheTime, error) {
+	var results []*models.CacheTime
+	cursor, err := m.collection(config.CacheTimesCollection).Find(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	if err = cursor.All(ctx, &results); err != nil {
+		return nil, err
+	}
+
+	cacheTimes := make(map[string]*models.CacheTime, len(results))
+	for _, cacheTime := range results {
+		cacheTimes[cacheTime.ID] = cacheTime
+	}
+	return cacheTimes, nil
+}
+
+// upsertUpdate replaces the fields of a cache time that clients can set, stamping it with the server managed metadata
+func upsertUpdate(cacheTime *models.CacheTime, changedBy string, changedAt time.Time) bson.M {
+	return bson.M{
+		"$set":         updatedFields(cacheTime, changedBy, changedAt),
+		"$setOnInsert": bson.M{"created_at": changedAt},
+		"$inc":         bson.M{"version": 1},
+	}
+}
+
+// createOnlyUpdate sets every field of a new cache time, leaving a cache time that already exists unchanged
+func createOnlyUpdate(cacheTime *models.CacheTime, changedBy string, changedAt time.Time) bson.M {
+	fields := updatedFields(cacheTime, changedBy, changedAt)
+	fields["created_at"] = changedAt
+	fields["version"] = 1
+	return bson.M{"$setOnInsert": fields}
+}
+
+func updatedFields(cacheTime *models.CacheTime, changedBy string, changedAt time.Time) bson.M {
+	return bson.M{
+		"path":            cacheTime.Path,
+		"collection_id":   cacheTime.CollectionID,
+		"release_time":    cacheTime.ReleaseTime,
+		"last_updated":    changedAt,
+		"last_updated_by": changedBy,
+	}
+}
+
+// preconditionSelector selects the cache time with the given id, provided it has one of the versions required by the
+// precondition. A cache time stored before versions were introduced has no version field, which stands for version 0.
+func preconditionSelector(id string, precondition models.Precondition) bson.M {
+	selector := bson.M{"_id": id}
+	if len(precondition.Versions) > 0 {
+		versions := bson.A{}
+		for _, version := range precondition.Versions {
+			versions = append(versions, version)
+			if version == 0 {
+				versions = append(versions, nil)
+			}
+		}
+		selector["version"] = bson.M{"$in": versions}
+	}
+	return selector
+}
+
+// DeleteCacheTime removes the cache time with the given id, provided it matches the precondition, recording the change
+// in its history and returning it
+func (m *Mongo) DeleteCacheTime(ctx context.Context, id string, precondition models.Precondition) (_ *models.CacheTimeChange, err error) {
+	ctx, span := m.startSpan(ctx, config.CacheTimesCollection, "DeleteCacheTime")
+	defer func() { tracing.End(span, err) }()
+
+	previous := &models.CacheTime{}
+	err = m.collection(config.CacheTimesCollection).FindOneAndDelete(ctx, preconditionSelector(id, precondition)).Decode(previous)
+	if err != nil {
+		if errors.Is(err, driver.ErrNoDocuments) && precondition.RequiresExisting() {
+			log.Info(ctx, "api.dataStore.DeleteCacheTime precondition failed")
+			return nil, errs.ErrPreconditionFailed
+		}
+		if errors.Is(err, driver.ErrNoDocuments) {
+			log.Info(ctx, "api.dataStore.DeleteCacheTime document not found")
+			return nil, errs.ErrCacheTimeNotFound
+		}
+		log.Error(ctx, "error targeting api.dataStore.DeleteCacheTime", err)
+		return nil, errs.ErrDataStore
+	}
+
+	change := models.NewCacheTimeChange(previous, nil, dprequest.Caller(ctx), time.Now().UTC())
+	m.recordChanges(ctx, change)
+	return change, nil
+}
+
+// UpdateCollectionReleaseTime sets the release time of every cache time in the given collection, returning the changes
+// made. The cache times are read and updated in a single transaction, so that the history records
+// exactly the cache times updated.
+func (m *Mongo) UpdateCollectionReleaseTime(ctx context.Context, collectionID string, releaseTime *time.Time) (_ []*models.CacheTimeChange, err error) {
+	ctx, span := m.startSpan(ctx, config.CacheTimesCollection, "UpdateCollectionReleaseTime")
+	defer func() { tracing.End(span, err) }()
+
+	changedBy, changedAt := dprequest.Caller(ctx), time.Now().UTC()
 	update := bson.M{
-		"$set": bson.M{"path": cacheTime.Path, "collection_id": cacheTime.CollectionID, "release_time": cacheTime.ReleaseTime},
+		"$set": bson.M{"release_time": releaseTime, "last_updated": changedAt, "last_updated_by": changedBy},
+		"$inc": bson.M{"version": 1},
 	}
-	selector := bson.M{"_id": cacheTime.ID}
+	selector := bson.M{"collection_id": collectionID}
 
-	_, err = m.Connection.Collection(m.ActualCollectionName(config.CacheTimesCollection)).UpsertOne(ctx, selector, update)
+	var previous map[string]*models.CacheTime
+	err = m.inTransaction(ctx, func(ctx context.Context) (err error) {
+		if previous, err = m.findCacheTimes(ctx, selector); err != nil {
+			return err
+		}
+		_, err = m.collection(config.CacheTimesCollection).UpdateMany(ctx, selector, update)
+		return err
+	})
+	if err != nil {
+		log.Error(ctx, "error targeting api.dataStore.UpdateCollectionReleaseTime", err)
+		return nil, errs.ErrDataStore
+	}
 
-	return err
+	changes := make([]*models.CacheTimeChange, 0, len(previous))
+	for _, id := range sortedIDs(previous) {
+		current := models.StampCacheTime(previous[id], previous[id], changedBy, changedAt)
+		current.ReleaseTime = releaseTime
+		changes = append(changes, models.NewCacheTimeChange(previous[id], current, changedBy, changedAt))
+	}
+	m.recordChanges(ctx, changes...)
+
+	return changes, nil
+}
+
+// DeleteCollectionCacheTimes removes every cache time in the given collection, returning the changes made. The history records the cache times found in the collection just before the deletion.
+func (m *Mongo) DeleteCollectionCacheTimes(ctx context.Context, collectionID string) (_ []*models.CacheTimeChange, err error) {
+	ctx, span := m.startSpan(ctx, config.CacheTimesCollection, "DeleteCollectionCacheTimes")
+	defer func() { tracing.End(span, err) }()
+
+	changes, err := m.deleteCacheTimes(ctx, bson.M{"collection_id": collectionID})
+	if err != nil {
+		log.Error(ctx, "error targeting api.dataStore.DeleteCollectionCacheTimes", err)
+		return nil, errs.ErrDataStore
+	}
+	return changes, nil
+}
+
+// DeleteCacheTimesReleasedBefore removes every cache time whose release time is before the given time, returning the
+// number of cache times deleted. The history records the cache times found just before the deletion.
+func (m *Mongo) DeleteCacheTimesReleasedBefore(ctx context.Context, before time.Time) (_ int, err error) {
+	ctx, span := m.startSpan(ctx, config.CacheTimesCollection, "DeleteCacheTimesReleasedBefore")
+	defer func() { tracing.End(span, err) }()
+
+	changes, err := m.deleteCacheTimes(ctx, bson.M{"release_time": bson.M{"$lt": before}})
+	if err != nil {
+		log.Error(ctx, "error targeting dataStore.DeleteCacheTimesReleasedBefore", err)
+		return 0, errs.ErrDataStore
+	}
+	return len(changes), nil
+}
+
+// deleteCacheTimes removes the cache times matching the selector, recording their deletion in the history, and returns
+// the changes made. The cache times are read and deleted in a single transaction, so that the history records exactly
+// the cache times deleted.
+func (m *Mongo) deleteCacheTimes(ctx context.Context, selector bson.M) ([]*models.CacheTimeChange, error) {
+	var previous map[string]*models.CacheTime
+	err := m.inTransaction(ctx, func(ctx context.Context) (err error) {
+		if previous, err = m.findCacheTimes(ctx, selector); err != nil {
+			return err
+		}
+		_, err = m.collection(config.CacheTimesCollection).DeleteMany(ctx, selector)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	changedBy, changedAt := dprequest.Caller(ctx), time.Now().UTC()
+	changes := make([]*models.CacheTimeChange, 0, len(previous))
+	for _, id := range sortedIDs(previous) {
+		changes = append(changes, models.NewCacheTimeChange(previous[id], nil, changedBy, changedAt))
+	}
+	m.recordChanges(ctx, changes...)
+
+	return changes, nil
+}
+
+func sortedIDs(cacheTimes map[string]*models.CacheTime) []string {
+	ids := make([]string, 0, len(cacheTimes))
+	for id := range cacheTimes {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids
 }
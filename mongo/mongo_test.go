@@ -0,0 +1,51 @@
+package mongo
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	errs "github.com/ONSdigital/dp-legacy-cache-api/apierrors"
+	"github.com/ONSdigital/dp-legacy-cache-api/models"
+	. "github.com/smartystreets/goconvey/convey"
+	driver "go.mongodb.org/mongo-driver/mongo"
+)
+
+func TestBulkWriteErrors(t *testing.T) {
+	Convey("Given a bulk write where some writes failed", t, func() {
+		err := driver.BulkWriteException{WriteErrors: []driver.BulkWriteError{
+			{WriteError: driver.WriteError{Index: 1, Code: 11000, Message: "E11000 duplicate key error"}},
+			{WriteError: driver.WriteError{Index: 3, Code: 2, Message: "bad value"}},
+		}}
+
+		Convey("When its errors are unpacked", func() {
+			writeErrors, ok := bulkWriteErrors(err)
+
+			Convey("Then the error of each failed write is returned by index", func() {
+				So(ok, ShouldBeTrue)
+				So(writeErrors, ShouldHaveLength, 2)
+				So(upsertError(context.Background(), &models.CacheTime{ID: "a"}, writeErrors[1]), ShouldEqual, errs.ErrPathConflict)
+				So(upsertError(context.Background(), &models.CacheTime{ID: "b"}, writeErrors[3]), ShouldEqual, errs.ErrDataStore)
+			})
+		})
+	})
+
+	Convey("Given a bulk write that succeeded", t, func() {
+		writeErrors, ok := bulkWriteErrors(nil)
+
+		Convey("Then no write failed", func() {
+			So(ok, ShouldBeTrue)
+			So(writeErrors, ShouldBeEmpty)
+		})
+	})
+
+	Convey("Given a bulk write that failed as a whole", t, func() {
+		Convey("Then its writes are not reported separately", func() {
+			_, ok := bulkWriteErrors(errors.New("connection reset"))
+			So(ok, ShouldBeFalse)
+
+			_, ok = bulkWriteErrors(driver.BulkWriteException{WriteConcernError: &driver.WriteConcernError{Code: 64}})
+			So(ok, ShouldBeFalse)
+		})
+	})
+}
@@ -0,0 +1,108 @@
+package mongo
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	errs "github.com/ONSdigital/dp-legacy-cache-api/apierrors"
+	"github.com/ONSdigital/dp-legacy-cache-api/config"
+	"github.com/ONSdigital/dp-legacy-cache-api/models"
+	"github.com/ONSdigital/dp-legacy-cache-api/tracing"
+	"github.com/ONSdigital/log.go/v2/log"
+	"go.mongodb.org/mongo-driver/bson"
+	driver "go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// RecordRelease stores a pending release, unless a release with the same id has already been recorded. As the id is
+// the primary key, only one of several instances recording the same release at the same time succeeds.
+func (m *Mongo) RecordRelease(ctx context.Context, release *models.Release) (err error) {
+	ctx, span := m.startSpan(ctx, config.CacheTimesReleasesCollection, "RecordRelease")
+	defer func() { tracing.End(span, err) }()
+
+	if _, err = m.collection(config.CacheTimesReleasesCollection).InsertOne(ctx, release); err != nil {
+		if driver.IsDuplicateKeyError(err) {
+			return errs.ErrReleaseRecorded
+		}
+		log.Error(ctx, "error targeting dataStore.RecordRelease", err)
+		return errs.ErrDataStore
+	}
+	return nil
+}
+
+// ClaimRelease records that a pending release is fired again by firedBy at firedAt. The release is only claimed if it
+// is still pending and was last fired when the given release says, so that only one of several instances claiming the
+// same release at the same time succeeds. ErrReleaseRecorded is returned otherwise.
+func (m *Mongo) ClaimRelease(ctx context.Context, release *models.Release, firedBy string, firedAt time.Time) (err error) {
+	ctx, span := m.startSpan(ctx, config.CacheTimesReleasesCollection, "ClaimRelease")
+	defer func() { tracing.End(span, err) }()
+
+	query := bson.M{"_id": release.ID, "sent_at": bson.M{"$exists": false}, "fired_at": release.FiredAt}
+	update := bson.M{"$set": bson.M{"fired_at": firedAt, "fired_by": firedBy}}
+
+	result, err := m.collection(config.CacheTimesReleasesCollection).UpdateOne(ctx, query, update)
+	if err != nil {
+		log.Error(ctx, "error targeting dataStore.ClaimRelease", err)
+		return errs.ErrDataStore
+	}
+	if result.MatchedCount == 0 {
+		return errs.ErrReleaseRecorded
+	}
+	return nil
+}
+
+// MarkReleaseSent records that the release with the given id was sent to every sink at sentAt
+func (m *Mongo) MarkReleaseSent(ctx context.Context, id string, sentAt time.Time) (err error) {
+	ctx, span := m.startSpan(ctx, config.CacheTimesReleasesCollection, "MarkReleaseSent")
+	defer func() { tracing.End(span, err) }()
+
+	update := bson.M{"$set": bson.M{"sent_at": sentAt}}
+	if _, err = m.collection(config.CacheTimesReleasesCollection).UpdateOne(ctx, bson.M{"_id": id}, update); err != nil {
+		log.Error(ctx, "error targeting dataStore.MarkReleaseSent", err)
+		return errs.ErrDataStore
+	}
+	return nil
+}
+
+// GetPendingReleases returns the releases not sent yet that were last fired no later than firedBefore, in release
+// time order
+func (m *Mongo) GetPendingReleases(ctx context.Context, firedBefore time.Time) (_ []*models.Release, err error) {
+	ctx, span := m.startSpan(ctx, config.CacheTimesReleasesCollection, "GetPendingReleases")
+	defer func() { tracing.End(span, err) }()
+
+	query := bson.M{"sent_at": bson.M{"$exists": false}, "fired_at": bson.M{"$lte": firedBefore}}
+	opts := options.Find().SetSort(bson.D{{Key: "release_time", Value: 1}, {Key: "_id", Value: 1}})
+
+	cursor, err := m.collection(config.CacheTimesReleasesCollection).Find(ctx, query, opts)
+	if err != nil {
+		log.Error(ctx, "error targeting dataStore.GetPendingReleases", err)
+		return nil, errs.ErrDataStore
+	}
+
+	results := []*models.Release{}
+	if err = cursor.All(ctx, &results); err != nil {
+		log.Error(ctx, "error targeting dataStore.GetPendingReleases", err)
+		return nil, errs.ErrDataStore
+	}
+	return results, nil
+}
+
+// GetLatestReleaseTime returns the latest release time recorded, or nil if no release is recorded
+func (m *Mongo) GetLatestReleaseTime(ctx context.Context) (_ *time.Time, err error) {
+	ctx, span := m.startSpan(ctx, config.CacheTimesReleasesCollection, "GetLatestReleaseTime")
+	defer func() { tracing.End(span, err) }()
+
+	opts := options.FindOne().SetSort(bson.D{{Key: "release_time", Value: -1}})
+
+	var release models.Release
+	err = m.collection(config.CacheTimesReleasesCollection).FindOne(ctx, bson.M{}, opts).Decode(&release)
+	switch {
+	case errors.Is(err, driver.ErrNoDocuments):
+		return nil, nil
+	case err != nil:
+		log.Error(ctx, "error targeting dataStore.GetLatestReleaseTime", err)
+		return nil, errs.ErrDataStore
+	}
+	return &release.ReleaseTime, nil
+}
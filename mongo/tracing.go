@@ -0,0 +1,22 @@
+package mongo
+
+import (
+	"context"
+
+	"github.com/ONSdigital/dp-legacy-cache-api/tracing"
+	semconv "go.opentelemetry.io/otel/semconv/v1.34.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// startSpan starts a client span for an operation on the given collection, to be ended with tracing.End
+func (m *Mongo) startSpan(ctx context.Context, collection, operation string) (context.Context, trace.Span) {
+	return tracing.Tracer().Start(ctx, "mongo."+operation,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			semconv.DBSystemNameMongoDB,
+			semconv.DBNamespace(m.Database),
+			semconv.DBCollectionName(m.ActualCollectionName(collection)),
+			semconv.DBOperationName(operation),
+		),
+	)
+}
@@ -0,0 +1,45 @@
+package mongo
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ONSdigital/dp-legacy-cache-api/config"
+	"github.com/ONSdigital/dp-legacy-cache-api/tracing"
+	mongoDriver "github.com/ONSdigital/dp-mongodb/v3/mongodb"
+	. "github.com/smartystreets/goconvey/convey"
+	"go.opentelemetry.io/otel"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	semconv "go.opentelemetry.io/otel/semconv/v1.34.0"
+)
+
+func TestStartSpan(t *testing.T) {
+	Convey("Given a mongo store with its collections configured", t, func() {
+		recorder := tracetest.NewSpanRecorder()
+		previous := otel.GetTracerProvider()
+		otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+		defer otel.SetTracerProvider(previous)
+
+		m := &Mongo{MongoDriverConfig: mongoDriver.MongoDriverConfig{
+			Database: "cachetimes",
+			Collections: map[string]string{
+				config.CacheTimesCollection:        "cachetimes",
+				config.CacheTimesHistoryCollection: "cachetimes_history",
+			},
+		}}
+
+		Convey("When a span is started for an operation on the history collection", func() {
+			_, span := m.startSpan(context.Background(), config.CacheTimesHistoryCollection, "GetCacheTimeHistory")
+			tracing.End(span, nil)
+
+			Convey("Then the span names the actual history collection and the operation", func() {
+				spans := recorder.Ended()
+				So(spans, ShouldHaveLength, 1)
+				So(spans[0].Name(), ShouldEqual, "mongo.GetCacheTimeHistory")
+				So(spans[0].Attributes(), ShouldContain, semconv.DBCollectionName("cachetimes_history"))
+				So(spans[0].Attributes(), ShouldContain, semconv.DBOperationName("GetCacheTimeHistory"))
+			})
+		})
+	})
+}
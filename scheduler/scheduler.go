@@ -0,0 +1,204 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"os"
+	"time"
+
+	errs "github.com/ONSdigital/dp-legacy-cache-api/apierrors"
+	"github.com/ONSdigital/dp-legacy-cache-api/events"
+	"github.com/ONSdigital/dp-legacy-cache-api/models"
+	"github.com/ONSdigital/log.go/v2/log"
+)
+
+// pageSize is the number of released cache times read from the store at once
+const pageSize = 1000
+
+// Store defines the store methods used by the scheduler
+type Store interface {
+	GetCacheTimes(ctx context.Context, filter models.CacheTimesFilter, offset, limit int) ([]*models.CacheTime, int, error)
+	RecordRelease(ctx context.Context, release *models.Release) error
+	ClaimRelease(ctx context.Context, release *models.Release, firedBy string, firedAt time.Time) error
+	MarkReleaseSent(ctx context.Context, id string, sentAt time.Time) error
+	GetPendingReleases(ctx context.Context, firedBefore time.Time) ([]*models.Release, error)
+	GetLatestReleaseTime(ctx context.Context) (*time.Time, error)
+}
+
+// Scheduler fires the release of every cache time whose release time is reached, sending a cache-time-released event
+// to every sink. The store is checked on every interval for release times reached within the lookback period, or since
+// the latest release recorded if that is earlier, so that releases reached while no instance was running are fired.
+// Each release is recorded in the store as pending before it is fired, which only one instance can do, so that a
+// release is fired once however many instances run the scheduler. A release is only marked as sent once every sink
+// has received it: a release left pending, because a sink failed or its instance stopped, is fired again on a later
+// run.
+type Scheduler struct {
+	store    Store
+	sinks    []events.Sink
+	interval time.Duration
+	lookback time.Duration
+	instance string
+	now      func() time.Time
+	recorded map[string]time.Time // Release times of the releases known to be recorded, by release id
+	stop     chan struct{}
+	done     chan struct{}
+}
+
+// New creates a scheduler firing releases to the given sinks
+func New(store Store, sinks []events.Sink, interval, lookback time.Duration) *Scheduler {
+	instance, err := os.Hostname()
+	if err != nil {
+		instance = "unknown"
+	}
+
+	return &Scheduler{
+		store:    store,
+		sinks:    sinks,
+		interval: interval,
+		lookback: lookback,
+		instance: instance,
+		now:      time.Now,
+		recorded: make(map[string]time.Time),
+		stop:     make(chan struct{}),
+		done:     make(chan struct{}),
+	}
+}
+
+// Start fires releases in the background until the scheduler is closed
+func (s *Scheduler) Start(ctx context.Context) {
+	go s.run(ctx)
+}
+
+// Close stops the scheduler and waits until the releases being fired have been sent, or ctx is done
+func (s *Scheduler) Close(ctx context.Context) error {
+	close(s.stop)
+	select {
+	case <-s.done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (s *Scheduler) run(ctx context.Context) {
+	defer close(s.done)
+
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	for {
+		s.fireReleases(ctx)
+		select {
+		case <-s.stop:
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// fireReleases fires the pending releases again, then the releases of the cache times whose release time was reached
+// within the lookback period or since the latest release recorded
+func (s *Scheduler) fireReleases(ctx context.Context) {
+	now := s.now().UTC()
+	s.firePendingReleases(ctx, now)
+
+	from := now.Add(-s.lookback)
+	latest, err := s.store.GetLatestReleaseTime(ctx)
+	if err != nil {
+		log.Error(ctx, "scheduler: error finding latest release", err)
+		return
+	}
+	if latest != nil && latest.Before(from) {
+		from = *latest
+	}
+	filter := models.CacheTimesFilter{ReleaseTimeAfter: &from, ReleaseTimeBefore: &now}
+
+	for id, releaseTime := range s.recorded {
+		if !releaseTime.After(from) {
+			delete(s.recorded, id)
+		}
+	}
+
+	for offset := 0; ; {
+		cacheTimes, totalCount, err := s.store.GetCacheTimes(ctx, filter, offset, pageSize)
+		if err != nil {
+			log.Error(ctx, "scheduler: error finding released cache times", err)
+			return
+		}
+		for _, cacheTime := range cacheTimes {
+			s.fireRelease(ctx, models.NewRelease(cacheTime, s.instance, now))
+		}
+
+		offset += len(cacheTimes)
+		if len(cacheTimes) == 0 || offset >= totalCount {
+			return
+		}
+	}
+}
+
+// firePendingReleases fires again the releases left pending for at least an interval, which leaves the instance that
+// last fired them the time to send them
+func (s *Scheduler) firePendingReleases(ctx context.Context, now time.Time) {
+	releases, err := s.store.GetPendingReleases(ctx, now.Add(-s.interval))
+	if err != nil {
+		log.Error(ctx, "scheduler: error finding pending releases", err)
+		return
+	}
+
+	for _, release := range releases {
+		if err := s.store.ClaimRelease(ctx, release, s.instance, now); err != nil {
+			if !errors.Is(err, errs.ErrReleaseRecorded) {
+				log.Error(ctx, "scheduler: error claiming pending release", err,
+					log.Data{"cache_time_id": release.CacheTimeID, "release_time": release.ReleaseTime})
+			}
+			continue
+		}
+		release.FiredAt = now
+		release.FiredBy = s.instance
+		s.sendRelease(ctx, release)
+	}
+}
+
+func (s *Scheduler) fireRelease(ctx context.Context, release *models.Release) {
+	if _, ok := s.recorded[release.ID]; ok {
+		return
+	}
+
+	if err := s.store.RecordRelease(ctx, release); err != nil {
+		if errors.Is(err, errs.ErrReleaseRecorded) {
+			s.recorded[release.ID] = release.ReleaseTime
+			return
+		}
+		log.Error(ctx, "scheduler: error recording release", err,
+			log.Data{"cache_time_id": release.CacheTimeID, "release_time": release.ReleaseTime})
+		return
+	}
+	s.recorded[release.ID] = release.ReleaseTime
+	s.sendRelease(ctx, release)
+}
+
+// sendRelease sends a release recorded as pending to every sink, and marks it as sent if every sink received it.
+// Otherwise, the release is left pending to be fired again on a later run.
+func (s *Scheduler) sendRelease(ctx context.Context, release *models.Release) {
+	logData := log.Data{"cache_time_id": release.CacheTimeID, "release_time": release.ReleaseTime}
+
+	event := models.NewCacheTimeReleasedEvent(release)
+	var failed bool
+	for _, sink := range s.sinks {
+		if err := sink.Send(ctx, event); err != nil {
+			log.Error(ctx, "scheduler: error sending cache time released event", err,
+				log.Data{"sink": sink.Name(), "cache_time_id": release.CacheTimeID})
+			failed = true
+		}
+	}
+	if failed {
+		log.Info(ctx, "scheduler: release left pending to be fired again", logData)
+		return
+	}
+
+	if err := s.store.MarkReleaseSent(ctx, release.ID, s.now().UTC()); err != nil {
+		log.Error(ctx, "scheduler: error marking release as sent", err, logData)
+		return
+	}
+	log.Info(ctx, "scheduler: release fired", logData)
+}
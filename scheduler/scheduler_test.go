@@ -0,0 +1,209 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ONSdigital/dp-legacy-cache-api/events"
+	"github.com/ONSdigital/dp-legacy-cache-api/memory"
+	"github.com/ONSdigital/dp-legacy-cache-api/models"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+var now = time.Date(2024, time.January, 31, 9, 30, 0, 0, time.UTC)
+
+type recordingSink struct {
+	mu     sync.Mutex
+	events []*models.CacheTimeEvent
+	err    error
+}
+
+func (s *recordingSink) Name() string {
+	return "recording"
+}
+
+func (s *recordingSink) Send(_ context.Context, event *models.CacheTimeEvent) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.err != nil {
+		return s.err
+	}
+	s.events = append(s.events, event)
+	return nil
+}
+
+func (s *recordingSink) received() []*models.CacheTimeEvent {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return append([]*models.CacheTimeEvent(nil), s.events...)
+}
+
+func newTestScheduler(store Store, sink events.Sink, instance string) *Scheduler {
+	s := New(store, []events.Sink{sink}, time.Second, time.Hour)
+	s.instance = instance
+	s.now = func() time.Time { return now }
+	return s
+}
+
+func cacheTimeReleasedAt(id string, releaseTime time.Time) *models.CacheTime {
+	return &models.CacheTime{ID: id, Path: "/economy/" + id, ReleaseTime: &releaseTime}
+}
+
+func TestFireReleases(t *testing.T) {
+	Convey("Given cache times released just now, long ago and in the future", t, func() {
+		store := memory.NewStore()
+		store.Seed(
+			cacheTimeReleasedAt("due", now.Add(-time.Minute)),
+			cacheTimeReleasedAt("old", now.Add(-2*time.Hour)),
+			cacheTimeReleasedAt("upcoming", now.Add(time.Minute)),
+			&models.CacheTime{ID: "unscheduled", Path: "/economy/unscheduled"},
+		)
+		sink := &recordingSink{}
+		scheduler := newTestScheduler(store, sink, "host-1")
+
+		Convey("When the scheduler runs twice", func() {
+			scheduler.fireReleases(context.Background())
+			scheduler.fireReleases(context.Background())
+
+			Convey("Then only the release reached within the lookback period is fired, once", func() {
+				received := sink.received()
+				So(received, ShouldHaveLength, 1)
+				So(received[0].Type, ShouldEqual, models.CacheTimeReleasedEventType)
+				So(received[0].CacheTimeID, ShouldEqual, "due")
+				So(received[0].Path, ShouldEqual, "/economy/due")
+				So(received[0].OccurredAt, ShouldEqual, now)
+			})
+
+			Convey("And the release is recorded", func() {
+				release := models.NewRelease(cacheTimeReleasedAt("due", now.Add(-time.Minute)), "host-1", now)
+				So(store.RecordRelease(context.Background(), release), ShouldNotBeNil)
+			})
+		})
+
+		Convey("When another instance runs the scheduler on the same store", func() {
+			otherSink := &recordingSink{}
+			scheduler.fireReleases(context.Background())
+			newTestScheduler(store, otherSink, "host-2").fireReleases(context.Background())
+
+			Convey("Then the release is only fired by the first instance", func() {
+				So(sink.received(), ShouldHaveLength, 1)
+				So(otherSink.received(), ShouldBeEmpty)
+			})
+		})
+
+		Convey("When the release time of a fired release is moved and reached again", func() {
+			scheduler.fireReleases(context.Background())
+			store.Seed(cacheTimeReleasedAt("due", now.Add(-time.Second)))
+			scheduler.fireReleases(context.Background())
+
+			Convey("Then the release is fired again", func() {
+				received := sink.received()
+				So(received, ShouldHaveLength, 2)
+				So(*received[1].ReleaseTime, ShouldEqual, now.Add(-time.Second))
+			})
+		})
+
+		Convey("When a sink fails to send the release", func() {
+			sink.err = errors.New("webhook unavailable")
+			scheduler.fireReleases(context.Background())
+			sink.err = nil
+
+			Convey("Then the release is left pending", func() {
+				So(sink.received(), ShouldBeEmpty)
+				pending, err := store.GetPendingReleases(context.Background(), now)
+				So(err, ShouldBeNil)
+				So(pending, ShouldHaveLength, 1)
+			})
+
+			Convey("And it is not fired again before an interval has passed", func() {
+				scheduler.fireReleases(context.Background())
+				So(sink.received(), ShouldBeEmpty)
+			})
+
+			Convey("And it is fired again on the next run, once", func() {
+				scheduler.now = func() time.Time { return now.Add(time.Second) }
+				scheduler.fireReleases(context.Background())
+				scheduler.fireReleases(context.Background())
+
+				received := sink.received()
+				So(received, ShouldHaveLength, 1)
+				So(received[0].CacheTimeID, ShouldEqual, "due")
+				So(received[0].OccurredAt, ShouldEqual, now.Add(time.Second))
+
+				pending, err := store.GetPendingReleases(context.Background(), now.Add(time.Hour))
+				So(err, ShouldBeNil)
+				So(pending, ShouldBeEmpty)
+			})
+		})
+	})
+
+	Convey("Given a release recorded by an instance that stopped before sending it", t, func() {
+		store := memory.NewStore()
+		cacheTime := cacheTimeReleasedAt("due", now.Add(-time.Minute))
+		store.Seed(cacheTime)
+		So(store.RecordRelease(context.Background(), models.NewRelease(cacheTime, "host-1", now.Add(-time.Minute))), ShouldBeNil)
+		sink := &recordingSink{}
+
+		Convey("When another instance runs the scheduler", func() {
+			newTestScheduler(store, sink, "host-2").fireReleases(context.Background())
+
+			Convey("Then the pending release is fired by that instance", func() {
+				received := sink.received()
+				So(received, ShouldHaveLength, 1)
+				So(received[0].CacheTimeID, ShouldEqual, "due")
+				So(received[0].OccurredAt, ShouldEqual, now)
+			})
+		})
+	})
+
+	Convey("Given cache times released while no instance was running, longer than the lookback period ago", t, func() {
+		store := memory.NewStore()
+		fired := cacheTimeReleasedAt("fired", now.Add(-3*time.Hour))
+		store.Seed(fired, cacheTimeReleasedAt("missed", now.Add(-2*time.Hour)))
+		release := models.NewRelease(fired, "host-1", now.Add(-3*time.Hour))
+		So(store.RecordRelease(context.Background(), release), ShouldBeNil)
+		So(store.MarkReleaseSent(context.Background(), release.ID, now.Add(-3*time.Hour)), ShouldBeNil)
+		sink := &recordingSink{}
+
+		Convey("When the scheduler runs", func() {
+			newTestScheduler(store, sink, "host-1").fireReleases(context.Background())
+
+			Convey("Then the releases reached since the latest release recorded are fired", func() {
+				received := sink.received()
+				So(received, ShouldHaveLength, 1)
+				So(received[0].CacheTimeID, ShouldEqual, "missed")
+			})
+		})
+	})
+}
+
+func TestStartAndClose(t *testing.T) {
+	Convey("Given a started scheduler with a release due", t, func() {
+		store := memory.NewStore()
+		store.Seed(cacheTimeReleasedAt("due", time.Now().Add(-time.Minute)))
+		sink := &recordingSink{}
+		scheduler := New(store, []events.Sink{sink}, time.Millisecond, time.Hour)
+		scheduler.Start(context.Background())
+
+		Convey("When it is closed after firing the release", func() {
+			So(func() bool {
+				deadline := time.Now().Add(time.Second)
+				for len(sink.received()) == 0 && time.Now().Before(deadline) {
+					time.Sleep(time.Millisecond)
+				}
+				return len(sink.received()) == 1
+			}(), ShouldBeTrue)
+			err := scheduler.Close(context.Background())
+
+			Convey("Then it stops without error", func() {
+				So(err, ShouldBeNil)
+				So(sink.received(), ShouldHaveLength, 1)
+			})
+		})
+	})
+}
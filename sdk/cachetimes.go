@@ -0,0 +1,156 @@
+package sdk
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/ONSdigital/dp-legacy-cache-api/models"
+)
+
+const (
+	cacheTimesEndpoint   = "/v1/cache-times"
+	cacheControlEndpoint = "/v1/cache-control"
+	collectionsEndpoint  = "/v1/collections"
+)
+
+// Options holds the optional pagination and filtering parameters of GetCacheTimes. Zero values are not sent, leaving
+// the API to apply its defaults.
+type Options struct {
+	Offset            int
+	Limit             int
+	CollectionID      string
+	PathPrefix        string
+	ReleaseTimeBefore *time.Time
+	ReleaseTimeAfter  *time.Time
+}
+
+func (o Options) query() url.Values {
+	query := url.Values{}
+	if o.Offset > 0 {
+		query.Set("offset", strconv.Itoa(o.Offset))
+	}
+	if o.Limit > 0 {
+		query.Set("limit", strconv.Itoa(o.Limit))
+	}
+	if o.CollectionID != "" {
+		query.Set("collection_id", o.CollectionID)
+	}
+	if o.PathPrefix != "" {
+		query.Set("path_prefix", o.PathPrefix)
+	}
+	if o.ReleaseTimeBefore != nil {
+		query.Set("release_time_before", o.ReleaseTimeBefore.Format(time.RFC3339))
+	}
+	if o.ReleaseTimeAfter != nil {
+		query.Set("release_time_after", o.ReleaseTimeAfter.Format(time.RFC3339))
+	}
+	return query
+}
+
+// GetCacheTime returns the cache time with the given id
+func (cli *Client) GetCacheTime(ctx context.Context, headers Headers, id string) (*models.CacheTime, error) {
+	var cacheTime models.CacheTime
+	if err := cli.callAPIForJSON(ctx, http.MethodGet, cacheTimesEndpoint+"/"+url.PathEscape(id), nil, headers, nil, &cacheTime); err != nil {
+		return nil, err
+	}
+	return &cacheTime, nil
+}
+
+// GetCacheTimeByPath returns the cache time of the page with the given path
+func (cli *Client) GetCacheTimeByPath(ctx context.Context, headers Headers, path string) (*models.CacheTime, error) {
+	query := url.Values{"path": []string{path}}
+
+	var cacheTime models.CacheTime
+	if err := cli.callAPIForJSON(ctx, http.MethodGet, cacheTimesEndpoint, query, headers, nil, &cacheTime); err != nil {
+		return nil, err
+	}
+	return &cacheTime, nil
+}
+
+// GetCacheTimes returns a page of cache times matching the given options
+func (cli *Client) GetCacheTimes(ctx context.Context, headers Headers, options Options) (*models.CacheTimesList, error) {
+	var list models.CacheTimesList
+	if err := cli.callAPIForJSON(ctx, http.MethodGet, cacheTimesEndpoint, options.query(), headers, nil, &list); err != nil {
+		return nil, err
+	}
+	return &list, nil
+}
+
+// PutCacheTime creates or updates the given cache time, stored under its id. Fields managed by the API, such as
+// last_updated, are not sent so that a cache time that was read can be put back as it is.
+func (cli *Client) PutCacheTime(ctx context.Context, headers Headers, cacheTime *models.CacheTime) error {
+	resp, err := cli.callAPI(ctx, http.MethodPut, cacheTimesEndpoint+"/"+url.PathEscape(cacheTime.ID), nil, headers, withoutMetadata(cacheTime))
+	if err != nil {
+		return err
+	}
+	closeResponseBody(resp)
+	return nil
+}
+
+// PutCacheTimes creates or updates the given cache times in a single batch, reporting the outcome of each one. As with
+// PutCacheTime, fields managed by the API are not sent.
+func (cli *Client) PutCacheTimes(ctx context.Context, headers Headers, cacheTimes []*models.CacheTime) (*models.BatchResult, error) {
+	body := make([]*models.CacheTime, len(cacheTimes))
+	for i, cacheTime := range cacheTimes {
+		body[i] = withoutMetadata(cacheTime)
+	}
+
+	var result models.BatchResult
+	if err := cli.callAPIForJSON(ctx, http.MethodPost, cacheTimesEndpoint+"/batch", nil, headers, body, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// withoutMetadata returns a copy of cacheTime without the fields that the API rejects because it manages them itself
+func withoutMetadata(cacheTime *models.CacheTime) *models.CacheTime {
+	copied := *cacheTime
+	copied.CreatedAt = nil
+	copied.LastUpdated = nil
+	copied.LastUpdatedBy = ""
+	copied.Version = 0
+	return &copied
+}
+
+// DeleteCacheTime removes the cache time with the given id
+func (cli *Client) DeleteCacheTime(ctx context.Context, headers Headers, id string) error {
+	resp, err := cli.callAPI(ctx, http.MethodDelete, cacheTimesEndpoint+"/"+url.PathEscape(id), nil, headers, nil)
+	if err != nil {
+		return err
+	}
+	closeResponseBody(resp)
+	return nil
+}
+
+// UpdateCollectionReleaseTime sets the release time of every cache time in a collection, returning the number of
+// cache times updated
+func (cli *Client) UpdateCollectionReleaseTime(ctx context.Context, headers Headers, collectionID string, releaseTime *time.Time) (int, error) {
+	body := models.CollectionReleaseTime{ReleaseTime: releaseTime}
+
+	var result models.BulkOperationResult
+	if err := cli.callAPIForJSON(ctx, http.MethodPut, collectionsEndpoint+"/"+url.PathEscape(collectionID)+"/release-time", nil, headers, body, &result); err != nil {
+		return 0, err
+	}
+	return result.Count, nil
+}
+
+// DeleteCollectionCacheTimes removes every cache time in a collection, returning the number of cache times deleted
+func (cli *Client) DeleteCollectionCacheTimes(ctx context.Context, headers Headers, collectionID string) (int, error) {
+	var result models.BulkOperationResult
+	if err := cli.callAPIForJSON(ctx, http.MethodDelete, collectionsEndpoint+"/"+url.PathEscape(collectionID)+"/cache-times", nil, headers, nil, &result); err != nil {
+		return 0, err
+	}
+	return result.Count, nil
+}
+
+// GetCacheControl returns the Cache-Control and Expires header values computed for the page with the given id
+func (cli *Client) GetCacheControl(ctx context.Context, headers Headers, id string) (*models.CacheControl, error) {
+	var cacheControl models.CacheControl
+	if err := cli.callAPIForJSON(ctx, http.MethodGet, cacheControlEndpoint+"/"+url.PathEscape(id), nil, headers, nil, &cacheControl); err != nil {
+		return nil, err
+	}
+	return &cacheControl, nil
+}
@@ -0,0 +1,119 @@
+package sdk
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/url"
+
+	"github.com/ONSdigital/dp-api-clients-go/v2/health"
+	"github.com/ONSdigital/dp-healthcheck/healthcheck"
+	dprequest "github.com/ONSdigital/dp-net/v3/request"
+)
+
+const service = "dp-legacy-cache-api"
+
+// Client is a typed client for the legacy cache API. Requests are made through the dp-net HTTP client, which
+// retries failed requests with exponential backoff.
+type Client struct {
+	hcCli *health.Client
+}
+
+// Headers holds the optional headers sent with a request. The service auth token is required by the publishing
+// endpoints.
+type Headers struct {
+	ServiceAuthToken string
+}
+
+// New creates a new instance of Client with a given legacy cache API URL
+func New(legacyCacheAPIURL string) *Client {
+	return &Client{
+		hcCli: health.NewClient(service, legacyCacheAPIURL),
+	}
+}
+
+// NewWithHealthClient creates a new instance of Client, reusing the URL and Clienter from the provided healthcheck
+// client
+func NewWithHealthClient(hcCli *health.Client) *Client {
+	return &Client{
+		hcCli: health.NewClientWithClienter(service, hcCli.URL, hcCli.Client),
+	}
+}
+
+// URL returns the URL used by this client
+func (cli *Client) URL() string {
+	return cli.hcCli.URL
+}
+
+// Health returns the underlying healthcheck client for this API client
+func (cli *Client) Health() *health.Client {
+	return cli.hcCli
+}
+
+// Checker calls the legacy cache API health endpoint and updates the provided CheckState accordingly
+func (cli *Client) Checker(ctx context.Context, check *healthcheck.CheckState) error {
+	return cli.hcCli.Checker(ctx, check)
+}
+
+func (h Headers) add(req *http.Request) {
+	if h.ServiceAuthToken != "" {
+		dprequest.AddServiceTokenHeader(req, h.ServiceAuthToken)
+	}
+}
+
+// callAPI sends a request to the given path, encoding body as JSON when it is not nil, and returns the response once
+// it has been checked for an error status
+func (cli *Client) callAPI(ctx context.Context, method, path string, query url.Values, headers Headers, body interface{}) (*http.Response, error) {
+	uri := cli.hcCli.URL + path
+	if len(query) > 0 {
+		uri += "?" + query.Encode()
+	}
+
+	var reqBody io.Reader
+	if body != nil {
+		b, err := json.Marshal(body)
+		if err != nil {
+			return nil, err
+		}
+		reqBody = bytes.NewReader(b)
+	}
+
+	req, err := http.NewRequest(method, uri, reqBody)
+	if err != nil {
+		return nil, err
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	headers.add(req)
+
+	resp, err := cli.hcCli.Client.Do(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		defer closeResponseBody(resp)
+		return nil, newStatusError(method, uri, resp)
+	}
+	return resp, nil
+}
+
+// callAPIForJSON sends a request and decodes the JSON response into result
+func (cli *Client) callAPIForJSON(ctx context.Context, method, path string, query url.Values, headers Headers, body, result interface{}) error {
+	resp, err := cli.callAPI(ctx, method, path, query, headers, body)
+	if err != nil {
+		return err
+	}
+	defer closeResponseBody(resp)
+
+	return json.NewDecoder(resp.Body).Decode(result)
+}
+
+func closeResponseBody(resp *http.Response) {
+	if resp.Body != nil {
+		_ = resp.Body.Close()
+	}
+}
@@ -0,0 +1,224 @@
+package sdk_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	errs "github.com/ONSdigital/dp-legacy-cache-api/apierrors"
+	"github.com/ONSdigital/dp-legacy-cache-api/models"
+	"github.com/ONSdigital/dp-legacy-cache-api/sdk"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+const (
+	testCacheID      = "374690207ce40c08d0c3b1a1869ef66e"
+	testServiceToken = "test-service-token"
+)
+
+var (
+	_ sdk.Clienter = &sdk.Client{}
+
+	testReleaseTime = time.Date(2024, time.January, 31, 1, 23, 45, 0, time.UTC)
+	testCacheTime   = &models.CacheTime{ID: testCacheID, Path: "testpath", CollectionID: "testcollection", ReleaseTime: &testReleaseTime}
+)
+
+// newTestServer starts a server that records the last request it received and answers with the given handler
+func newTestServer(handler http.HandlerFunc) (*httptest.Server, *http.Request) {
+	last := &http.Request{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		*last = *req.Clone(context.Background())
+		handler(w, req)
+	}))
+	return server, last
+}
+
+func TestGetCacheTime(t *testing.T) {
+	Convey("Given a legacy cache API that holds a cache time", t, func() {
+		server, last := newTestServer(func(w http.ResponseWriter, req *http.Request) {
+			if req.URL.Path != "/v1/cache-times/"+testCacheID {
+				w.WriteHeader(http.StatusNotFound)
+				_, _ = w.Write([]byte(`{"error":"cachetime not found"}`))
+				return
+			}
+			_ = json.NewEncoder(w).Encode(testCacheTime)
+		})
+		defer server.Close()
+		client := sdk.New(server.URL)
+
+		Convey("When the cache time is requested", func() {
+			cacheTime, err := client.GetCacheTime(context.Background(), sdk.Headers{}, testCacheID)
+
+			Convey("Then the cache time is returned", func() {
+				So(err, ShouldBeNil)
+				So(cacheTime, ShouldResemble, testCacheTime)
+				So(last.Method, ShouldEqual, http.MethodGet)
+			})
+		})
+
+		Convey("When an unknown cache time is requested", func() {
+			cacheTime, err := client.GetCacheTime(context.Background(), sdk.Headers{}, "11111111111111111111111111111111")
+
+			Convey("Then ErrCacheTimeNotFound is returned along with the status code", func() {
+				So(cacheTime, ShouldBeNil)
+				So(errors.Is(err, errs.ErrCacheTimeNotFound), ShouldBeTrue)
+
+				var statusErr *sdk.StatusError
+				So(errors.As(err, &statusErr), ShouldBeTrue)
+				So(statusErr.Status(), ShouldEqual, http.StatusNotFound)
+				So(statusErr.Message, ShouldEqual, "cachetime not found")
+			})
+		})
+	})
+
+	Convey("Given a legacy cache API that fails once before answering", t, func() {
+		var calls int32
+		server, _ := newTestServer(func(w http.ResponseWriter, req *http.Request) {
+			if atomic.AddInt32(&calls, 1) == 1 {
+				w.WriteHeader(http.StatusInternalServerError)
+				return
+			}
+			_ = json.NewEncoder(w).Encode(testCacheTime)
+		})
+		defer server.Close()
+		client := sdk.New(server.URL)
+
+		Convey("When the cache time is requested", func() {
+			cacheTime, err := client.GetCacheTime(context.Background(), sdk.Headers{}, testCacheID)
+
+			Convey("Then the request is retried and the cache time is returned", func() {
+				So(err, ShouldBeNil)
+				So(cacheTime, ShouldResemble, testCacheTime)
+				So(atomic.LoadInt32(&calls), ShouldEqual, 2)
+			})
+		})
+	})
+}
+
+func TestGetCacheTimes(t *testing.T) {
+	Convey("Given a legacy cache API that lists cache times", t, func() {
+		server, last := newTestServer(func(w http.ResponseWriter, req *http.Request) {
+			_ = json.NewEncoder(w).Encode(models.CacheTimesList{
+				Items: []*models.CacheTime{testCacheTime}, Count: 1, Offset: 10, Limit: 5, TotalCount: 11,
+			})
+		})
+		defer server.Close()
+		client := sdk.New(server.URL)
+
+		Convey("When cache times are requested with options", func() {
+			list, err := client.GetCacheTimes(context.Background(), sdk.Headers{}, sdk.Options{
+				Offset:            10,
+				Limit:             5,
+				CollectionID:      "testcollection",
+				ReleaseTimeBefore: &testReleaseTime,
+			})
+
+			Convey("Then the options are sent as query parameters and the list is returned", func() {
+				So(err, ShouldBeNil)
+				So(list.TotalCount, ShouldEqual, 11)
+				So(list.Items, ShouldResemble, []*models.CacheTime{testCacheTime})
+
+				query := last.URL.Query()
+				So(last.URL.Path, ShouldEqual, "/v1/cache-times")
+				So(query.Get("offset"), ShouldEqual, "10")
+				So(query.Get("limit"), ShouldEqual, "5")
+				So(query.Get("collection_id"), ShouldEqual, "testcollection")
+				So(query.Get("release_time_before"), ShouldEqual, "2024-01-31T01:23:45Z")
+				So(query.Has("path_prefix"), ShouldBeFalse)
+				So(query.Has("release_time_after"), ShouldBeFalse)
+			})
+		})
+	})
+}
+
+func TestPutCacheTime(t *testing.T) {
+	Convey("Given a legacy cache API that accepts cache times", t, func() {
+		var body []byte
+		server, last := newTestServer(func(w http.ResponseWriter, req *http.Request) {
+			body, _ = io.ReadAll(req.Body)
+			w.WriteHeader(http.StatusNoContent)
+		})
+		defer server.Close()
+		client := sdk.New(server.URL)
+
+		Convey("When a cache time is put with a service auth token", func() {
+			err := client.PutCacheTime(context.Background(), sdk.Headers{ServiceAuthToken: testServiceToken}, testCacheTime)
+
+			Convey("Then the cache time is sent with the service auth header", func() {
+				So(err, ShouldBeNil)
+				So(last.Method, ShouldEqual, http.MethodPut)
+				So(last.URL.Path, ShouldEqual, "/v1/cache-times/"+testCacheID)
+				So(last.Header.Get("Authorization"), ShouldEqual, "Bearer "+testServiceToken)
+
+				var sent models.CacheTime
+				So(json.Unmarshal(body, &sent), ShouldBeNil)
+				So(&sent, ShouldResemble, testCacheTime)
+			})
+		})
+
+		Convey("When a cache time that was read from the API is put back", func() {
+			readCacheTime := *testCacheTime
+			readCacheTime.CreatedAt = &testReleaseTime
+			readCacheTime.LastUpdated = &testReleaseTime
+			readCacheTime.LastUpdatedBy = "publisher@ons.gov.uk"
+			err := client.PutCacheTime(context.Background(), sdk.Headers{ServiceAuthToken: testServiceToken}, &readCacheTime)
+
+			Convey("Then the fields managed by the API are not sent", func() {
+				So(err, ShouldBeNil)
+
+				var sent models.CacheTime
+				So(json.Unmarshal(body, &sent), ShouldBeNil)
+				So(&sent, ShouldResemble, testCacheTime)
+				So(readCacheTime.LastUpdated, ShouldNotBeNil)
+			})
+		})
+	})
+
+	Convey("Given a legacy cache API that rejects cache times", t, func() {
+		server, _ := newTestServer(func(w http.ResponseWriter, req *http.Request) {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"error":"validation errors: [path field missing]"}`))
+		})
+		defer server.Close()
+		client := sdk.New(server.URL)
+
+		Convey("When a cache time is put", func() {
+			err := client.PutCacheTime(context.Background(), sdk.Headers{}, &models.CacheTime{ID: testCacheID})
+
+			Convey("Then the error given by the API is returned", func() {
+				var statusErr *sdk.StatusError
+				So(errors.As(err, &statusErr), ShouldBeTrue)
+				So(statusErr.Code, ShouldEqual, http.StatusBadRequest)
+				So(statusErr.Message, ShouldEqual, "validation errors: [path field missing]")
+				So(errors.Is(err, errs.ErrCacheTimeNotFound), ShouldBeFalse)
+			})
+		})
+	})
+}
+
+func TestDeleteCollectionCacheTimes(t *testing.T) {
+	Convey("Given a legacy cache API that deletes the cache times of a collection", t, func() {
+		server, last := newTestServer(func(w http.ResponseWriter, req *http.Request) {
+			_, _ = w.Write([]byte(`{"count":3}`))
+		})
+		defer server.Close()
+		client := sdk.New(server.URL)
+
+		Convey("When the cache times of a collection are deleted", func() {
+			count, err := client.DeleteCollectionCacheTimes(context.Background(), sdk.Headers{ServiceAuthToken: testServiceToken}, "testcollection")
+
+			Convey("Then the number of cache times deleted is returned", func() {
+				So(err, ShouldBeNil)
+				So(count, ShouldEqual, 3)
+				So(last.Method, ShouldEqual, http.MethodDelete)
+				So(last.URL.Path, ShouldEqual, "/v1/collections/testcollection/cache-times")
+			})
+		})
+	})
+}
@@ -0,0 +1,52 @@
+package sdk
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	errs "github.com/ONSdigital/dp-legacy-cache-api/apierrors"
+)
+
+// StatusError is returned when the legacy cache API responds with an unexpected status code
+type StatusError struct {
+	Method  string
+	URI     string
+	Code    int
+	Message string
+}
+
+// Error returns the status code and the error message given by the API
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("%s %s failed with status code %d: %s", e.Method, e.URI, e.Code, e.Message)
+}
+
+// Status returns the status code of the response
+func (e *StatusError) Status() int {
+	return e.Code
+}
+
+// Unwrap maps a 404 response to errs.ErrCacheTimeNotFound, so that callers can check for it with errors.Is
+func (e *StatusError) Unwrap() error {
+	if e.Code == http.StatusNotFound {
+		return errs.ErrCacheTimeNotFound
+	}
+	return nil
+}
+
+func newStatusError(method, uri string, resp *http.Response) *StatusError {
+	statusErr := &StatusError{
+		Method:  method,
+		URI:     uri,
+		Code:    resp.StatusCode,
+		Message: http.StatusText(resp.StatusCode),
+	}
+
+	var body struct {
+		Error string `json:"error"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err == nil && body.Error != "" {
+		statusErr.Message = body.Error
+	}
+	return statusErr
+}
@@ -0,0 +1,29 @@
+package sdk
+
+import (
+	"context"
+	"time"
+
+	"github.com/ONSdigital/dp-api-clients-go/v2/health"
+	"github.com/ONSdigital/dp-healthcheck/healthcheck"
+	"github.com/ONSdigital/dp-legacy-cache-api/models"
+)
+
+//go:generate moq -out mock/client.go -pkg mock . Clienter
+
+// Clienter is the interface implemented by Client, allowing consumers to mock the legacy cache API in their tests
+type Clienter interface {
+	Checker(ctx context.Context, check *healthcheck.CheckState) error
+	Health() *health.Client
+	URL() string
+
+	GetCacheTime(ctx context.Context, headers Headers, id string) (*models.CacheTime, error)
+	GetCacheTimeByPath(ctx context.Context, headers Headers, path string) (*models.CacheTime, error)
+	GetCacheTimes(ctx context.Context, headers Headers, options Options) (*models.CacheTimesList, error)
+	PutCacheTime(ctx context.Context, headers Headers, cacheTime *models.CacheTime) error
+	PutCacheTimes(ctx context.Context, headers Headers, cacheTimes []*models.CacheTime) (*models.BatchResult, error)
+	DeleteCacheTime(ctx context.Context, headers Headers, id string) error
+	UpdateCollectionReleaseTime(ctx context.Context, headers Headers, collectionID string, releaseTime *time.Time) (int, error)
+	DeleteCollectionCacheTimes(ctx context.Context, headers Headers, collectionID string) (int, error)
+	GetCacheControl(ctx context.Context, headers Headers, id string) (*models.CacheControl, error)
+}
@@ -2,12 +2,17 @@ package service
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"net/http"
 
 	"github.com/ONSdigital/dp-healthcheck/healthcheck"
 	"github.com/ONSdigital/dp-legacy-cache-api/config"
+	"github.com/ONSdigital/dp-legacy-cache-api/events"
+	"github.com/ONSdigital/dp-legacy-cache-api/memory"
 	"github.com/ONSdigital/dp-legacy-cache-api/mongo"
 	dphttp "github.com/ONSdigital/dp-net/v3/http"
+	"github.com/ONSdigital/log.go/v2/log"
 )
 
 // ExternalServiceList holds the initialiser and initialisation state of external services.
@@ -71,12 +76,65 @@ func (e *ExternalServiceList) GetMongoDB(ctx context.Context, cfg *config.Config
 	return mongoDB, nil
 }
 
-// DoGetMongoDB returns a MongoDB
+// DoGetMongoDB returns the data store selected by the store backend config, MongoDB unless the in-memory store is
+// selected. MongoDB is migrated first when configured to migrate on startup, otherwise a warning is logged if it has not
+// been migrated yet.
 func (e *Init) DoGetMongoDB(ctx context.Context, cfg *config.Config) (DataStore, error) {
+	if cfg.StoreBackend == config.StoreBackendMemory {
+		log.Warn(ctx, "using the in-memory store, data will be lost when the service stops")
+		return memory.NewStore(), nil
+	}
+	if cfg.StoreBackend != config.StoreBackendMongo {
+		return nil, fmt.Errorf("unknown store backend: %q", cfg.StoreBackend)
+	}
+
 	mongoDB, err := mongo.NewMongoStore(ctx, cfg.MongoConfig)
 	if err != nil {
 		return nil, err
 	}
 
+	if cfg.MigrateOnStartup {
+		if err = mongoDB.Migrate(ctx); err != nil {
+			if closeErr := mongoDB.Close(ctx); closeErr != nil {
+				log.Error(ctx, "error closing mongo connection", closeErr)
+			}
+			return nil, err
+		}
+		return mongoDB, nil
+	}
+
+	// The unique index on path is only created by migrating, so a path conflict is only rejected once migrated
+	hasPathIndex, err := mongoDB.HasUniquePathIndex(ctx)
+	switch {
+	case err != nil:
+		log.Error(ctx, "error checking the unique index on path", err)
+	case !hasPathIndex:
+		log.Warn(ctx, "the unique index on path is missing, cache times sharing a path are accepted until MongoDB is migrated")
+	}
+
 	return mongoDB, nil
 }
+
+// GetEventSinks creates the sinks that cache time changed events are sent to
+func (e *ExternalServiceList) GetEventSinks(ctx context.Context, cfg *config.Config) ([]events.Sink, error) {
+	return e.Init.DoGetEventSinks(ctx, cfg)
+}
+
+// DoGetEventSinks returns the event sinks listed in the config
+func (e *Init) DoGetEventSinks(_ context.Context, cfg *config.Config) ([]events.Sink, error) {
+	sinks := make([]events.Sink, 0, len(cfg.EventSinks))
+	for _, name := range cfg.EventSinks {
+		switch name {
+		case config.EventSinkLog:
+			sinks = append(sinks, events.LogSink{})
+		case config.EventSinkWebhook:
+			if cfg.EventWebhookURL == "" {
+				return nil, errors.New("EVENT_WEBHOOK_URL is required by the webhook event sink")
+			}
+			sinks = append(sinks, events.NewWebhookSink(cfg.EventWebhookURL, cfg.EventWebhookTimeout))
+		default:
+			return nil, fmt.Errorf("unknown event sink: %q", name)
+		}
+	}
+	return sinks, nil
+}
@@ -7,6 +7,8 @@ import (
 	"time"
 
 	"github.com/ONSdigital/dp-legacy-cache-api/config"
+	"github.com/ONSdigital/dp-legacy-cache-api/events"
+	"github.com/ONSdigital/dp-legacy-cache-api/memory"
 	"github.com/ONSdigital/dp-legacy-cache-api/service"
 	"github.com/ONSdigital/dp-legacy-cache-api/service/mock"
 	"github.com/gorilla/mux"
@@ -193,3 +195,82 @@ func TestGetMongoDB(t *testing.T) {
 		})
 	})
 }
+
+func TestDoGetMongoDB(t *testing.T) {
+	Convey("Given a config selecting the in-memory store backend", t, func() {
+		memoryCfg := *cfg
+		memoryCfg.StoreBackend = config.StoreBackendMemory
+
+		Convey("When DoGetMongoDB is called", func() {
+			dataStore, err := (&service.Init{}).DoGetMongoDB(ctx, &memoryCfg)
+
+			Convey("Then an in-memory store is returned", func() {
+				So(err, ShouldBeNil)
+				So(dataStore, ShouldHaveSameTypeAs, memory.NewStore())
+			})
+		})
+	})
+
+	Convey("Given a config selecting an unknown store backend", t, func() {
+		unknownCfg := *cfg
+		unknownCfg.StoreBackend = "unknown"
+
+		Convey("When DoGetMongoDB is called", func() {
+			dataStore, err := (&service.Init{}).DoGetMongoDB(ctx, &unknownCfg)
+
+			Convey("Then an error is returned", func() {
+				So(dataStore, ShouldBeNil)
+				So(err, ShouldNotBeNil)
+				So(err.Error(), ShouldEqual, `unknown store backend: "unknown"`)
+			})
+		})
+	})
+}
+
+func TestDoGetEventSinks(t *testing.T) {
+	Convey("Given a config listing the log and webhook event sinks", t, func() {
+		sinksCfg := *cfg
+		sinksCfg.EventSinks = []string{config.EventSinkLog, config.EventSinkWebhook}
+		sinksCfg.EventWebhookURL = "http://localhost:8080/cache-time-changed"
+
+		Convey("When DoGetEventSinks is called", func() {
+			sinks, err := (&service.Init{}).DoGetEventSinks(ctx, &sinksCfg)
+
+			Convey("Then both sinks are returned in order", func() {
+				So(err, ShouldBeNil)
+				So(sinks, ShouldHaveLength, 2)
+				So(sinks[0], ShouldHaveSameTypeAs, events.LogSink{})
+				So(sinks[1], ShouldHaveSameTypeAs, &events.WebhookSink{})
+			})
+		})
+	})
+
+	Convey("Given a config listing the webhook event sink without a webhook URL", t, func() {
+		sinksCfg := *cfg
+		sinksCfg.EventSinks = []string{config.EventSinkWebhook}
+		sinksCfg.EventWebhookURL = ""
+
+		Convey("When DoGetEventSinks is called", func() {
+			_, err := (&service.Init{}).DoGetEventSinks(ctx, &sinksCfg)
+
+			Convey("Then an error is returned", func() {
+				So(err, ShouldNotBeNil)
+				So(err.Error(), ShouldEqual, "EVENT_WEBHOOK_URL is required by the webhook event sink")
+			})
+		})
+	})
+
+	Convey("Given a config listing an unknown event sink", t, func() {
+		sinksCfg := *cfg
+		sinksCfg.EventSinks = []string{"unknown"}
+
+		Convey("When DoGetEventSinks is called", func() {
+			_, err := (&service.Init{}).DoGetEventSinks(ctx, &sinksCfg)
+
+			Convey("Then an error is returned", func() {
+				So(err, ShouldNotBeNil)
+				So(err.Error(), ShouldEqual, `unknown event sink: "unknown"`)
+			})
+		})
+	})
+}
@@ -3,21 +3,26 @@ package service
 import (
 	"context"
 	"net/http"
+	"time"
 
 	"github.com/ONSdigital/dp-healthcheck/healthcheck"
 	"github.com/ONSdigital/dp-legacy-cache-api/api"
 	"github.com/ONSdigital/dp-legacy-cache-api/config"
+	"github.com/ONSdigital/dp-legacy-cache-api/events"
+	"github.com/ONSdigital/dp-legacy-cache-api/scheduler"
 )
 
 //go:generate moq -out mock/initialiser.go -pkg mock . Initialiser
 //go:generate moq -out mock/server.go -pkg mock . HTTPServer
 //go:generate moq -out mock/healthCheck.go -pkg mock . HealthChecker
+//go:generate moq -out mock/store.go -pkg mock . DataStore
 
 // Initialiser defines the methods to initialise external services
 type Initialiser interface {
 	DoGetHTTPServer(bindAddr string, router http.Handler) HTTPServer
 	DoGetHealthCheck(cfg *config.Config, buildTime, gitCommit, version string) (HealthChecker, error)
 	DoGetMongoDB(ctx context.Context, cfg *config.Config) (DataStore, error)
+	DoGetEventSinks(ctx context.Context, cfg *config.Config) ([]events.Sink, error)
 }
 
 // HTTPServer defines the required methods from the HTTP server
@@ -34,7 +39,14 @@ type HealthChecker interface {
 	AddCheck(name string, checker healthcheck.Checker) (err error)
 }
 
-// DataStore includes all store functions for the API package
+// DataStore includes all store functions for the API package, the scheduler and the retention sweeper
 type DataStore interface {
 	api.DataStore
+	scheduler.Store
+	RetentionStore
+}
+
+// RetentionStore defines the store function used by the retention sweeper
+type RetentionStore interface {
+	DeleteCacheTimesReleasedBefore(ctx context.Context, before time.Time) (int, error)
 }
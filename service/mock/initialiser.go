@@ -6,6 +6,7 @@ package mock
 import (
 	"context"
 	"github.com/ONSdigital/dp-legacy-cache-api/config"
+	"github.com/ONSdigital/dp-legacy-cache-api/events"
 	"github.com/ONSdigital/dp-legacy-cache-api/service"
 	"net/http"
 	"sync"
@@ -21,6 +22,9 @@ var _ service.Initialiser = &InitialiserMock{}
 //
 //		// make and configure a mocked service.Initialiser
 //		mockedInitialiser := &InitialiserMock{
+//			DoGetEventSinksFunc: func(ctx context.Context, cfg *config.Config) ([]events.Sink, error) {
+//				panic("mock out the DoGetEventSinks method")
+//			},
 //			DoGetHTTPServerFunc: func(bindAddr string, router http.Handler) service.HTTPServer {
 //				panic("mock out the DoGetHTTPServer method")
 //			},
@@ -37,6 +41,9 @@ var _ service.Initialiser = &InitialiserMock{}
 //
 //	}
 type InitialiserMock struct {
+	// DoGetEventSinksFunc mocks the DoGetEventSinks method.
+	DoGetEventSinksFunc func(ctx context.Context, cfg *config.Config) ([]events.Sink, error)
+
 	// DoGetHTTPServerFunc mocks the DoGetHTTPServer method.
 	DoGetHTTPServerFunc func(bindAddr string, router http.Handler) service.HTTPServer
 
@@ -48,6 +55,13 @@ type InitialiserMock struct {
 
 	// calls tracks calls to the methods.
 	calls struct {
+		// DoGetEventSinks holds details about calls to the DoGetEventSinks method.
+		DoGetEventSinks []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Cfg is the cfg argument value.
+			Cfg *config.Config
+		}
 		// DoGetHTTPServer holds details about calls to the DoGetHTTPServer method.
 		DoGetHTTPServer []struct {
 			// BindAddr is the bindAddr argument value.
@@ -74,11 +88,48 @@ type InitialiserMock struct {
 			Cfg *config.Config
 		}
 	}
+	lockDoGetEventSinks  sync.RWMutex
 	lockDoGetHTTPServer  sync.RWMutex
 	lockDoGetHealthCheck sync.RWMutex
 	lockDoGetMongoDB     sync.RWMutex
 }
 
+// DoGetEventSinks calls DoGetEventSinksFunc.
+func (mock *InitialiserMock) DoGetEventSinks(ctx context.Context, cfg *config.Config) ([]events.Sink, error) {
+	if mock.DoGetEventSinksFunc == nil {
+		panic("InitialiserMock.DoGetEventSinksFunc: method is nil but Initialiser.DoGetEventSinks was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Cfg *config.Config
+	}{
+		Ctx: ctx,
+		Cfg: cfg,
+	}
+	mock.lockDoGetEventSinks.Lock()
+	mock.calls.DoGetEventSinks = append(mock.calls.DoGetEventSinks, callInfo)
+	mock.lockDoGetEventSinks.Unlock()
+	return mock.DoGetEventSinksFunc(ctx, cfg)
+}
+
+// DoGetEventSinksCalls gets all the calls that were made to DoGetEventSinks.
+// Check the length with:
+//
+//	len(mockedInitialiser.DoGetEventSinksCalls())
+func (mock *InitialiserMock) DoGetEventSinksCalls() []struct {
+	Ctx context.Context
+	Cfg *config.Config
+} {
+	var calls []struct {
+		Ctx context.Context
+		Cfg *config.Config
+	}
+	mock.lockDoGetEventSinks.RLock()
+	calls = mock.calls.DoGetEventSinks
+	mock.lockDoGetEventSinks.RUnlock()
+	return calls
+}
+
 // DoGetHTTPServer calls DoGetHTTPServerFunc.
 func (mock *InitialiserMock) DoGetHTTPServer(bindAddr string, router http.Handler) service.HTTPServer {
 	if mock.DoGetHTTPServerFunc == nil {
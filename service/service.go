@@ -5,7 +5,12 @@ import (
 	"net/http"
 
 	"github.com/ONSdigital/dp-legacy-cache-api/api"
+	"github.com/ONSdigital/dp-legacy-cache-api/cache"
 	"github.com/ONSdigital/dp-legacy-cache-api/config"
+	"github.com/ONSdigital/dp-legacy-cache-api/events"
+	"github.com/ONSdigital/dp-legacy-cache-api/metrics"
+	"github.com/ONSdigital/dp-legacy-cache-api/scheduler"
+	"github.com/ONSdigital/dp-legacy-cache-api/tracing"
 	dphandlers "github.com/ONSdigital/dp-net/v3/handlers"
 	"github.com/ONSdigital/log.go/v2/log"
 	"github.com/gorilla/mux"
@@ -21,6 +26,9 @@ type Service struct {
 	ServiceList *ExternalServiceList
 	HealthCheck HealthChecker
 	mongoDB     DataStore
+	publisher   *events.Publisher
+	scheduler   *scheduler.Scheduler
+	sweeper     *Sweeper
 }
 
 // Run the service
@@ -29,8 +37,14 @@ func Run(ctx context.Context, cfg *config.Config, serviceList *ExternalServiceLi
 
 	log.Info(ctx, "using service configuration", log.Data{"config": cfg})
 
+	serviceMetrics := metrics.New()
+
 	router := mux.NewRouter()
+	router.Use(tracing.Middleware)
+	router.Use(serviceMetrics.Middleware)
 	router.Use(ensureJSONHeaderMiddleware)
+	router.NotFoundHandler = serviceMetrics.Middleware(http.NotFoundHandler())
+	router.MethodNotAllowedHandler = serviceMetrics.Middleware(http.HandlerFunc(methodNotAllowed))
 
 	httpServer := serviceList.GetHTTPServer(cfg.BindAddr, router)
 
@@ -40,9 +54,29 @@ func Run(ctx context.Context, cfg *config.Config, serviceList *ExternalServiceLi
 		return nil, err
 	}
 
+	// Only web instances cache reads, as publishing instances must always see the latest writes
+	var dataStore api.DataStore = metrics.NewDataStore(mongoDB, serviceMetrics)
+	var cachedStore *cache.Store
+	if cfg.CacheEnabled && !cfg.IsPublishing {
+		cachedStore, err = cache.NewStore(dataStore, cfg.CacheSize, cfg.CacheTTL, cfg.CacheNotFoundTTL)
+		if err != nil {
+			log.Fatal(ctx, "failed to initialise cache", err)
+			return nil, err
+		}
+		serviceMetrics.RegisterCache(cachedStore.Stats)
+		dataStore = cachedStore
+	}
+
+	eventSinks, err := serviceList.GetEventSinks(ctx, cfg)
+	if err != nil {
+		log.Fatal(ctx, "failed to initialise event sinks", err)
+		return nil, err
+	}
+	publisher := events.NewPublisher(cfg.EventQueueSize, eventSinks...)
+
 	identityHandler := dphandlers.Identity(cfg.ZebedeeURL)
 
-	legacyCacheAPI := api.Setup(ctx, cfg.IsPublishing, router, mongoDB, identityHandler)
+	legacyCacheAPI := api.Setup(ctx, cfg, router, dataStore, publisher, identityHandler)
 
 	hc, err := serviceList.GetHealthCheck(cfg, buildTime, gitCommit, version)
 	if err != nil {
@@ -50,13 +84,35 @@ func Run(ctx context.Context, cfg *config.Config, serviceList *ExternalServiceLi
 		return nil, err
 	}
 
-	if err := registerCheckers(ctx, hc, mongoDB); err != nil {
+	// The release scheduler and the retention sweeper use the store directly, as they never read through the cache
+	releaseStore := metrics.NewReleaseDataStore(mongoDB, serviceMetrics)
+
+	var sweeper *Sweeper
+	if cfg.RetentionPeriod > 0 {
+		sweeper = NewSweeper(releaseStore, cfg.RetentionPeriod, cfg.RetentionSweepInterval)
+	}
+
+	if err := registerCheckers(ctx, hc, mongoDB, cachedStore, sweeper); err != nil {
 		return nil, errors.Wrap(err, "unable to register checkers")
 	}
 
 	router.StrictSlash(true).Path("/health").HandlerFunc(hc.Handler)
+	router.Path("/metrics").Handler(serviceMetrics.Handler())
 	hc.Start(ctx)
 
+	var releaseScheduler *scheduler.Scheduler
+	if cfg.SchedulerEnabled {
+		if len(eventSinks) == 0 {
+			log.Warn(ctx, "the release scheduler is enabled without any event sink to fire releases to")
+		}
+		releaseScheduler = scheduler.New(releaseStore, eventSinks, cfg.SchedulerInterval, cfg.SchedulerLookback)
+		releaseScheduler.Start(ctx)
+	}
+
+	if sweeper != nil {
+		sweeper.Start(ctx)
+	}
+
 	// Run the HTTP server in a new go-routine
 	go func() {
 		if err := httpServer.ListenAndServe(); err != nil {
@@ -72,12 +128,23 @@ func Run(ctx context.Context, cfg *config.Config, serviceList *ExternalServiceLi
 		ServiceList: serviceList,
 		Server:      httpServer,
 		mongoDB:     mongoDB,
+		publisher:   publisher,
+		scheduler:   releaseScheduler,
+		sweeper:     sweeper,
 	}, nil
 }
 
+// methodNotAllowed responds to requests matching the path of a route but none of its methods, as the router does by
+// default
+func methodNotAllowed(w http.ResponseWriter, _ *http.Request) {
+	w.WriteHeader(http.StatusMethodNotAllowed)
+}
+
+// ensureJSONHeaderMiddleware sets the content type of responses to JSON by default. Handlers negotiating another
+// representation with the client set their own content type, replacing it.
 func ensureJSONHeaderMiddleware(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		w.Header().Add("Content-Type", "application/json")
+		w.Header().Set("Content-Type", "application/json")
 		next.ServeHTTP(w, r)
 	})
 }
@@ -105,6 +172,30 @@ func (svc *Service) Close(ctx context.Context) error {
 			hasShutdownError = true
 		}
 
+		// stop firing releases, which reads from MongoDB
+		if svc.scheduler != nil {
+			if err := svc.scheduler.Close(ctx); err != nil {
+				log.Error(ctx, "failed to stop the release scheduler", err)
+				hasShutdownError = true
+			}
+		}
+
+		// stop deleting past release times, which writes to MongoDB
+		if svc.sweeper != nil {
+			if err := svc.sweeper.Close(ctx); err != nil {
+				log.Error(ctx, "failed to stop the retention sweeper", err)
+				hasShutdownError = true
+			}
+		}
+
+		// send the events of the last requests before closing the connections they were read from
+		if svc.publisher != nil {
+			if err := svc.publisher.Close(ctx); err != nil {
+				log.Error(ctx, "failed to send queued events", err)
+				hasShutdownError = true
+			}
+		}
+
 		if svc.mongoDB != nil {
 			if err := svc.mongoDB.Close(ctx); err != nil {
 				log.Error(ctx, "failed to close MongoDB connection", err)
@@ -136,6 +227,8 @@ func (svc *Service) Close(ctx context.Context) error {
 func registerCheckers(ctx context.Context,
 	healthChecker HealthChecker,
 	dataStore DataStore,
+	cachedStore *cache.Store,
+	sweeper *Sweeper,
 ) (err error) {
 	hasErrors := false
 
@@ -144,6 +237,20 @@ func registerCheckers(ctx context.Context,
 		log.Error(ctx, "error adding check for mongo db", err)
 	}
 
+	if cachedStore != nil {
+		if err = healthChecker.AddCheck("Cache", cachedStore.StatsChecker); err != nil {
+			hasErrors = true
+			log.Error(ctx, "error adding check for cache", err)
+		}
+	}
+
+	if sweeper != nil {
+		if err = healthChecker.AddCheck("Retention sweeper", sweeper.Checker); err != nil {
+			hasErrors = true
+			log.Error(ctx, "error adding check for retention sweeper", err)
+		}
+	}
+
 	if hasErrors {
 		return errors.New("Error(s) registering checkers for healthcheck")
 	}
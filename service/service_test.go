@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"net/http/httptest"
 	"sync"
 	"testing"
 	"time"
@@ -11,6 +12,8 @@ import (
 	"github.com/ONSdigital/dp-healthcheck/healthcheck"
 
 	"github.com/ONSdigital/dp-legacy-cache-api/config"
+	"github.com/ONSdigital/dp-legacy-cache-api/events"
+	"github.com/ONSdigital/dp-legacy-cache-api/models"
 	"github.com/ONSdigital/dp-legacy-cache-api/service"
 	"github.com/ONSdigital/dp-legacy-cache-api/service/mock"
 
@@ -43,6 +46,10 @@ var funcDoGetMongoDBErr = func(ctx context.Context, cfg *config.Config) (service
 	return nil, errMongoDB
 }
 
+var funcDoGetEventSinksNone = func(ctx context.Context, cfg *config.Config) ([]events.Sink, error) {
+	return nil, nil
+}
+
 func TestRun(t *testing.T) {
 	Convey("Having a set of mocked dependencies", t, func() {
 		cfg, err := config.Get()
@@ -51,6 +58,7 @@ func TestRun(t *testing.T) {
 		hcMock := &mock.HealthCheckerMock{
 			AddCheckFunc: func(name string, checker healthcheck.Checker) error { return nil },
 			StartFunc:    func(ctx context.Context) {},
+			HandlerFunc:  func(w http.ResponseWriter, req *http.Request) {},
 		}
 
 		serverWg := &sync.WaitGroup{}
@@ -90,6 +98,7 @@ func TestRun(t *testing.T) {
 			initMock := &mock.InitialiserMock{
 				DoGetHTTPServerFunc:  funcDoGetHTTPServerNil,
 				DoGetMongoDBFunc:     funcDoGetMongoDBErr,
+				DoGetEventSinksFunc:  funcDoGetEventSinksNone,
 				DoGetHealthCheckFunc: funcDoGetHealthcheckOk,
 			}
 			svcErrors := make(chan error, 1)
@@ -109,6 +118,7 @@ func TestRun(t *testing.T) {
 				DoGetHTTPServerFunc:  funcDoGetHTTPServerNil,
 				DoGetHealthCheckFunc: funcDoGetHealthcheckErr,
 				DoGetMongoDBFunc:     funcDoGetMongoDBOk,
+				DoGetEventSinksFunc:  funcDoGetEventSinksNone,
 			}
 			svcErrors := make(chan error, 1)
 			svcList := service.NewServiceList(initMock)
@@ -133,7 +143,8 @@ func TestRun(t *testing.T) {
 				DoGetHealthCheckFunc: func(cfg *config.Config, buildTime string, gitCommit string, version string) (service.HealthChecker, error) {
 					return hcMockAddFail, nil
 				},
-				DoGetMongoDBFunc: funcDoGetMongoDBOk,
+				DoGetMongoDBFunc:    funcDoGetMongoDBOk,
+				DoGetEventSinksFunc: funcDoGetEventSinksNone,
 			}
 			svcErrors := make(chan error, 1)
 			svcList := service.NewServiceList(initMock)
@@ -153,11 +164,12 @@ func TestRun(t *testing.T) {
 				DoGetHTTPServerFunc:  funcDoGetHTTPServer,
 				DoGetHealthCheckFunc: funcDoGetHealthcheckOk,
 				DoGetMongoDBFunc:     funcDoGetMongoDBOk,
+				DoGetEventSinksFunc:  funcDoGetEventSinksNone,
 			}
 			svcErrors := make(chan error, 1)
 			svcList := service.NewServiceList(initMock)
 			serverWg.Add(1)
-			_, err := service.Run(ctx, cfg, svcList, testBuildTime, testGitCommit, testVersion, svcErrors)
+			svc, err := service.Run(ctx, cfg, svcList, testBuildTime, testGitCommit, testVersion, svcErrors)
 
 			Convey("Then service Run succeeds and all the flags are set", func() {
 				So(err, ShouldBeNil)
@@ -174,6 +186,71 @@ func TestRun(t *testing.T) {
 				serverWg.Wait() // Wait for HTTP server go-routine to finish
 				So(len(serverMock.ListenAndServeCalls()), ShouldEqual, 1)
 			})
+
+			Convey("The metrics endpoint serves the request metrics", func() {
+				serverWg.Wait() // Wait for HTTP server go-routine to finish
+				svc.Router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/health", http.NoBody))
+
+				w := httptest.NewRecorder()
+				svc.Router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/metrics", http.NoBody))
+
+				So(w.Code, ShouldEqual, http.StatusOK)
+				So(w.Body.String(), ShouldContainSubstring, `dp_legacy_cache_api_http_requests_total{code="200",method="GET",route="/health"} 1`)
+			})
+		})
+
+		Convey("Given that all dependencies are successfully initialised and caching is enabled in web mode", func() {
+			cachedCfg := *cfg
+			cachedCfg.CacheEnabled = true
+			cachedCfg.IsPublishing = false
+
+			initMock := &mock.InitialiserMock{
+				DoGetHTTPServerFunc:  funcDoGetHTTPServer,
+				DoGetHealthCheckFunc: funcDoGetHealthcheckOk,
+				DoGetMongoDBFunc:     funcDoGetMongoDBOk,
+				DoGetEventSinksFunc:  funcDoGetEventSinksNone,
+			}
+			svcErrors := make(chan error, 1)
+			svcList := service.NewServiceList(initMock)
+			serverWg.Add(1)
+			_, err := service.Run(ctx, &cachedCfg, svcList, testBuildTime, testGitCommit, testVersion, svcErrors)
+
+			Convey("Then service Run succeeds and the cache checker is registered", func() {
+				So(err, ShouldBeNil)
+				So(len(hcMock.AddCheckCalls()), ShouldEqual, 2)
+				So(hcMock.AddCheckCalls()[1].Name, ShouldEqual, "Cache")
+				serverWg.Wait() // Wait for HTTP server go-routine to finish
+			})
+		})
+
+		Convey("Given that all dependencies are successfully initialised and a retention period is set", func() {
+			retentionCfg := *cfg
+			retentionCfg.RetentionPeriod = 30 * 24 * time.Hour
+
+			initMock := &mock.InitialiserMock{
+				DoGetHTTPServerFunc:  funcDoGetHTTPServer,
+				DoGetHealthCheckFunc: funcDoGetHealthcheckOk,
+				DoGetMongoDBFunc: func(ctx context.Context, cfg *config.Config) (service.DataStore, error) {
+					return &mock.DataStoreMock{
+						CloseFunc: func(ctx context.Context) error { return nil },
+						DeleteCacheTimesReleasedBeforeFunc: func(ctx context.Context, before time.Time) (int, error) {
+							return 0, nil
+						},
+					}, nil
+				},
+				DoGetEventSinksFunc: funcDoGetEventSinksNone,
+			}
+			svcErrors := make(chan error, 1)
+			svcList := service.NewServiceList(initMock)
+			serverWg.Add(1)
+			_, err := service.Run(ctx, &retentionCfg, svcList, testBuildTime, testGitCommit, testVersion, svcErrors)
+
+			Convey("Then service Run succeeds and the retention sweeper checker is registered", func() {
+				So(err, ShouldBeNil)
+				So(len(hcMock.AddCheckCalls()), ShouldEqual, 2)
+				So(hcMock.AddCheckCalls()[1].Name, ShouldEqual, "Retention sweeper")
+				serverWg.Wait() // Wait for HTTP server go-routine to finish
+			})
 		})
 
 		Convey("Given that all dependencies are successfully initialised but the http server fails", func() {
@@ -182,6 +259,7 @@ func TestRun(t *testing.T) {
 				DoGetHealthCheckFunc: funcDoGetHealthcheckOk,
 				DoGetHTTPServerFunc:  funcDoGetFailingHTTPServer,
 				DoGetMongoDBFunc:     funcDoGetMongoDBOk,
+				DoGetEventSinksFunc:  funcDoGetEventSinksNone,
 			}
 			svcErrors := make(chan error, 1)
 			svcList := service.NewServiceList(initMock)
@@ -245,6 +323,7 @@ func TestClose(t *testing.T) {
 				DoGetMongoDBFunc: func(ctx context.Context, cfg *config.Config) (service.DataStore, error) {
 					return mongoDBMock, nil
 				},
+				DoGetEventSinksFunc: funcDoGetEventSinksNone,
 			}
 
 			svcErrors := make(chan error, 1)
@@ -275,6 +354,7 @@ func TestClose(t *testing.T) {
 				DoGetMongoDBFunc: func(ctx context.Context, cfg *config.Config) (service.DataStore, error) {
 					return mongoDBMock, nil
 				},
+				DoGetEventSinksFunc: funcDoGetEventSinksNone,
 			}
 
 			svcErrors := make(chan error, 1)
@@ -289,6 +369,41 @@ func TestClose(t *testing.T) {
 			So(len(mongoDBMock.CloseCalls()), ShouldEqual, 1)
 		})
 
+		Convey("Closing a service running the release scheduler stops it before closing MongoDB", func() {
+			schedulerCfg := *cfg
+			schedulerCfg.SchedulerEnabled = true
+			mongoDBMock.GetCacheTimesFunc = func(ctx context.Context, filter models.CacheTimesFilter, offset, limit int) ([]*models.CacheTime, int, error) {
+				return []*models.CacheTime{}, 0, nil
+			}
+			mongoDBMock.GetPendingReleasesFunc = func(ctx context.Context, firedBefore time.Time) ([]*models.Release, error) {
+				return []*models.Release{}, nil
+			}
+			mongoDBMock.GetLatestReleaseTimeFunc = func(ctx context.Context) (*time.Time, error) {
+				return nil, nil
+			}
+
+			initMock := &mock.InitialiserMock{
+				DoGetHTTPServerFunc: func(bindAddr string, router http.Handler) service.HTTPServer { return serverMock },
+				DoGetHealthCheckFunc: func(cfg *config.Config, buildTime string, gitCommit string, version string) (service.HealthChecker, error) {
+					return hcMock, nil
+				},
+				DoGetMongoDBFunc: func(ctx context.Context, cfg *config.Config) (service.DataStore, error) {
+					return mongoDBMock, nil
+				},
+				DoGetEventSinksFunc: funcDoGetEventSinksNone,
+			}
+
+			svcErrors := make(chan error, 1)
+			svcList := service.NewServiceList(initMock)
+			svc, err = service.Run(ctx, &schedulerCfg, svcList, testBuildTime, testGitCommit, testVersion, svcErrors)
+			So(err, ShouldBeNil)
+
+			err = svc.Close(context.Background())
+			So(err, ShouldBeNil)
+			So(mongoDBMock.GetCacheTimesCalls(), ShouldNotBeEmpty)
+			So(len(mongoDBMock.CloseCalls()), ShouldEqual, 1)
+		})
+
 		Convey("If service times out while shutting down, the Close operation fails with the expected error", func() {
 			cfg.GracefulShutdownTimeout = 1 * time.Millisecond
 			timeoutServerMock := &mock.HTTPServerMock{
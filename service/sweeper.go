@@ -0,0 +1,113 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/ONSdigital/dp-healthcheck/healthcheck"
+	dprequest "github.com/ONSdigital/dp-net/v3/request"
+	"github.com/ONSdigital/log.go/v2/log"
+)
+
+// sweeperCaller is the identity recorded in the history of the cache times deleted by the sweeper
+const sweeperCaller = "retention-sweeper"
+
+// Sweeper deletes the cache times whose release time is older than the retention period, as the proxy has no use for
+// release times long past. The deletions are recorded in the history of the cache times, which keeps their last value.
+type Sweeper struct {
+	store    RetentionStore
+	period   time.Duration
+	interval time.Duration
+	now      func() time.Time
+
+	mu        sync.RWMutex
+	lastSweep time.Time
+	lastCount int
+	lastErr   error
+
+	stop chan struct{}
+	done chan struct{}
+}
+
+// NewSweeper creates a sweeper deleting the cache times released more than period ago, every interval
+func NewSweeper(store RetentionStore, period, interval time.Duration) *Sweeper {
+	return &Sweeper{
+		store:    store,
+		period:   period,
+		interval: interval,
+		now:      time.Now,
+		stop:     make(chan struct{}),
+		done:     make(chan struct{}),
+	}
+}
+
+// Start sweeps in the background until the sweeper is closed
+func (s *Sweeper) Start(ctx context.Context) {
+	go s.run(dprequest.SetCaller(ctx, sweeperCaller))
+}
+
+// Close stops the sweeper and waits until the sweep in progress completes, or ctx is done
+func (s *Sweeper) Close(ctx context.Context) error {
+	close(s.stop)
+	select {
+	case <-s.done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (s *Sweeper) run(ctx context.Context) {
+	defer close(s.done)
+
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	for {
+		_, _ = s.Sweep(ctx)
+		select {
+		case <-s.stop:
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Sweep deletes the cache times released more than the retention period ago, returning the number deleted
+func (s *Sweeper) Sweep(ctx context.Context) (int, error) {
+	sweptAt := s.now().UTC()
+	before := sweptAt.Add(-s.period)
+
+	count, err := s.store.DeleteCacheTimesReleasedBefore(ctx, before)
+
+	s.mu.Lock()
+	s.lastSweep, s.lastCount, s.lastErr = sweptAt, count, err
+	s.mu.Unlock()
+
+	logData := log.Data{"released_before": before, "deleted": count}
+	if err != nil {
+		log.Error(ctx, "retention sweep failed", err, logData)
+		return 0, err
+	}
+	log.Info(ctx, "retention sweep completed", logData)
+	return count, nil
+}
+
+// Checker reports the outcome of the last sweep, warning when it failed
+func (s *Sweeper) Checker(_ context.Context, state *healthcheck.CheckState) error {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	switch {
+	case s.lastSweep.IsZero():
+		return state.Update(healthcheck.StatusOK, "no retention sweep has run yet", 0)
+	case s.lastErr != nil:
+		message := fmt.Sprintf("retention sweep at %s failed: %s", s.lastSweep.Format(time.RFC3339), s.lastErr)
+		return state.Update(healthcheck.StatusWarning, message, 0)
+	default:
+		message := fmt.Sprintf("retention sweep at %s deleted %d cache times", s.lastSweep.Format(time.RFC3339), s.lastCount)
+		return state.Update(healthcheck.StatusOK, message, 0)
+	}
+}
@@ -0,0 +1,78 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ONSdigital/dp-healthcheck/healthcheck"
+	"github.com/ONSdigital/dp-legacy-cache-api/service"
+	"github.com/ONSdigital/dp-legacy-cache-api/service/mock"
+	dprequest "github.com/ONSdigital/dp-net/v3/request"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestSweeper(t *testing.T) {
+	Convey("Given a sweeper keeping cache times for a day after their release", t, func() {
+		storeMock := &mock.DataStoreMock{
+			DeleteCacheTimesReleasedBeforeFunc: func(ctx context.Context, before time.Time) (int, error) {
+				return 3, nil
+			},
+		}
+		sweeper := service.NewSweeper(storeMock, 24*time.Hour, time.Hour)
+
+		Convey("When no sweep has run yet", func() {
+			state := healthcheck.NewCheckState("Retention sweeper")
+			So(sweeper.Checker(context.Background(), state), ShouldBeNil)
+
+			Convey("Then the sweeper is healthy", func() {
+				So(state.Status(), ShouldEqual, healthcheck.StatusOK)
+				So(state.Message(), ShouldEqual, "no retention sweep has run yet")
+			})
+		})
+
+		Convey("When a sweep runs", func() {
+			start := time.Now()
+			count, err := sweeper.Sweep(context.Background())
+			state := healthcheck.NewCheckState("Retention sweeper")
+			So(sweeper.Checker(context.Background(), state), ShouldBeNil)
+
+			Convey("Then the cache times released more than a day ago are deleted and counted", func() {
+				So(err, ShouldBeNil)
+				So(count, ShouldEqual, 3)
+				So(storeMock.DeleteCacheTimesReleasedBeforeCalls(), ShouldHaveLength, 1)
+				before := storeMock.DeleteCacheTimesReleasedBeforeCalls()[0].Before
+				So(before, ShouldHappenOnOrBetween, start.Add(-24*time.Hour), time.Now().Add(-24*time.Hour))
+				So(state.Status(), ShouldEqual, healthcheck.StatusOK)
+				So(state.Message(), ShouldEndWith, "deleted 3 cache times")
+			})
+		})
+
+		Convey("When a sweep fails", func() {
+			storeMock.DeleteCacheTimesReleasedBeforeFunc = func(ctx context.Context, before time.Time) (int, error) {
+				return 0, errors.New("mongo unavailable")
+			}
+			_, err := sweeper.Sweep(context.Background())
+			state := healthcheck.NewCheckState("Retention sweeper")
+			So(sweeper.Checker(context.Background(), state), ShouldBeNil)
+
+			Convey("Then the error is returned and reported as a warning", func() {
+				So(err, ShouldNotBeNil)
+				So(state.Status(), ShouldEqual, healthcheck.StatusWarning)
+				So(state.Message(), ShouldEndWith, "failed: mongo unavailable")
+			})
+		})
+
+		Convey("When the sweeper is started and closed", func() {
+			sweeper.Start(context.Background())
+			err := sweeper.Close(context.Background())
+
+			Convey("Then it has swept once as the retention sweeper", func() {
+				So(err, ShouldBeNil)
+				So(storeMock.DeleteCacheTimesReleasedBeforeCalls(), ShouldHaveLength, 1)
+				So(dprequest.Caller(storeMock.DeleteCacheTimesReleasedBeforeCalls()[0].Ctx), ShouldEqual, "retention-sweeper")
+			})
+		})
+	})
+}
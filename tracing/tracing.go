@@ -0,0 +1,86 @@
+package tracing
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	errs "github.com/ONSdigital/dp-legacy-cache-api/apierrors"
+	"github.com/ONSdigital/dp-legacy-cache-api/config"
+	"github.com/gorilla/mux"
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.34.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const tracerName = "github.com/ONSdigital/dp-legacy-cache-api"
+
+// Setup installs the W3C trace context propagator and a global tracer provider exporting spans with the exporter
+// selected in cfg. With no exporter the global no-op tracer provider is kept, so incoming trace context is still
+// propagated but no spans are recorded. The returned function flushes any pending spans and stops the provider.
+func Setup(ctx context.Context, cfg *config.Config) (shutdown func(context.Context) error, err error) {
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+
+	var exporter sdktrace.SpanExporter
+	switch cfg.OTelTracesExporter {
+	case config.TracesExporterNone:
+		return func(context.Context) error { return nil }, nil
+	case config.TracesExporterStdout:
+		exporter, err = stdouttrace.New(stdouttrace.WithPrettyPrint())
+	case config.TracesExporterOTLP:
+		exporter, err = otlptracehttp.New(ctx, otlptracehttp.WithEndpointURL(cfg.OTelExporterOTLPEndpoint))
+	default:
+		return nil, fmt.Errorf("unknown traces exporter: %q", cfg.OTelTracesExporter)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := resource.Merge(resource.Default(), resource.NewWithAttributes(semconv.SchemaURL, semconv.ServiceName(cfg.OTelServiceName)))
+	if err != nil {
+		return nil, err
+	}
+
+	tracerProvider := sdktrace.NewTracerProvider(sdktrace.WithBatcher(exporter), sdktrace.WithResource(res))
+	otel.SetTracerProvider(tracerProvider)
+
+	return tracerProvider.Shutdown, nil
+}
+
+// Tracer returns the tracer used for the spans of the service
+func Tracer() trace.Tracer {
+	return otel.Tracer(tracerName)
+}
+
+// Middleware is a router middleware starting a server span for each request, continuing any trace context in the
+// request headers. Spans are named after the path template of the matched route.
+func Middleware(next http.Handler) http.Handler {
+	return otelhttp.NewHandler(next, "", otelhttp.WithSpanNameFormatter(spanName))
+}
+
+func spanName(_ string, req *http.Request) string {
+	if route := mux.CurrentRoute(req); route != nil {
+		if template, err := route.GetPathTemplate(); err == nil {
+			return req.Method + " " + template
+		}
+	}
+	return req.Method
+}
+
+// End records err on span, unless it is a cache time not being found or not matching a precondition which are expected
+// outcomes, and ends the span
+func End(span trace.Span, err error) {
+	if err != nil && !errors.Is(err, errs.ErrCacheTimeNotFound) && !errors.Is(err, errs.ErrPreconditionFailed) {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
@@ -0,0 +1,136 @@
+package tracing
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	errs "github.com/ONSdigital/dp-legacy-cache-api/apierrors"
+	"github.com/ONSdigital/dp-legacy-cache-api/config"
+	"github.com/gorilla/mux"
+	. "github.com/smartystreets/goconvey/convey"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	testTraceID    = "4bf92f3577b34da6a3ce929d0e0e4736"
+	testParentSpan = "00f067aa0ba902b7"
+)
+
+var ctx = context.Background()
+
+// useSpanRecorder installs a global tracer provider recording ended spans, restoring the previous provider when the
+// test ends
+func useSpanRecorder(t *testing.T) *tracetest.SpanRecorder {
+	recorder := tracetest.NewSpanRecorder()
+	previous := otel.GetTracerProvider()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	t.Cleanup(func() { otel.SetTracerProvider(previous) })
+	return recorder
+}
+
+func TestSetup(t *testing.T) {
+	Convey("Given a config", t, func() {
+		cfg := &config.Config{
+			OTelServiceName:          "dp-legacy-cache-api",
+			OTelExporterOTLPEndpoint: "http://localhost:4318",
+		}
+		previous := otel.GetTracerProvider()
+		defer otel.SetTracerProvider(previous)
+
+		Convey("When tracing is set up with no exporter", func() {
+			cfg.OTelTracesExporter = config.TracesExporterNone
+			shutdown, err := Setup(ctx, cfg)
+
+			Convey("Then the global tracer provider is kept and shutting down succeeds", func() {
+				So(err, ShouldBeNil)
+				So(otel.GetTracerProvider(), ShouldEqual, previous)
+				So(shutdown(ctx), ShouldBeNil)
+			})
+		})
+
+		Convey("When tracing is set up with the stdout exporter", func() {
+			cfg.OTelTracesExporter = config.TracesExporterStdout
+			shutdown, err := Setup(ctx, cfg)
+
+			Convey("Then an SDK tracer provider is installed and shutting down succeeds", func() {
+				So(err, ShouldBeNil)
+				So(otel.GetTracerProvider(), ShouldHaveSameTypeAs, &sdktrace.TracerProvider{})
+				So(shutdown(ctx), ShouldBeNil)
+			})
+		})
+
+		Convey("When tracing is set up with an unknown exporter", func() {
+			cfg.OTelTracesExporter = "jaeger"
+			shutdown, err := Setup(ctx, cfg)
+
+			Convey("Then an error is returned", func() {
+				So(err, ShouldNotBeNil)
+				So(err.Error(), ShouldEqual, `unknown traces exporter: "jaeger"`)
+				So(shutdown, ShouldBeNil)
+			})
+		})
+	})
+}
+
+func TestMiddleware(t *testing.T) {
+	recorder := useSpanRecorder(t)
+	Setup(ctx, &config.Config{OTelTracesExporter: config.TracesExporterNone}) //nolint:errcheck // no exporter to fail
+
+	Convey("Given a router using the tracing middleware", t, func() {
+		var handlerSpan trace.SpanContext
+		router := mux.NewRouter()
+		router.Use(Middleware)
+		router.HandleFunc("/v1/cache-times/{id}", func(w http.ResponseWriter, req *http.Request) {
+			handlerSpan = trace.SpanContextFromContext(req.Context())
+		}).Methods(http.MethodGet)
+
+		Convey("When a request with W3C trace context is made", func() {
+			req := httptest.NewRequest(http.MethodGet, "/v1/cache-times/374690207ce40c08d0c3b1a1869ef66e", http.NoBody)
+			req.Header.Set("traceparent", "00-"+testTraceID+"-"+testParentSpan+"-01")
+			router.ServeHTTP(httptest.NewRecorder(), req)
+
+			Convey("Then a server span named after the route continues the incoming trace", func() {
+				spans := recorder.Ended()
+				So(spans, ShouldNotBeEmpty)
+				span := spans[len(spans)-1]
+				So(span.Name(), ShouldEqual, "GET /v1/cache-times/{id}")
+				So(span.SpanKind(), ShouldEqual, trace.SpanKindServer)
+				So(span.SpanContext().TraceID().String(), ShouldEqual, testTraceID)
+				So(span.Parent().SpanID().String(), ShouldEqual, testParentSpan)
+
+				Convey("And the span is in the context of the handler", func() {
+					So(handlerSpan.SpanID(), ShouldEqual, span.SpanContext().SpanID())
+				})
+			})
+		})
+	})
+}
+
+func TestEnd(t *testing.T) {
+	recorder := useSpanRecorder(t)
+
+	Convey("Given spans ending with different outcomes", t, func() {
+		_, failed := Tracer().Start(ctx, "failed")
+		End(failed, errs.ErrDataStore)
+		_, notFound := Tracer().Start(ctx, "not found")
+		End(notFound, errs.ErrCacheTimeNotFound)
+		_, succeeded := Tracer().Start(ctx, "succeeded")
+		End(succeeded, nil)
+
+		Convey("Then only the span of the failure has an error status", func() {
+			statuses := map[string]codes.Code{}
+			for _, span := range recorder.Ended() {
+				statuses[span.Name()] = span.Status().Code
+			}
+			So(statuses["failed"], ShouldEqual, codes.Error)
+			So(statuses["not found"], ShouldEqual, codes.Unset)
+			So(statuses["succeeded"], ShouldEqual, codes.Unset)
+		})
+	})
+}